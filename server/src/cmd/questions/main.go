@@ -0,0 +1,219 @@
+// server/src/cmd/questions/main.go
+// 問題バンクを操作するコマンドラインツールです。
+//
+//	go run ./cmd/questions verify [-timeout 10s] [-memory 512] [-json] ../mock/mock.json
+//	go run ./cmd/questions export -format markdown [-o questions.md] ../mock/mock.json
+//	go run ./cmd/questions import [-into ../mock/mock.json] questions.yaml
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"server/src/internal/feature/quiz/codec"
+	"server/src/internal/feature/quiz/service"
+	"server/src/internal/feature/quiz/types"
+	"server/src/internal/feature/quiz/verifier"
+	"strings"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "verify":
+		os.Exit(runVerify(os.Args[2:]))
+	case "export":
+		os.Exit(runExport(os.Args[2:]))
+	case "import":
+		os.Exit(runImport(os.Args[2:]))
+	default:
+		usage()
+		os.Exit(2)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: questions verify [-timeout 10s] [-memory 512] [-unsandboxed] [-json] <file>")
+	fmt.Fprintf(os.Stderr, "       questions export [-format %s] [-o <file>] <file>\n", strings.Join(codec.Names(), "|"))
+	fmt.Fprintln(os.Stderr, "       questions import [-into <bank file>] <file>")
+}
+
+// runVerify は問題のコード片を実行して答えを検証します。
+// 不一致または実行エラーがあった場合は終了コード1を返します。
+func runVerify(args []string) int {
+	fs := flag.NewFlagSet("verify", flag.ExitOnError)
+	timeout := fs.Duration("timeout", verifier.DefaultOptions.Timeout, "コンパイル・実行それぞれの制限時間")
+	memory := fs.Int("memory", verifier.DefaultOptions.MemoryLimitMB, "実行時のメモリ上限（MB、0で無制限）")
+	unsandboxed := fs.Bool("unsandboxed", false, "サンドボックスを使わずに実行する（ユーザー名前空間を使えない環境向け。信頼できる問題のみ）")
+	asJSON := fs.Bool("json", false, "結果をJSONで出力する")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		usage()
+		return 2
+	}
+
+	questions, err := service.LoadQuestions(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: cannot load questions: %v\n", err)
+		return 1
+	}
+
+	v := verifier.New(verifier.Options{Timeout: *timeout, MemoryLimitMB: *memory, Unsandboxed: *unsandboxed})
+	results := v.VerifyAll(context.Background(), questions)
+
+	failed := 0
+	for _, result := range results {
+		if result.Status == verifier.StatusMismatch || result.Status == verifier.StatusError {
+			failed++
+		}
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(results)
+	} else {
+		for _, result := range results {
+			fmt.Printf("[%s] %s", result.Status, result.QuestionID)
+			switch result.Status {
+			case verifier.StatusMismatch:
+				fmt.Printf("\n    expected: %q\n    actual:   %q", result.Expected, result.Actual)
+			case verifier.StatusSkipped, verifier.StatusError:
+				fmt.Printf(" (%s)", result.Detail)
+			}
+			fmt.Println()
+		}
+		fmt.Printf("%d questions, %d failed\n", len(results), failed)
+	}
+
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// runExport は問題バンクを指定した形式に変換して出力します。
+// -o を指定した場合、-format を省略するとそのファイルの拡張子から形式を判定します。
+func runExport(args []string) int {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	format := fs.String("format", "", "出力形式（"+strings.Join(codec.Names(), ", ")+"）")
+	output := fs.String("o", "", "出力先のファイル（省略時は標準出力）")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		usage()
+		return 2
+	}
+
+	questions, err := service.LoadQuestions(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: cannot load questions: %v\n", err)
+		return 1
+	}
+	if err := writeQuestions(*output, *format, questions); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// runImport は問題ファイルを読み込んで検証し、問題バンクに取り込みます。
+// -into を指定した場合は、そのファイルの同じIDの問題を上書きし、新しい問題を末尾に追加します。
+// 省略した場合はJSONとして標準出力に書き出します。
+func runImport(args []string) int {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	into := fs.String("into", "", "取り込み先の問題バンクのファイル")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		usage()
+		return 2
+	}
+
+	imported, err := service.LoadQuestions(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: cannot load questions: %v\n", err)
+		return 1
+	}
+	if *into == "" {
+		if err := writeQuestions("", "json", imported); err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	bank, err := service.LoadQuestions(*into)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: cannot load %s: %v\n", *into, err)
+		return 1
+	}
+	merged, created, updated := mergeQuestions(bank, imported)
+	if err := writeQuestions(*into, "", merged); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		return 1
+	}
+	fmt.Printf("%d created, %d updated\n", created, updated)
+	return 0
+}
+
+// mergeQuestions は bank の同じIDの問題を imported の問題で置き換え、新しい問題を末尾に追加します。
+func mergeQuestions(bank, imported []types.Question) ([]types.Question, int, int) {
+	index := make(map[string]int, len(bank))
+	for i, q := range bank {
+		index[q.ID] = i
+	}
+
+	merged := append([]types.Question(nil), bank...)
+	created, updated := 0, 0
+	for _, q := range imported {
+		if i, ok := index[q.ID]; ok {
+			merged[i] = q
+			updated++
+			continue
+		}
+		index[q.ID] = len(merged)
+		merged = append(merged, q)
+		created++
+	}
+	return merged, created, updated
+}
+
+// writeQuestions は問題をファイルまたは標準出力に書き出します。
+// format が空の場合はファイルの拡張子から形式を判定します。
+func writeQuestions(path, format string, questions []types.Question) error {
+	var (
+		c   codec.Codec
+		err error
+	)
+	switch {
+	case format != "":
+		c, err = codec.Lookup(format)
+	case path != "":
+		c, err = codec.ForFile(path)
+	default:
+		c, err = codec.Lookup("json")
+	}
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if path != "" {
+		file, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+	return c.Encode(w, questions)
+}
@@ -4,10 +4,13 @@ package main
 import (
 	"log"
 	"server/src/internal/database"
+	"server/src/internal/feature/daily"
+	"server/src/internal/feature/practice"
 	"server/src/internal/feature/quiz"
 	"server/src/internal/feature/quiz/service" // serviceをインポート
 	"server/src/internal/feature/quiz/websocket"
 	"server/src/internal/feature/room"
+	"server/src/internal/feature/tournament"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
@@ -42,9 +45,18 @@ func main() {
     api.GET("/", func(c echo.Context) error {
         return c.String(200, "OK")
     })
-	room.RegisterRoutes(api.Group("/room"), db)
+	// ルームを削除したら進行中のゲームのタイマーも止める
+	room.RegisterRoutes(api.Group("/room"), db, quizSvc.CloseRoom)
 	// quiz.RegisterRoutes に quizSvc を渡す
 	quiz.RegisterRoutes(api.Group("/quiz"), hub, quizSvc)
+	// 問題バンクの管理API（ADMIN_TOKEN による認証が必要）
+	quiz.RegisterAdminRoutes(api.Group("/admin"), db, quizSvc)
+	// デイリーチャレンジ（DAILY_CHALLENGE_SECRET が必要）
+	daily.RegisterRoutes(api.Group("/daily"), db, hub, quizSvc)
+	// ソロ練習（間隔反復）
+	practice.RegisterRoutes(api.Group("/practice"), db, quizSvc)
+	// トーナメント（複数ルームにまたがる1対1の対戦）
+	tournament.RegisterRoutes(api.Group("/tournaments"), db, quizSvc)
 
 	log.Println("Server starting on port 8080...")
 	if err := e.Start(":8080"); err != nil {
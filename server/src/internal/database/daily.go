@@ -0,0 +1,119 @@
+// backend/src/internal/database/daily.go
+package database
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	dailytypes "server/src/internal/feature/daily/types"
+)
+
+// ErrItemExists は条件付き書き込みで、同じキーの項目が既に存在した場合のエラーです。
+var ErrItemExists = errors.New("item already exists")
+
+// dailyTableName はデイリーチャレンジの挑戦記録を保存するテーブル名を返します。
+// テーブルのキーはパーティションキー date、ソートキー user_id です。
+func dailyTableName() string {
+	if name := os.Getenv("DYNAMO_DAILY_TABLE"); name != "" {
+		return name
+	}
+	return "quiz_daily_attempts" // デフォルト名
+}
+
+// CreateDailyAttempt は挑戦記録を新規作成します。同じ日・同じユーザーの記録がある場合は ErrItemExists を返します。
+func (h *DBHandler) CreateDailyAttempt(attempt *dailytypes.Attempt) error {
+	item, err := attributevalue.MarshalMap(attempt)
+	if err != nil {
+		return err
+	}
+
+	_, err = h.client.PutItem(context.TODO(), &dynamodb.PutItemInput{
+		TableName:           aws.String(dailyTableName()),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(user_id)"),
+	})
+	var conditionFailed *types.ConditionalCheckFailedException
+	if errors.As(err, &conditionFailed) {
+		return ErrItemExists
+	}
+	return err
+}
+
+// WriteDailyAttempt は挑戦記録を上書きします。
+func (h *DBHandler) WriteDailyAttempt(attempt *dailytypes.Attempt) error {
+	item, err := attributevalue.MarshalMap(attempt)
+	if err != nil {
+		return err
+	}
+
+	_, err = h.client.PutItem(context.TODO(), &dynamodb.PutItemInput{
+		TableName: aws.String(dailyTableName()),
+		Item:      item,
+	})
+	return err
+}
+
+// ReadDailyAttempt は挑戦記録を1件取得します。存在しない場合は nil を返します。
+func (h *DBHandler) ReadDailyAttempt(date, userID string) (*dailytypes.Attempt, error) {
+	resp, err := h.client.GetItem(context.TODO(), &dynamodb.GetItemInput{
+		TableName: aws.String(dailyTableName()),
+		Key: map[string]types.AttributeValue{
+			"date":    &types.AttributeValueMemberS{Value: date},
+			"user_id": &types.AttributeValueMemberS{Value: userID},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Item == nil {
+		return nil, nil
+	}
+
+	var attempt dailytypes.Attempt
+	if err := attributevalue.UnmarshalMap(resp.Item, &attempt); err != nil {
+		return nil, err
+	}
+	return &attempt, nil
+}
+
+// ListDailyAttempts は指定した日のすべての挑戦記録を取得します。
+func (h *DBHandler) ListDailyAttempts(date string) ([]dailytypes.Attempt, error) {
+	var attempts []dailytypes.Attempt
+	paginator := dynamodb.NewQueryPaginator(h.client, &dynamodb.QueryInput{
+		TableName:                aws.String(dailyTableName()),
+		KeyConditionExpression:   aws.String("#date = :date"),
+		ExpressionAttributeNames: map[string]string{"#date": "date"},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":date": &types.AttributeValueMemberS{Value: date},
+		},
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, err
+		}
+		var items []dailytypes.Attempt
+		if err := attributevalue.UnmarshalListOfMaps(page.Items, &items); err != nil {
+			return nil, err
+		}
+		attempts = append(attempts, items...)
+	}
+	return attempts, nil
+}
+
+// DeleteDailyAttempt は挑戦記録を削除します。
+func (h *DBHandler) DeleteDailyAttempt(date, userID string) error {
+	_, err := h.client.DeleteItem(context.TODO(), &dynamodb.DeleteItemInput{
+		TableName: aws.String(dailyTableName()),
+		Key: map[string]types.AttributeValue{
+			"date":    &types.AttributeValueMemberS{Value: date},
+			"user_id": &types.AttributeValueMemberS{Value: userID},
+		},
+	})
+	return err
+}
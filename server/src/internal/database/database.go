@@ -3,6 +3,7 @@ package database
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 
@@ -81,6 +82,26 @@ func (h *DBHandler) WriteDB(room *roomtypes.Room) error {
 }
 
 
+// UpdateRoomGameState はルームのゲームの状態のみを更新します。ルームが存在しない場合は何もしません。
+func (h *DBHandler) UpdateRoomGameState(roomID, gameState string) error {
+	_, err := h.client.UpdateItem(context.TODO(), &dynamodb.UpdateItemInput{
+		TableName: aws.String(h.tableName),
+		Key: map[string]types.AttributeValue{
+			"room_id": &types.AttributeValueMemberS{Value: roomID},
+		},
+		UpdateExpression:    aws.String("SET game_state = :state"),
+		ConditionExpression: aws.String("attribute_exists(room_id)"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":state": &types.AttributeValueMemberS{Value: gameState},
+		},
+	})
+	var conditionFailed *types.ConditionalCheckFailedException
+	if errors.As(err, &conditionFailed) {
+		return nil // 削除されたルームを作り直さない
+	}
+	return err
+}
+
 func (h *DBHandler) DeleteRoom(roomID string) error {
 	_, err := h.client.DeleteItem(context.TODO(), &dynamodb.DeleteItemInput{
 		TableName: aws.String(h.tableName),
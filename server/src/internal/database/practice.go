@@ -0,0 +1,83 @@
+// backend/src/internal/database/practice.go
+package database
+
+import (
+	"context"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	practicetypes "server/src/internal/feature/practice/types"
+)
+
+// practiceTableName はソロ練習の学習記録を保存するテーブル名を返します。
+// テーブルのキーはパーティションキー user_id、ソートキー question_id です。
+func practiceTableName() string {
+	if name := os.Getenv("DYNAMO_PRACTICE_TABLE"); name != "" {
+		return name
+	}
+	return "quiz_practice_cards" // デフォルト名
+}
+
+// WritePracticeCard は学習記録を保存します（既存の記録は上書きします）。
+func (h *DBHandler) WritePracticeCard(card *practicetypes.Card) error {
+	item, err := attributevalue.MarshalMap(card)
+	if err != nil {
+		return err
+	}
+
+	_, err = h.client.PutItem(context.TODO(), &dynamodb.PutItemInput{
+		TableName: aws.String(practiceTableName()),
+		Item:      item,
+	})
+	return err
+}
+
+// ReadPracticeCard は学習記録を1件取得します。存在しない場合は nil を返します。
+func (h *DBHandler) ReadPracticeCard(userID, questionID string) (*practicetypes.Card, error) {
+	resp, err := h.client.GetItem(context.TODO(), &dynamodb.GetItemInput{
+		TableName: aws.String(practiceTableName()),
+		Key: map[string]types.AttributeValue{
+			"user_id":     &types.AttributeValueMemberS{Value: userID},
+			"question_id": &types.AttributeValueMemberS{Value: questionID},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Item == nil {
+		return nil, nil
+	}
+
+	var card practicetypes.Card
+	if err := attributevalue.UnmarshalMap(resp.Item, &card); err != nil {
+		return nil, err
+	}
+	return &card, nil
+}
+
+// ListPracticeCards は指定したユーザーのすべての学習記録を取得します。
+func (h *DBHandler) ListPracticeCards(userID string) ([]practicetypes.Card, error) {
+	var cards []practicetypes.Card
+	paginator := dynamodb.NewQueryPaginator(h.client, &dynamodb.QueryInput{
+		TableName:              aws.String(practiceTableName()),
+		KeyConditionExpression: aws.String("user_id = :user_id"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":user_id": &types.AttributeValueMemberS{Value: userID},
+		},
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, err
+		}
+		var items []practicetypes.Card
+		if err := attributevalue.UnmarshalListOfMaps(page.Items, &items); err != nil {
+			return nil, err
+		}
+		cards = append(cards, items...)
+	}
+	return cards, nil
+}
@@ -0,0 +1,74 @@
+// backend/src/internal/database/question.go
+package database
+
+import (
+	"context"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	quiztypes "server/src/internal/feature/quiz/types"
+)
+
+// questionItem は問題をDynamoDBに保存する際の形式です。
+type questionItem struct {
+	QuestionID string             `dynamodbav:"question_id"`
+	Question   quiztypes.Question `dynamodbav:"question"`
+}
+
+// questionTableName は問題を保存するテーブル名を返します。
+func questionTableName() string {
+	if name := os.Getenv("DYNAMO_QUESTION_TABLE"); name != "" {
+		return name
+	}
+	return "quiz_questions" // デフォルト名
+}
+
+// ListQuestions は保存されているすべての問題を取得
+func (h *DBHandler) ListQuestions() ([]quiztypes.Question, error) {
+	var questions []quiztypes.Question
+	paginator := dynamodb.NewScanPaginator(h.client, &dynamodb.ScanInput{
+		TableName: aws.String(questionTableName()),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, err
+		}
+		var items []questionItem
+		if err := attributevalue.UnmarshalListOfMaps(page.Items, &items); err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			questions = append(questions, item.Question)
+		}
+	}
+	return questions, nil
+}
+
+// WriteQuestion は問題を保存（Put）
+func (h *DBHandler) WriteQuestion(question *quiztypes.Question) error {
+	item, err := attributevalue.MarshalMap(questionItem{QuestionID: question.ID, Question: *question})
+	if err != nil {
+		return err
+	}
+
+	_, err = h.client.PutItem(context.TODO(), &dynamodb.PutItemInput{
+		TableName: aws.String(questionTableName()),
+		Item:      item,
+	})
+	return err
+}
+
+// DeleteQuestion は問題を削除
+func (h *DBHandler) DeleteQuestion(id string) error {
+	_, err := h.client.DeleteItem(context.TODO(), &dynamodb.DeleteItemInput{
+		TableName: aws.String(questionTableName()),
+		Key: map[string]types.AttributeValue{
+			"question_id": &types.AttributeValueMemberS{Value: id},
+		},
+	})
+	return err
+}
@@ -0,0 +1,78 @@
+// backend/src/internal/database/tournament.go
+package database
+
+import (
+	"context"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	tournamenttypes "server/src/internal/feature/tournament/types"
+)
+
+// tournamentTableName はトーナメントを保存するテーブル名を返します。
+// テーブルのキーはパーティションキー tournament_id です。
+func tournamentTableName() string {
+	if name := os.Getenv("DYNAMO_TOURNAMENT_TABLE"); name != "" {
+		return name
+	}
+	return "quiz_tournaments" // デフォルト名
+}
+
+// WriteTournament はトーナメントを保存します（既存のトーナメントは上書きします）。
+func (h *DBHandler) WriteTournament(t *tournamenttypes.Tournament) error {
+	item, err := attributevalue.MarshalMap(t)
+	if err != nil {
+		return err
+	}
+
+	_, err = h.client.PutItem(context.TODO(), &dynamodb.PutItemInput{
+		TableName: aws.String(tournamentTableName()),
+		Item:      item,
+	})
+	return err
+}
+
+// ReadTournament はトーナメントを1件取得します。存在しない場合は nil を返します。
+func (h *DBHandler) ReadTournament(id string) (*tournamenttypes.Tournament, error) {
+	resp, err := h.client.GetItem(context.TODO(), &dynamodb.GetItemInput{
+		TableName: aws.String(tournamentTableName()),
+		Key: map[string]types.AttributeValue{
+			"tournament_id": &types.AttributeValueMemberS{Value: id},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Item == nil {
+		return nil, nil
+	}
+
+	var t tournamenttypes.Tournament
+	if err := attributevalue.UnmarshalMap(resp.Item, &t); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// ListTournaments はすべてのトーナメントを取得します。
+func (h *DBHandler) ListTournaments() ([]tournamenttypes.Tournament, error) {
+	var tournaments []tournamenttypes.Tournament
+	paginator := dynamodb.NewScanPaginator(h.client, &dynamodb.ScanInput{
+		TableName: aws.String(tournamentTableName()),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, err
+		}
+		var items []tournamenttypes.Tournament
+		if err := attributevalue.UnmarshalListOfMaps(page.Items, &items); err != nil {
+			return nil, err
+		}
+		tournaments = append(tournaments, items...)
+	}
+	return tournaments, nil
+}
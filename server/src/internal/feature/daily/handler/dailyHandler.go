@@ -0,0 +1,83 @@
+// server/src/internal/feature/daily/handler/dailyHandler.go
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"server/src/internal/feature/daily/service"
+	"server/src/internal/feature/daily/types"
+
+	"github.com/labstack/echo/v4"
+)
+
+// DailyHandler はデイリーチャレンジのリクエストを処理します。
+type DailyHandler struct {
+	service *service.DailyService
+}
+
+func NewDailyHandler(svc *service.DailyService) *DailyHandler {
+	return &DailyHandler{service: svc}
+}
+
+// GetChallenge は GET /daily のリクエストを処理します。
+// userId クエリパラメータを指定すると、そのプレイヤーの挑戦記録も返します。
+func (h *DailyHandler) GetChallenge(c echo.Context) error {
+	challenge, err := h.service.Challenge(c.QueryParam("userId"))
+	if err != nil {
+		return dailyErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, challenge)
+}
+
+// CreateAttempt は POST /daily/attempts のリクエストを処理します。
+// 今日の挑戦権を確保し、挑戦用ルームのIDを返します。
+func (h *DailyHandler) CreateAttempt(c echo.Context) error {
+	req := new(types.AttemptRequest)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+	attempt, err := h.service.StartAttempt(req.UserID)
+	if err != nil {
+		return dailyErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusCreated, attempt)
+}
+
+// StartAttempt は POST /daily/attempts/start のリクエストを処理します。
+// 挑戦用ルームに接続した後に呼び出すと、ゲームが始まります。
+func (h *DailyHandler) StartAttempt(c echo.Context) error {
+	req := new(types.AttemptRequest)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+	attempt, err := h.service.BeginGame(req.UserID)
+	if err != nil {
+		return dailyErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, attempt)
+}
+
+// GetLeaderboard は GET /daily/leaderboard のリクエストを処理します。
+// date クエリパラメータ（YYYY-MM-DD）で過去の日のランキングも取得できます。
+func (h *DailyHandler) GetLeaderboard(c echo.Context) error {
+	leaderboard, err := h.service.Leaderboard(c.QueryParam("date"))
+	if err != nil {
+		return dailyErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, leaderboard)
+}
+
+func dailyErrorResponse(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, service.ErrUserIDRequired), errors.Is(err, service.ErrInvalidDate):
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	case errors.Is(err, service.ErrAttemptNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	case errors.Is(err, service.ErrAlreadyAttempted), errors.Is(err, service.ErrAttemptStarted), errors.Is(err, service.ErrPlayerNotInRoom):
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	case errors.Is(err, service.ErrDailyDisabled):
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": err.Error()})
+	default:
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+}
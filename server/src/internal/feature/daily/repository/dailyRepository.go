@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"server/src/internal/database"
+	"server/src/internal/feature/daily/types"
+)
+
+type DailyRepository struct {
+	db *database.DBHandler
+}
+
+func NewDailyRepository(db *database.DBHandler) *DailyRepository {
+	return &DailyRepository{db: db}
+}
+
+// Create は挑戦記録を作成（同じ日・同じユーザーの記録がある場合は database.ErrItemExists）
+func (r *DailyRepository) Create(attempt *types.Attempt) error {
+	return r.db.CreateDailyAttempt(attempt)
+}
+
+// Save は挑戦記録を上書き
+func (r *DailyRepository) Save(attempt *types.Attempt) error {
+	return r.db.WriteDailyAttempt(attempt)
+}
+
+// Find は挑戦記録を取得（存在しない場合は nil）
+func (r *DailyRepository) Find(date, userID string) (*types.Attempt, error) {
+	return r.db.ReadDailyAttempt(date, userID)
+}
+
+// FindByDate は指定した日のすべての挑戦記録を取得
+func (r *DailyRepository) FindByDate(date string) ([]types.Attempt, error) {
+	return r.db.ListDailyAttempts(date)
+}
+
+// Delete は挑戦記録を削除
+func (r *DailyRepository) Delete(date, userID string) error {
+	return r.db.DeleteDailyAttempt(date, userID)
+}
@@ -0,0 +1,35 @@
+// server/src/internal/feature/daily/route.go
+package daily
+
+import (
+	"log"
+	"os"
+	"server/src/internal/database"
+	"server/src/internal/feature/daily/handler"
+	"server/src/internal/feature/daily/repository"
+	"server/src/internal/feature/daily/service"
+	quizservice "server/src/internal/feature/quiz/service"
+	"server/src/internal/feature/quiz/websocket"
+	roomrepository "server/src/internal/feature/room/repository"
+	roomservice "server/src/internal/feature/room/service"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RegisterRoutes はデイリーチャレンジ機能の依存関係を解決し、ルートを登録します。
+// 挑戦用のルームは通常のルームと同じく room 機能で作成し、ゲームの進行は QuizService に任せます。
+func RegisterRoutes(g *echo.Group, db *database.DBHandler, hub *websocket.RoomHub, quizSvc *quizservice.QuizService) {
+	if os.Getenv("DAILY_CHALLENGE_SECRET") == "" {
+		log.Println("warning: DAILY_CHALLENGE_SECRET is not set; daily challenge is disabled")
+	}
+
+	rooms := roomservice.NewRoomService(roomrepository.NewRoomRepository(db))
+	repo := repository.NewDailyRepository(db)
+	svc := service.NewDailyService(repo, rooms, hub, quizSvc)
+	h := handler.NewDailyHandler(svc)
+
+	g.GET("", h.GetChallenge)
+	g.POST("/attempts", h.CreateAttempt)
+	g.POST("/attempts/start", h.StartAttempt)
+	g.GET("/leaderboard", h.GetLeaderboard)
+}
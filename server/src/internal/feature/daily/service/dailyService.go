@@ -0,0 +1,278 @@
+// server/src/internal/feature/daily/service/dailyService.go
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"server/src/internal/database"
+	"server/src/internal/feature/daily/repository"
+	"server/src/internal/feature/daily/types"
+	quizservice "server/src/internal/feature/quiz/service"
+	quiztypes "server/src/internal/feature/quiz/types"
+	"server/src/internal/feature/quiz/websocket"
+	roomservice "server/src/internal/feature/room/service"
+	roomtypes "server/src/internal/feature/room/types"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	DAILY_DATE_FORMAT    = "2006-01-02"
+	DAILY_QUESTION_COUNT = 5
+	DAILY_ROOM_OWNER     = "daily" // 挑戦用ルームの管理者（ゲームは BeginGame からのみ開始でき、ホストの操作は無効）
+)
+
+// DailyService はデイリーチャレンジを担当します。
+//
+// その日の問題は日付とサーバーの秘密鍵（DAILY_CHALLENGE_SECRET）から決まるシードで選ぶため、
+// 同じ日に挑戦するプレイヤーには同じ問題が同じ順番で出題されます（日付はUTCで切り替わります）。
+// 各プレイヤーは1日1回だけ、1人用のルームで通常のゲームと同じ流れで挑戦します。
+type DailyService struct {
+	repo   *repository.DailyRepository
+	rooms  *roomservice.RoomService
+	hub    *websocket.RoomHub
+	quiz   *quizservice.QuizService
+	secret []byte
+
+	active map[string]types.Attempt // 進行中の挑戦（Key: ルームID）
+	mu     sync.Mutex
+}
+
+// NewDailyService は新しいサービスインスタンスを生成し、ゲーム終了時に結果を記録するよう登録します。
+func NewDailyService(repo *repository.DailyRepository, rooms *roomservice.RoomService, hub *websocket.RoomHub, quiz *quizservice.QuizService) *DailyService {
+	s := &DailyService{
+		repo:   repo,
+		rooms:  rooms,
+		hub:    hub,
+		quiz:   quiz,
+		secret: []byte(os.Getenv("DAILY_CHALLENGE_SECRET")),
+		active: make(map[string]types.Attempt),
+	}
+	quiz.OnGameOver(s.recordResult)
+	return s
+}
+
+// today は現在のチャレンジの日付を返します。
+func today() string {
+	return time.Now().UTC().Format(DAILY_DATE_FORMAT)
+}
+
+// Seed はその日の問題を決めるシードを返します。秘密鍵を知らなければ事前に問題を予測できません。
+func (s *DailyService) Seed(date string) int64 {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte("daily\x00" + date))
+	seed := int64(binary.BigEndian.Uint64(mac.Sum(nil)) & quizservice.MAX_GAME_SEED)
+	if seed == 0 {
+		seed = 1 // 0 は「ランダムに決める」を意味するため使用しない
+	}
+	return seed
+}
+
+// Challenge は今日のチャレンジの情報を返します。userID を指定した場合はそのプレイヤーの挑戦記録も含めます。
+func (s *DailyService) Challenge(userID string) (*types.Challenge, error) {
+	if len(s.secret) == 0 {
+		return nil, ErrDailyDisabled
+	}
+
+	date := today()
+	resetsAt, _ := time.Parse(DAILY_DATE_FORMAT, date)
+	challenge := &types.Challenge{
+		Date:          date,
+		QuestionCount: DAILY_QUESTION_COUNT,
+		ResetsAt:      resetsAt.AddDate(0, 0, 1),
+	}
+	if userID != "" {
+		attempt, err := s.repo.Find(date, userID)
+		if err != nil {
+			return nil, err
+		}
+		challenge.Attempt = attempt
+	}
+	return challenge, nil
+}
+
+// StartAttempt は今日の挑戦権を確保し、挑戦用の1人用ルームを作成します。
+// プレイヤーはルームに WebSocket で接続した後、BeginGame でゲームを開始します。
+func (s *DailyService) StartAttempt(userID string) (*types.Attempt, error) {
+	if len(s.secret) == 0 {
+		return nil, ErrDailyDisabled
+	}
+	if userID == "" {
+		return nil, ErrUserIDRequired
+	}
+
+	date := today()
+	attempt := &types.Attempt{
+		Date:      date,
+		UserID:    userID,
+		Status:    types.AttemptReserved,
+		StartedAt: time.Now().UTC(),
+	}
+	if err := s.repo.Create(attempt); err != nil {
+		if errors.Is(err, database.ErrItemExists) {
+			return nil, ErrAlreadyAttempted
+		}
+		return nil, err
+	}
+
+	room, err := s.rooms.CreateRoom(&roomtypes.RoomCreationRequest{
+		HostID:   userID,
+		Settings: s.roomSettings(date),
+		Owner:    DAILY_ROOM_OWNER,
+	})
+	if err != nil {
+		// ルームを作成できなかった場合は挑戦権を戻す
+		if delErr := s.repo.Delete(date, userID); delErr != nil {
+			log.Printf("error: cannot release daily attempt for %s: %v", userID, delErr)
+		}
+		return nil, fmt.Errorf("cannot create challenge room: %w", err)
+	}
+
+	attempt.RoomID = room.RoomID
+	if err := s.repo.Save(attempt); err != nil {
+		return nil, err
+	}
+	return attempt, nil
+}
+
+// roomSettings はデイリーチャレンジ用のルーム設定です。全員が同じ条件で挑戦できるよう固定します。
+func (s *DailyService) roomSettings(date string) roomtypes.Settings {
+	return roomtypes.Settings{
+		Language:       quizservice.LANGUAGE_RANDOM,
+		QuestionCount:  DAILY_QUESTION_COUNT,
+		AnswerMode:     string(quiztypes.AnswerModeEveryone),
+		Scoring:        []string{"time_decay"}, // 同点を減らすため、早く答えるほど高得点にする
+		ShuffleChoices: string(quiztypes.ShuffleGame),
+		Seed:           s.Seed(date),
+	}
+}
+
+// BeginGame は確保した挑戦のゲームを開始します。開始できるのは1回だけです。
+func (s *DailyService) BeginGame(userID string) (*types.Attempt, error) {
+	if len(s.secret) == 0 {
+		return nil, ErrDailyDisabled
+	}
+	if userID == "" {
+		return nil, ErrUserIDRequired
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	attempt, err := s.repo.Find(today(), userID)
+	if err != nil {
+		return nil, err
+	}
+	if attempt == nil || attempt.RoomID == "" {
+		return nil, ErrAttemptNotFound
+	}
+	if attempt.Status != types.AttemptReserved {
+		return nil, ErrAttemptStarted
+	}
+	if !s.playerInRoom(attempt.RoomID, userID) {
+		return nil, ErrPlayerNotInRoom
+	}
+
+	attempt.Status = types.AttemptPlaying
+	if err := s.repo.Save(attempt); err != nil {
+		return nil, err
+	}
+	s.active[attempt.RoomID] = *attempt
+	if err := s.quiz.StartOwnedGame(attempt.RoomID, DAILY_ROOM_OWNER); err != nil {
+		delete(s.active, attempt.RoomID)
+		return nil, err
+	}
+	return attempt, nil
+}
+
+func (s *DailyService) playerInRoom(roomID, userID string) bool {
+	for _, id := range s.hub.GetClientIDs(roomID) {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// recordResult はデイリーチャレンジのルームのゲームが終了したときに、挑戦記録に結果を保存します。
+func (s *DailyService) recordResult(result quiztypes.GameResult) {
+	s.mu.Lock()
+	attempt, ok := s.active[result.RoomID]
+	delete(s.active, result.RoomID)
+	s.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	for _, r := range result.Results {
+		if r.UserID == attempt.UserID {
+			attempt.Score = r.Score
+		}
+	}
+	for _, record := range result.History {
+		for _, answer := range record.Results {
+			if answer.UserID == attempt.UserID && answer.IsCorrect {
+				attempt.Correct++
+			}
+		}
+	}
+	finishedAt := time.Now().UTC()
+	attempt.Status = types.AttemptFinished
+	attempt.FinishedAt = &finishedAt
+
+	if err := s.repo.Save(&attempt); err != nil {
+		log.Printf("error: cannot save daily result for %s on %s: %v", attempt.UserID, attempt.Date, err)
+		return
+	}
+	log.Printf("Daily challenge %s finished by %s: %d points", attempt.Date, attempt.UserID, attempt.Score)
+}
+
+// Leaderboard は指定した日（空の場合は今日）のランキングを返します。
+// 同じスコアは同じ順位とし、表示は先に終えたプレイヤーを上にします。
+func (s *DailyService) Leaderboard(date string) (*types.Leaderboard, error) {
+	if date == "" {
+		date = today()
+	}
+	if _, err := time.Parse(DAILY_DATE_FORMAT, date); err != nil {
+		return nil, ErrInvalidDate
+	}
+
+	attempts, err := s.repo.FindByDate(date)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]types.LeaderboardEntry, 0, len(attempts))
+	for _, a := range attempts {
+		if a.Status != types.AttemptFinished || a.FinishedAt == nil {
+			continue
+		}
+		entries = append(entries, types.LeaderboardEntry{
+			UserID:     a.UserID,
+			Score:      a.Score,
+			Correct:    a.Correct,
+			FinishedAt: *a.FinishedAt,
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Score != entries[j].Score {
+			return entries[i].Score > entries[j].Score
+		}
+		return entries[i].FinishedAt.Before(entries[j].FinishedAt)
+	})
+	for i := range entries {
+		if i > 0 && entries[i].Score == entries[i-1].Score {
+			entries[i].Rank = entries[i-1].Rank
+		} else {
+			entries[i].Rank = i + 1
+		}
+	}
+
+	return &types.Leaderboard{Date: date, Entries: entries}, nil
+}
@@ -0,0 +1,13 @@
+package service
+
+import "errors"
+
+var (
+	ErrDailyDisabled    = errors.New("daily challenge is disabled (DAILY_CHALLENGE_SECRET is not set)")
+	ErrAlreadyAttempted = errors.New("already attempted today's challenge")
+	ErrAttemptNotFound  = errors.New("no attempt for today's challenge")
+	ErrAttemptStarted   = errors.New("today's challenge has already been started")
+	ErrPlayerNotInRoom  = errors.New("connect to the challenge room before starting")
+	ErrInvalidDate      = errors.New("date must be in YYYY-MM-DD format")
+	ErrUserIDRequired   = errors.New("userId is required")
+)
@@ -0,0 +1,54 @@
+// server/src/internal/feature/daily/types/dailyType.go
+package types
+
+import "time"
+
+// AttemptStatus はデイリーチャレンジの挑戦の状態です。
+type AttemptStatus string
+
+const (
+	AttemptReserved AttemptStatus = "reserved" // 挑戦権を確保し、ルームを作成した
+	AttemptPlaying  AttemptStatus = "playing"  // ゲームを開始した
+	AttemptFinished AttemptStatus = "finished" // ゲームが終了し、結果を記録した
+)
+
+// Attempt はプレイヤー1人の1日分の挑戦記録です。1日に1回だけ作成できます。
+// ゲームを開始した後に中断した場合も、その日の挑戦は終了したものとして扱います。
+type Attempt struct {
+	Date       string        `json:"date" dynamodbav:"date"` // 挑戦日（UTC、2006-01-02 形式）
+	UserID     string        `json:"userId" dynamodbav:"user_id"`
+	RoomID     string        `json:"roomId" dynamodbav:"room_id"` // 挑戦用の1人用ルーム
+	Status     AttemptStatus `json:"status" dynamodbav:"status"`
+	Score      int           `json:"score" dynamodbav:"score"`
+	Correct    int           `json:"correct" dynamodbav:"correct"` // 正解数
+	StartedAt  time.Time     `json:"startedAt" dynamodbav:"started_at"`
+	FinishedAt *time.Time    `json:"finishedAt,omitempty" dynamodbav:"finished_at,omitempty"`
+}
+
+// Challenge はその日のデイリーチャレンジの情報です。
+type Challenge struct {
+	Date          string    `json:"date"`
+	QuestionCount int       `json:"questionCount"`
+	ResetsAt      time.Time `json:"resetsAt"`          // 次のチャレンジに切り替わる時刻
+	Attempt       *Attempt  `json:"attempt,omitempty"` // userId を指定した場合の、そのプレイヤーの挑戦記録
+}
+
+// LeaderboardEntry はデイリーランキングの1行です。
+type LeaderboardEntry struct {
+	Rank       int       `json:"rank"`
+	UserID     string    `json:"userId"`
+	Score      int       `json:"score"`
+	Correct    int       `json:"correct"`
+	FinishedAt time.Time `json:"finishedAt"`
+}
+
+// Leaderboard は1日分のランキングです。
+type Leaderboard struct {
+	Date    string             `json:"date"`
+	Entries []LeaderboardEntry `json:"entries"`
+}
+
+// AttemptRequest は挑戦の開始リクエストです。
+type AttemptRequest struct {
+	UserID string `json:"userId"`
+}
@@ -0,0 +1,70 @@
+// server/src/internal/feature/practice/handler/practiceHandler.go
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"server/src/internal/feature/practice/service"
+	"server/src/internal/feature/practice/types"
+	quizservice "server/src/internal/feature/quiz/service"
+
+	"github.com/labstack/echo/v4"
+)
+
+// PracticeHandler はソロ練習のリクエストを処理します。
+type PracticeHandler struct {
+	service *service.PracticeService
+}
+
+func NewPracticeHandler(svc *service.PracticeService) *PracticeHandler {
+	return &PracticeHandler{service: svc}
+}
+
+// NextQuestion は GET /practice/next のリクエストを処理します。
+// language, difficulty, tag, kind クエリパラメータで練習する問題を絞り込めます。
+func (h *PracticeHandler) NextQuestion(c echo.Context) error {
+	filter := quizservice.QuestionFilter{
+		Language:   c.QueryParam("language"),
+		Difficulty: c.QueryParam("difficulty"),
+		Tag:        c.QueryParam("tag"),
+		Kind:       c.QueryParam("kind"),
+	}
+	question, err := h.service.Next(c.QueryParam("userId"), filter)
+	if err != nil {
+		return practiceErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, question)
+}
+
+// SubmitAnswer は POST /practice/answer のリクエストを処理します。
+func (h *PracticeHandler) SubmitAnswer(c echo.Context) error {
+	req := new(types.AnswerRequest)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+	result, err := h.service.Answer(req)
+	if err != nil {
+		return practiceErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+// GetHistory は GET /practice/history のリクエストを処理します。
+func (h *PracticeHandler) GetHistory(c echo.Context) error {
+	history, err := h.service.History(c.QueryParam("userId"))
+	if err != nil {
+		return practiceErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, history)
+}
+
+func practiceErrorResponse(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, service.ErrUserIDRequired), errors.Is(err, service.ErrInvalidAnswer):
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	case errors.Is(err, service.ErrQuestionNotFound), errors.Is(err, service.ErrNoQuestions):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	default:
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"server/src/internal/database"
+	"server/src/internal/feature/practice/types"
+)
+
+type PracticeRepository struct {
+	db *database.DBHandler
+}
+
+func NewPracticeRepository(db *database.DBHandler) *PracticeRepository {
+	return &PracticeRepository{db: db}
+}
+
+// Save は学習記録を保存
+func (r *PracticeRepository) Save(card *types.Card) error {
+	return r.db.WritePracticeCard(card)
+}
+
+// Find は学習記録を取得（存在しない場合は nil）
+func (r *PracticeRepository) Find(userID, questionID string) (*types.Card, error) {
+	return r.db.ReadPracticeCard(userID, questionID)
+}
+
+// FindByUser はユーザーのすべての学習記録を取得
+func (r *PracticeRepository) FindByUser(userID string) ([]types.Card, error) {
+	return r.db.ListPracticeCards(userID)
+}
@@ -0,0 +1,26 @@
+// server/src/internal/feature/practice/route.go
+package practice
+
+import (
+	"math/rand"
+	"server/src/internal/database"
+	"server/src/internal/feature/practice/handler"
+	"server/src/internal/feature/practice/repository"
+	"server/src/internal/feature/practice/service"
+	quizservice "server/src/internal/feature/quiz/service"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RegisterRoutes はソロ練習機能の依存関係を解決し、ルートを登録します。
+// 問題バンクと回答の判定は QuizService のものを使用します。
+func RegisterRoutes(g *echo.Group, db *database.DBHandler, quizSvc *quizservice.QuizService) {
+	repo := repository.NewPracticeRepository(db)
+	svc := service.NewPracticeService(repo, quizSvc, rand.New(rand.NewSource(time.Now().UnixNano())))
+	h := handler.NewPracticeHandler(svc)
+
+	g.GET("/next", h.NextQuestion)
+	g.POST("/answer", h.SubmitAnswer)
+	g.GET("/history", h.GetHistory)
+}
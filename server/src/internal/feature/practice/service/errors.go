@@ -0,0 +1,10 @@
+package service
+
+import "errors"
+
+var (
+	ErrUserIDRequired   = errors.New("userId is required")
+	ErrQuestionNotFound = errors.New("question not found")
+	ErrNoQuestions      = errors.New("no questions match the practice filter")
+	ErrInvalidAnswer    = errors.New("invalid answer")
+)
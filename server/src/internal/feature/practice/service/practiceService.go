@@ -0,0 +1,247 @@
+// server/src/internal/feature/practice/service/practiceService.go
+package service
+
+import (
+	"fmt"
+	"math/rand"
+	"server/src/internal/feature/practice/repository"
+	"server/src/internal/feature/practice/types"
+	quizservice "server/src/internal/feature/quiz/service"
+	quiztypes "server/src/internal/feature/quiz/types"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PracticeService はソロ練習を担当します。
+//
+// ゲームのルームを使わず、REST API で1問ずつ出題・判定します。
+// プレイヤーごと・問題ごとに正解・不正解の履歴を学習記録（Card）として保存し、
+// 間隔反復（SM-2）で復習の時期になった問題、苦手な問題を優先して出題します。
+type PracticeService struct {
+	repo *repository.PracticeRepository
+	quiz *quizservice.QuizService
+
+	rng   *rand.Rand // 新しい問題と選択肢の並び替えに使用する乱数（rngMu で保護）
+	rngMu sync.Mutex
+
+	locks map[string]*userLock // 回答を処理中のプレイヤーのロック（Key: ユーザーID、mu で保護）
+	mu    sync.Mutex
+}
+
+// userLock は同じプレイヤーの回答が同時に届いた場合に、学習記録の更新が失われないようにするロックです。
+// 他のプレイヤーの回答は待たせないよう、プレイヤーごとに用意し、使用中の間だけ保持します。
+type userLock struct {
+	mu   sync.Mutex
+	refs int // ロックを使用中（待機中を含む）の回答の数
+}
+
+// NewPracticeService は新しいサービスインスタンスを生成します。
+func NewPracticeService(repo *repository.PracticeRepository, quiz *quizservice.QuizService, rng *rand.Rand) *PracticeService {
+	return &PracticeService{
+		repo:  repo,
+		quiz:  quiz,
+		rng:   rng,
+		locks: make(map[string]*userLock),
+	}
+}
+
+// Next は次に練習する問題を選びます。
+//
+// 復習の時期になった問題があれば、時期を最も過ぎている問題（同じ場合は苦手な問題）を出題します。
+// なければまだ解いたことのない問題をランダムに出題し、それもなければ次に復習の時期が来る問題を前倒しで出題します。
+func (s *PracticeService) Next(userID string, filter quizservice.QuestionFilter) (*types.PracticeQuestion, error) {
+	if userID == "" {
+		return nil, ErrUserIDRequired
+	}
+	if strings.EqualFold(filter.Language, quizservice.LANGUAGE_RANDOM) {
+		filter.Language = ""
+	}
+
+	cards, err := s.cardsByQuestion(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	var due, ahead []*types.Card
+	var fresh []*quiztypes.Question
+	questions := s.quiz.Questions()
+	byID := make(map[string]*quiztypes.Question, len(questions))
+	for i := range questions {
+		q := &questions[i]
+		if !filter.Matches(q) {
+			continue
+		}
+		byID[q.ID] = q
+		card, ok := cards[q.ID]
+		switch {
+		case !ok:
+			fresh = append(fresh, q)
+		case !card.DueAt.After(now):
+			due = append(due, card)
+		default:
+			ahead = append(ahead, card)
+		}
+	}
+
+	switch {
+	case len(due) > 0:
+		sortCards(due)
+		return s.practiceQuestion(byID[due[0].QuestionID], due[0], types.CardDue), nil
+	case len(fresh) > 0:
+		return s.practiceQuestion(fresh[s.intn(len(fresh))], nil, types.CardNew), nil
+	case len(ahead) > 0:
+		sortCards(ahead)
+		return s.practiceQuestion(byID[ahead[0].QuestionID], ahead[0], types.CardAhead), nil
+	default:
+		return nil, ErrNoQuestions
+	}
+}
+
+// sortCards は出題の時期が早い順、同じ場合は苦手な順（EaseFactor が小さい順）に並べます。
+func sortCards(cards []*types.Card) {
+	sort.Slice(cards, func(i, j int) bool {
+		if !cards[i].DueAt.Equal(cards[j].DueAt) {
+			return cards[i].DueAt.Before(cards[j].DueAt)
+		}
+		return cards[i].EaseFactor < cards[j].EaseFactor
+	})
+}
+
+// practiceQuestion は答えを含まない出題内容を組み立てます。
+// 並べ替え問題は問題バンク上の順番が正解のため、選択肢は常に並び替えます。
+func (s *PracticeService) practiceQuestion(q *quiztypes.Question, card *types.Card, status types.CardStatus) *types.PracticeQuestion {
+	choices := q.ChoiceList()
+	s.rngMu.Lock()
+	s.rng.Shuffle(len(choices), func(i, j int) {
+		choices[i], choices[j] = choices[j], choices[i]
+	})
+	s.rngMu.Unlock()
+	return &types.PracticeQuestion{
+		QuestionID: q.ID,
+		Kind:       q.QuestionKind(),
+		Question:   q.Statement,
+		Choices:    choices,
+		Language:   q.Language,
+		Difficulty: q.Difficulty,
+		Tags:       q.Tags,
+		Status:     status,
+		Card:       card,
+	}
+}
+
+// Answer は練習問題への回答を判定し、学習記録を更新します。
+func (s *PracticeService) Answer(req *types.AnswerRequest) (*types.AnswerResult, error) {
+	if req.UserID == "" {
+		return nil, ErrUserIDRequired
+	}
+	q, ok := s.quiz.Question(req.QuestionID)
+	if !ok {
+		return nil, ErrQuestionNotFound
+	}
+	graded, err := quizservice.GradeAnswer(q, req.Answer)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAnswer, err)
+	}
+
+	unlock := s.lockUser(req.UserID)
+	defer unlock()
+
+	card, err := s.repo.Find(req.UserID, q.ID)
+	if err != nil {
+		return nil, err
+	}
+	if card == nil {
+		c := newCard(req.UserID, q.ID)
+		card = &c
+	}
+	quality := answerQuality(graded.IsCorrect, graded.Credit, time.Duration(req.ResponseMs)*time.Millisecond)
+	review(card, quality, time.Now().UTC())
+	if err := s.repo.Save(card); err != nil {
+		return nil, err
+	}
+
+	return &types.AnswerResult{
+		IsCorrect:        graded.IsCorrect,
+		Credit:           graded.Credit,
+		Choice:           graded.Choice,
+		CorrectAnswer:    q.CorrectAnswerText(),
+		CorrectChoiceIDs: q.CorrectChoiceIDs(),
+		Reveal:           q.Reveal(),
+		Quality:          quality,
+		Card:             *card,
+	}, nil
+}
+
+// intn は [0, n) の乱数を返します。
+func (s *PracticeService) intn(n int) int {
+	s.rngMu.Lock()
+	defer s.rngMu.Unlock()
+	return s.rng.Intn(n)
+}
+
+// lockUser はプレイヤーのロックを取得し、解放する関数を返します。
+func (s *PracticeService) lockUser(userID string) func() {
+	s.mu.Lock()
+	l, ok := s.locks[userID]
+	if !ok {
+		l = &userLock{}
+		s.locks[userID] = l
+	}
+	l.refs++
+	s.mu.Unlock()
+
+	l.mu.Lock()
+	return func() {
+		l.mu.Unlock()
+		s.mu.Lock()
+		l.refs--
+		if l.refs == 0 {
+			delete(s.locks, userID)
+		}
+		s.mu.Unlock()
+	}
+}
+
+// History はプレイヤーの学習記録を、苦手な問題から順に返します。
+func (s *PracticeService) History(userID string) (*types.History, error) {
+	if userID == "" {
+		return nil, ErrUserIDRequired
+	}
+	cards, err := s.repo.FindByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	history := &types.History{UserID: userID, Cards: make([]types.Card, 0, len(cards))}
+	for _, card := range cards {
+		if !card.DueAt.After(now) {
+			history.Due++
+		}
+		history.Cards = append(history.Cards, card)
+	}
+	sort.Slice(history.Cards, func(i, j int) bool {
+		a, b := history.Cards[i], history.Cards[j]
+		if a.EaseFactor != b.EaseFactor {
+			return a.EaseFactor < b.EaseFactor
+		}
+		return a.Incorrect > b.Incorrect
+	})
+	return history, nil
+}
+
+// cardsByQuestion はプレイヤーの学習記録を問題IDで引けるようにして返します。
+func (s *PracticeService) cardsByQuestion(userID string) (map[string]*types.Card, error) {
+	cards, err := s.repo.FindByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*types.Card, len(cards))
+	for i := range cards {
+		byID[cards[i].QuestionID] = &cards[i]
+	}
+	return byID, nil
+}
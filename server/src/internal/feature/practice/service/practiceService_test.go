@@ -0,0 +1,72 @@
+// server/src/internal/feature/practice/service/practiceService_test.go
+package service
+
+import (
+	"math/rand"
+	"server/src/internal/feature/practice/types"
+	quiztypes "server/src/internal/feature/quiz/types"
+	"testing"
+	"time"
+)
+
+func TestLockUser(t *testing.T) {
+	s := NewPracticeService(nil, nil, rand.New(rand.NewSource(1)))
+	unlock := s.lockUser("alice")
+
+	// 他のプレイヤーの回答は待たない
+	done := make(chan struct{})
+	go func() {
+		s.lockUser("bob")()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("bob waited for alice's lock")
+	}
+
+	// 同じプレイヤーの回答は先の回答の処理が終わるまで待つ
+	acquired := make(chan struct{})
+	go func() {
+		defer s.lockUser("alice")()
+		close(acquired)
+	}()
+	select {
+	case <-acquired:
+		t.Fatal("alice's second answer did not wait")
+	case <-time.After(50 * time.Millisecond):
+	}
+	unlock()
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("alice's second answer was not resumed")
+	}
+
+	// 使い終わったロックは残さない
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.locks) != 0 {
+		t.Errorf("locks = %v, want none", s.locks)
+	}
+}
+
+func TestPracticeQuestionUsesRand(t *testing.T) {
+	q := &quiztypes.Question{ID: "q1", Statement: "question", Choices: []string{"a", "b", "c", "d", "e", "f"}, Answer: "a"}
+	order := func(seed int64) []string {
+		s := NewPracticeService(nil, nil, rand.New(rand.NewSource(seed)))
+		var texts []string
+		for _, c := range s.practiceQuestion(q, nil, types.CardNew).Choices {
+			texts = append(texts, c.Text)
+		}
+		return texts
+	}
+
+	// 同じ乱数からは同じ順番に並び替える
+	first, second := order(42), order(42)
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("choices = %v and %v, want the same order", first, second)
+		}
+	}
+}
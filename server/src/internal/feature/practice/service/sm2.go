@@ -0,0 +1,83 @@
+// server/src/internal/feature/practice/service/sm2.go
+package service
+
+import (
+	"math"
+	"server/src/internal/feature/practice/types"
+	"time"
+)
+
+const (
+	INITIAL_EASE_FACTOR = 2.5
+	MIN_EASE_FACTOR     = 1.3
+	// RELEARN_DELAY は不正解だった問題を同じ練習の中でもう一度出題するまでの時間です。
+	RELEARN_DELAY = 10 * time.Minute
+	// FAST_RESPONSE と SLOW_RESPONSE は正解の評価を回答時間で分ける境界です。
+	FAST_RESPONSE = 10 * time.Second
+	SLOW_RESPONSE = 30 * time.Second
+)
+
+// newCard はまだ解いたことのない問題の学習記録を作成します。
+func newCard(userID, questionID string) types.Card {
+	return types.Card{
+		UserID:     userID,
+		QuestionID: questionID,
+		EaseFactor: INITIAL_EASE_FACTOR,
+	}
+}
+
+// answerQuality は回答を SM-2 の評価（0〜5）に変換します。3以上が正解です。
+//
+// 正解は回答時間で 5（すぐ答えられた）・4・3（時間がかかった）に分け、回答時間が不明な場合は 4 とします。
+// 不正解は部分点が半分以上であれば 2、それ以外は 1 とします。
+func answerQuality(isCorrect bool, credit float64, response time.Duration) int {
+	if !isCorrect {
+		if credit >= 0.5 {
+			return 2
+		}
+		return 1
+	}
+	switch {
+	case response <= 0:
+		return 4
+	case response <= FAST_RESPONSE:
+		return 5
+	case response <= SLOW_RESPONSE:
+		return 4
+	default:
+		return 3
+	}
+}
+
+// review は SM-2 アルゴリズムで学習記録を更新し、次に出題する日時を決めます。
+//
+// 正解が続くほど間隔は 1日 → 6日 → 前回の間隔 × EaseFactor と伸び、不正解の場合は最初からやり直します。
+// EaseFactor は評価が低いほど小さくなるため、間違えやすい問題ほど頻繁に出題されます。
+func review(card *types.Card, quality int, now time.Time) {
+	if quality >= 3 {
+		switch card.Repetitions {
+		case 0:
+			card.IntervalDays = 1
+		case 1:
+			card.IntervalDays = 6
+		default:
+			card.IntervalDays = int(math.Round(float64(card.IntervalDays) * card.EaseFactor))
+		}
+		card.Repetitions++
+		card.Correct++
+		card.DueAt = now.AddDate(0, 0, card.IntervalDays)
+	} else {
+		card.Repetitions = 0
+		card.IntervalDays = 1
+		card.Incorrect++
+		card.DueAt = now.Add(RELEARN_DELAY)
+	}
+
+	diff := float64(5 - quality)
+	card.EaseFactor += 0.1 - diff*(0.08+diff*0.02)
+	if card.EaseFactor < MIN_EASE_FACTOR {
+		card.EaseFactor = MIN_EASE_FACTOR
+	}
+	card.LastQuality = quality
+	card.LastReviewedAt = now
+}
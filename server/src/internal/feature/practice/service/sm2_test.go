@@ -0,0 +1,124 @@
+// server/src/internal/feature/practice/service/sm2_test.go
+package service
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestReview(t *testing.T) {
+	type step struct {
+		quality     int
+		interval    int     // 更新後の IntervalDays
+		repetitions int     // 更新後の Repetitions
+		ease        float64 // 更新後の EaseFactor
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "評価5が続くと間隔が伸び、EaseFactor が上がる",
+			steps: []step{
+				{quality: 5, interval: 1, repetitions: 1, ease: 2.6},
+				{quality: 5, interval: 6, repetitions: 2, ease: 2.7},
+				{quality: 5, interval: 16, repetitions: 3, ease: 2.8}, // 6 × 2.7 = 16.2
+			},
+		},
+		{
+			name: "評価4は EaseFactor を変えない",
+			steps: []step{
+				{quality: 4, interval: 1, repetitions: 1, ease: 2.5},
+				{quality: 4, interval: 6, repetitions: 2, ease: 2.5},
+				{quality: 4, interval: 15, repetitions: 3, ease: 2.5},
+				{quality: 4, interval: 38, repetitions: 4, ease: 2.5}, // 15 × 2.5 = 37.5
+			},
+		},
+		{
+			name: "評価3は正解として扱い、EaseFactor を下げる",
+			steps: []step{
+				{quality: 3, interval: 1, repetitions: 1, ease: 2.36},
+				{quality: 3, interval: 6, repetitions: 2, ease: 2.22},
+				{quality: 3, interval: 13, repetitions: 3, ease: 2.08}, // 6 × 2.22 = 13.32
+				{quality: 3, interval: 27, repetitions: 4, ease: 1.94}, // 13 × 2.08 = 27.04
+			},
+		},
+		{
+			name: "評価が3未満の場合は最初からやり直す",
+			steps: []step{
+				{quality: 5, interval: 1, repetitions: 1, ease: 2.6},
+				{quality: 5, interval: 6, repetitions: 2, ease: 2.7},
+				{quality: 2, interval: 1, repetitions: 0, ease: 2.38},
+				{quality: 4, interval: 1, repetitions: 1, ease: 2.38},
+				{quality: 4, interval: 6, repetitions: 2, ease: 2.38},
+			},
+		},
+		{
+			name: "EaseFactor は1.3を下回らない",
+			steps: []step{
+				{quality: 1, interval: 1, repetitions: 0, ease: 1.96},
+				{quality: 1, interval: 1, repetitions: 0, ease: 1.42},
+				{quality: 1, interval: 1, repetitions: 0, ease: MIN_EASE_FACTOR},
+				{quality: 0, interval: 1, repetitions: 0, ease: MIN_EASE_FACTOR},
+				{quality: 3, interval: 1, repetitions: 1, ease: MIN_EASE_FACTOR},
+			},
+		},
+	}
+
+	now := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			card := newCard("user", "q1")
+			correct, incorrect := 0, 0
+			for i, s := range tt.steps {
+				review(&card, s.quality, now)
+
+				if card.IntervalDays != s.interval || card.Repetitions != s.repetitions || math.Abs(card.EaseFactor-s.ease) > 1e-9 {
+					t.Fatalf("step %d (quality %d): interval %d repetitions %d ease %v, want %d %d %v",
+						i+1, s.quality, card.IntervalDays, card.Repetitions, card.EaseFactor, s.interval, s.repetitions, s.ease)
+				}
+
+				wantDue := now.AddDate(0, 0, s.interval)
+				if s.quality < 3 {
+					wantDue = now.Add(RELEARN_DELAY)
+					incorrect++
+				} else {
+					correct++
+				}
+				if !card.DueAt.Equal(wantDue) {
+					t.Errorf("step %d: DueAt = %v, want %v", i+1, card.DueAt, wantDue)
+				}
+				if card.Correct != correct || card.Incorrect != incorrect {
+					t.Errorf("step %d: correct %d incorrect %d, want %d %d", i+1, card.Correct, card.Incorrect, correct, incorrect)
+				}
+				if card.LastQuality != s.quality || !card.LastReviewedAt.Equal(now) {
+					t.Errorf("step %d: last quality %d reviewed %v", i+1, card.LastQuality, card.LastReviewedAt)
+				}
+			}
+		})
+	}
+}
+
+func TestAnswerQuality(t *testing.T) {
+	tests := []struct {
+		name      string
+		isCorrect bool
+		credit    float64
+		response  time.Duration
+		want      int
+	}{
+		{name: "すぐに正解", isCorrect: true, credit: 1, response: 5 * time.Second, want: 5},
+		{name: "境界の10秒", isCorrect: true, credit: 1, response: FAST_RESPONSE, want: 5},
+		{name: "少し時間がかかった正解", isCorrect: true, credit: 1, response: 20 * time.Second, want: 4},
+		{name: "時間がかかった正解", isCorrect: true, credit: 1, response: 45 * time.Second, want: 3},
+		{name: "回答時間が不明な正解", isCorrect: true, credit: 1, response: 0, want: 4},
+		{name: "部分点が半分以上の不正解", isCorrect: false, credit: 0.5, response: 5 * time.Second, want: 2},
+		{name: "不正解", isCorrect: false, credit: 0, response: 5 * time.Second, want: 1},
+	}
+	for _, tt := range tests {
+		if got := answerQuality(tt.isCorrect, tt.credit, tt.response); got != tt.want {
+			t.Errorf("%s: answerQuality = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+// server/src/internal/feature/practice/types/practiceType.go
+package types
+
+import (
+	quiztypes "server/src/internal/feature/quiz/types"
+	"time"
+)
+
+// Card はプレイヤー1人・問題1問ごとの学習記録です。間隔反復（SM-2）で次に出題する日時を決めます。
+type Card struct {
+	UserID         string    `json:"userId" dynamodbav:"user_id"`
+	QuestionID     string    `json:"questionId" dynamodbav:"question_id"`
+	Repetitions    int       `json:"repetitions" dynamodbav:"repetitions"`    // 連続で正解した回数（不正解で0に戻る）
+	IntervalDays   int       `json:"intervalDays" dynamodbav:"interval_days"` // 次の出題までの間隔（日）
+	EaseFactor     float64   `json:"easeFactor" dynamodbav:"ease_factor"`     // 間隔の伸び率（苦手な問題ほど小さい）
+	DueAt          time.Time `json:"dueAt" dynamodbav:"due_at"`               // 次に出題する日時
+	LastReviewedAt time.Time `json:"lastReviewedAt" dynamodbav:"last_reviewed_at"`
+	LastQuality    int       `json:"lastQuality" dynamodbav:"last_quality"` // 直近の回答の評価（0〜5）
+	Correct        int       `json:"correct" dynamodbav:"correct"`          // 累計の正解数
+	Incorrect      int       `json:"incorrect" dynamodbav:"incorrect"`      // 累計の不正解数
+}
+
+// CardStatus は練習で出題する問題の選ばれ方です。
+type CardStatus string
+
+const (
+	CardDue   CardStatus = "due"   // 復習の時期になった問題
+	CardNew   CardStatus = "new"   // まだ解いたことのない問題
+	CardAhead CardStatus = "ahead" // 復習の時期より前に前倒しで出題する問題
+)
+
+// PracticeQuestion は練習で出題する問題です。答えは含みません。
+type PracticeQuestion struct {
+	QuestionID string                 `json:"questionId"`
+	Kind       quiztypes.QuestionKind `json:"kind"`
+	Question   string                 `json:"question"`
+	Choices    []quiztypes.Choice     `json:"choices,omitempty"`
+	Language   string                 `json:"language,omitempty"`
+	Difficulty string                 `json:"difficulty,omitempty"`
+	Tags       []string               `json:"tags,omitempty"`
+	Status     CardStatus             `json:"status"`
+	Card       *Card                  `json:"card,omitempty"` // 新しい問題の場合は nil
+}
+
+// AnswerRequest は練習問題への回答です。Answer の形式は WebSocket の answer メッセージのペイロードと同じです。
+type AnswerRequest struct {
+	UserID     string                 `json:"userId"`
+	QuestionID string                 `json:"questionId"`
+	Answer     map[string]interface{} `json:"answer"`
+	ResponseMs int                    `json:"responseMs,omitempty"` // 回答にかかった時間（ミリ秒）。評価の参考にします
+}
+
+// AnswerResult は練習問題の判定結果と、更新後の学習記録です。
+type AnswerResult struct {
+	IsCorrect        bool                     `json:"isCorrect"`
+	Credit           float64                  `json:"credit"`
+	Choice           string                   `json:"choice,omitempty"`
+	CorrectAnswer    string                   `json:"correctAnswer"`
+	CorrectChoiceIDs []string                 `json:"correctChoiceIds,omitempty"`
+	Reveal           quiztypes.QuestionReveal `json:"reveal"`
+	Quality          int                      `json:"quality"`
+	Card             Card                     `json:"card"`
+}
+
+// History はプレイヤーの学習記録の一覧です。苦手な問題（EaseFactor が小さい順）に並びます。
+type History struct {
+	UserID string `json:"userId"`
+	Due    int    `json:"due"` // 現在復習の時期になっている問題の数
+	Cards  []Card `json:"cards"`
+}
@@ -0,0 +1,61 @@
+// server/src/internal/feature/quiz/codec/codec.go
+// 問題バンクのファイル形式（JSON, YAML, CSV, Markdown）の読み書きを提供します。
+//
+// どの形式も Question の全フィールドを表現でき、Decode(Encode(x)) は x と同じ問題を返します。
+// 問題の内容の検証は行わないため、読み込んだ問題は呼び出し側で検証してください。
+package codec
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"server/src/internal/feature/quiz/types"
+	"strings"
+)
+
+// Codec は問題バンクの1つのファイル形式です。
+type Codec interface {
+	Name() string         // 形式名（json, yaml, csv, markdown）
+	Extensions() []string // 対応する拡張子（先頭が既定）
+	ContentType() string  // HTTPレスポンスの Content-Type
+	Decode(r io.Reader) ([]types.Question, error)
+	Encode(w io.Writer, questions []types.Question) error
+}
+
+// ErrUnknownFormat は未対応の形式名または拡張子が指定された場合のエラーです。
+var ErrUnknownFormat = errors.New("unknown question bank format")
+
+var codecs = []Codec{jsonCodec{}, yamlCodec{}, csvCodec{}, markdownCodec{}}
+
+// Lookup は形式名から Codec を返します。大文字・小文字は区別しません。
+func Lookup(name string) (Codec, error) {
+	for _, c := range codecs {
+		if strings.EqualFold(c.Name(), name) {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %q (supported: %s)", ErrUnknownFormat, name, strings.Join(Names(), ", "))
+}
+
+// ForFile はファイルの拡張子から Codec を返します。
+func ForFile(path string) (Codec, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, c := range codecs {
+		for _, e := range c.Extensions() {
+			if e == ext {
+				return c, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, path)
+}
+
+// Names は対応している形式名の一覧を返します。
+func Names() []string {
+	names := make([]string, len(codecs))
+	for i, c := range codecs {
+		names[i] = c.Name()
+	}
+	return names
+}
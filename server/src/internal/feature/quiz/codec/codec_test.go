@@ -0,0 +1,166 @@
+package codec
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"server/src/internal/feature/quiz/types"
+	"strings"
+	"testing"
+)
+
+// sampleQuestions はすべての形式の問題と、区切り文字や改行を含む値を網羅した問題バンクです。
+func sampleQuestions() []types.Question {
+	return []types.Question{
+		{
+			ID:          "single-1",
+			Kind:        types.KindSingleChoice,
+			Statement:   "#include <stdio.h>\n\nint main(void) {\n    printf(\"%d, %d\\n\", 1, 2);\n    return 0;\n}\n",
+			Choices:     []string{"1, 2", "1 2", "\"1\", \"2\"", "| pipe |"},
+			Answer:      "1, 2",
+			Language:    "C",
+			Difficulty:  "Easy",
+			Rating:      2,
+			Tags:        []string{"printf", "format"},
+			Explanation: "printf は書式文字列に従って出力します。\n\n- %d は整数\n- \\n は改行",
+			ChoiceRationales: map[string]string{
+				"1 2":      "区切りのカンマも出力されます。",
+				"| pipe |": "無関係な選択肢です。",
+			},
+			References: []types.Reference{
+				{Title: "printf(3)", URL: "https://man7.org/linux/man-pages/man3/printf.3.html"},
+			},
+		},
+		{
+			ID:        "single-default-kind",
+			Statement: "print(len('abc'))",
+			Choices:   []string{"3", "4"},
+			Answer:    "3",
+			Language:  "Python",
+		},
+		{
+			ID:            "free-1",
+			Kind:          types.KindFreeText,
+			Statement:     "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"a\\tb\")\n}",
+			Answer:        "^a\\s+b$",
+			Match:         types.MatchRegex,
+			DisplayAnswer: "a\tb",
+			Language:      "Go",
+			Difficulty:    "Normal",
+			Tags:          []string{"strings"},
+		},
+		{
+			ID:          "multi-1",
+			Kind:        types.KindMultiSelect,
+			Statement:   "次のうち、Go の組み込み型をすべて選んでください。",
+			Choices:     []string{"int", "rune", "char", "string"},
+			Answers:     []string{"int", "rune", "string"},
+			Language:    "Go",
+			Difficulty:  "Hard",
+			Rating:      8,
+			Explanation: "char は C の型です。",
+			Replaces:    true,
+		},
+		{
+			ID:        "order-1",
+			Kind:      types.KindOrdering,
+			Statement: "出力が 1 2 3 になるように並べてください。",
+			Choices:   []string{"x := 1", "fmt.Println(x, x+1, x+2)", "// end: done"},
+			Language:  "Go",
+			Tags:      []string{"basics", "ordering"},
+		},
+	}
+}
+
+func encodeString(t *testing.T, c Codec, questions []types.Question) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := c.Encode(&buf, questions); err != nil {
+		t.Fatalf("%s: encode: %v", c.Name(), err)
+	}
+	return buf.String()
+}
+
+// assertRoundTrip は Encode した結果を Decode すると元の問題に戻ることを確認し、出力を返します。
+func assertRoundTrip(t *testing.T, c Codec, want []types.Question) string {
+	t.Helper()
+	encoded := encodeString(t, c, want)
+	got, err := c.Decode(strings.NewReader(encoded))
+	if err != nil {
+		t.Fatalf("%s: decode: %v\n%s", c.Name(), err, encoded)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("%s: decoded %d questions, want %d\n%s", c.Name(), len(got), len(want), encoded)
+	}
+	for i := range want {
+		if !reflect.DeepEqual(got[i], want[i]) {
+			t.Errorf("%s: question %s changed in the round trip\n got: %#v\nwant: %#v\n%s", c.Name(), want[i].ID, got[i], want[i], encoded)
+		}
+	}
+	return encoded
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, c := range codecs {
+		t.Run(c.Name(), func(t *testing.T) {
+			assertRoundTrip(t, c, sampleQuestions())
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{name: "json", want: "json"},
+		{name: "YAML", want: "yaml"},
+		{name: "Markdown", want: "markdown"},
+		{name: "xml", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := Lookup(tt.name)
+			if tt.wantErr {
+				if !errors.Is(err, ErrUnknownFormat) {
+					t.Fatalf("Lookup() error = %v, want ErrUnknownFormat", err)
+				}
+				return
+			}
+			if err != nil || c.Name() != tt.want {
+				t.Fatalf("Lookup() = %v, %v, want %s", c, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestForFile(t *testing.T) {
+	tests := []struct {
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{path: "bank.json", want: "json"},
+		{path: "bank.yml", want: "yaml"},
+		{path: "dir/bank.YAML", want: "yaml"},
+		{path: "bank.csv", want: "csv"},
+		{path: "bank.markdown", want: "markdown"},
+		{path: "bank.txt", wantErr: true},
+		{path: "bank", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			c, err := ForFile(tt.path)
+			if tt.wantErr {
+				if !errors.Is(err, ErrUnknownFormat) {
+					t.Fatalf("ForFile() error = %v, want ErrUnknownFormat", err)
+				}
+				return
+			}
+			if err != nil || c.Name() != tt.want {
+				t.Fatalf("ForFile() = %v, %v, want %s", c, err, tt.want)
+			}
+		})
+	}
+}
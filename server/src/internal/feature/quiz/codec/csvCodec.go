@@ -0,0 +1,211 @@
+// server/src/internal/feature/quiz/codec/csvCodec.go
+package codec
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"server/src/internal/feature/quiz/types"
+	"strconv"
+	"strings"
+)
+
+// csvColumns はCSVの列です。読み込み時は列の順番を問わず、id 以外の列は省略できます。
+var csvColumns = []string{
+	"id", "kind", "language", "difficulty", "rating", "tags",
+	"statement", "choices", "answer", "answers", "match", "displayAnswer",
+	"explanation", "choiceRationales", "references", "replaces",
+}
+
+// csvCodec はスプレッドシートで編集するためのCSV形式です。1行が1問に対応します。
+//
+// リストの列（tags, choices, answers）はセル内で1行に1要素を記述します。
+// 要素に改行を含むなど1行ずつでは表現できない場合は、JSONの文字列配列で出力します。
+// choiceRationales と references はJSONで記述します。
+// なお、CSVの仕様上セル内の CRLF は LF として読み込まれます。
+type csvCodec struct{}
+
+func (csvCodec) Name() string         { return "csv" }
+func (csvCodec) Extensions() []string { return []string{".csv"} }
+func (csvCodec) ContentType() string  { return "text/csv; charset=utf-8" }
+
+func (csvCodec) Decode(r io.Reader) ([]types.Question, error) {
+	reader := csv.NewReader(r)
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	header := records[0]
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")) // Excel が付与するBOMを除去
+		if !isCSVColumn(name) {
+			return nil, fmt.Errorf("csv: unknown column %q", name)
+		}
+		if _, dup := columns[name]; dup {
+			return nil, fmt.Errorf("csv: duplicate column %q", name)
+		}
+		columns[name] = i
+	}
+	if _, ok := columns["id"]; !ok {
+		return nil, fmt.Errorf("csv: missing column %q", "id")
+	}
+
+	questions := make([]types.Question, 0, len(records)-1)
+	for n, record := range records[1:] {
+		cell := func(name string) string {
+			if i, ok := columns[name]; ok {
+				return record[i]
+			}
+			return ""
+		}
+
+		q := types.Question{
+			ID:            cell("id"),
+			Kind:          types.QuestionKind(cell("kind")),
+			Language:      cell("language"),
+			Difficulty:    cell("difficulty"),
+			Statement:     cell("statement"),
+			Answer:        cell("answer"),
+			Match:         types.MatchMode(cell("match")),
+			DisplayAnswer: cell("displayAnswer"),
+			Explanation:   cell("explanation"),
+		}
+		if rating := strings.TrimSpace(cell("rating")); rating != "" {
+			if q.Rating, err = strconv.Atoi(rating); err != nil {
+				return nil, fmt.Errorf("csv: row %d: rating: %w", n+2, err)
+			}
+		}
+		if replaces := strings.TrimSpace(cell("replaces")); replaces != "" {
+			if q.Replaces, err = strconv.ParseBool(replaces); err != nil {
+				return nil, fmt.Errorf("csv: row %d: replaces: %w", n+2, err)
+			}
+		}
+		if q.Tags, err = decodeCSVList(cell("tags")); err != nil {
+			return nil, fmt.Errorf("csv: row %d: tags: %w", n+2, err)
+		}
+		if q.Choices, err = decodeCSVList(cell("choices")); err != nil {
+			return nil, fmt.Errorf("csv: row %d: choices: %w", n+2, err)
+		}
+		if q.Answers, err = decodeCSVList(cell("answers")); err != nil {
+			return nil, fmt.Errorf("csv: row %d: answers: %w", n+2, err)
+		}
+		if err := decodeCSVJSON(cell("choiceRationales"), &q.ChoiceRationales); err != nil {
+			return nil, fmt.Errorf("csv: row %d: choiceRationales: %w", n+2, err)
+		}
+		if err := decodeCSVJSON(cell("references"), &q.References); err != nil {
+			return nil, fmt.Errorf("csv: row %d: references: %w", n+2, err)
+		}
+		questions = append(questions, q)
+	}
+	return questions, nil
+}
+
+func (csvCodec) Encode(w io.Writer, questions []types.Question) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvColumns); err != nil {
+		return err
+	}
+
+	for _, q := range questions {
+		rationales, err := encodeCSVJSON(q.ChoiceRationales, len(q.ChoiceRationales) == 0)
+		if err != nil {
+			return err
+		}
+		references, err := encodeCSVJSON(q.References, len(q.References) == 0)
+		if err != nil {
+			return err
+		}
+		rating := ""
+		if q.Rating != 0 {
+			rating = strconv.Itoa(q.Rating)
+		}
+		replaces := ""
+		if q.Replaces {
+			replaces = "true"
+		}
+		record := []string{
+			q.ID, string(q.Kind), q.Language, q.Difficulty, rating, encodeCSVList(q.Tags),
+			q.Statement, encodeCSVList(q.Choices), q.Answer, encodeCSVList(q.Answers), string(q.Match), q.DisplayAnswer,
+			q.Explanation, rationales, references, replaces,
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func isCSVColumn(name string) bool {
+	for _, c := range csvColumns {
+		if c == name {
+			return true
+		}
+	}
+	return false
+}
+
+// encodeCSVList はリストをセルの文字列にします。1行1要素で表現できない場合はJSON配列にします。
+func encodeCSVList(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	plain := !strings.HasPrefix(values[0], "[")
+	for _, v := range values {
+		if v == "" || strings.ContainsAny(v, "\r\n") {
+			plain = false
+		}
+	}
+	if plain {
+		return strings.Join(values, "\n")
+	}
+	data, _ := marshalJSON(values)
+	return data
+}
+
+// decodeCSVList はセルの文字列をリストにします。"[" で始まるセルはJSON配列として読み込みます。
+func decodeCSVList(cell string) ([]string, error) {
+	if cell == "" {
+		return nil, nil
+	}
+	if strings.HasPrefix(cell, "[") {
+		var values []string
+		if err := json.Unmarshal([]byte(cell), &values); err != nil {
+			return nil, err
+		}
+		return values, nil
+	}
+	return strings.Split(cell, "\n"), nil
+}
+
+func encodeCSVJSON(v interface{}, empty bool) (string, error) {
+	if empty {
+		return "", nil
+	}
+	return marshalJSON(v)
+}
+
+func decodeCSVJSON(cell string, v interface{}) error {
+	if cell == "" {
+		return nil
+	}
+	return json.Unmarshal([]byte(cell), v)
+}
+
+// marshalJSON は < や & をエスケープせずに1行のJSONを返します。
+func marshalJSON(v interface{}) (string, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
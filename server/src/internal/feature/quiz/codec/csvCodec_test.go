@@ -0,0 +1,125 @@
+package codec
+
+import (
+	"reflect"
+	"server/src/internal/feature/quiz/types"
+	"strings"
+	"testing"
+)
+
+func TestCSVCodecQuoting(t *testing.T) {
+	tests := []struct {
+		name     string
+		question types.Question
+		wantCell string
+	}{
+		{
+			name:     "カンマと引用符を含む値は引用符で囲みエスケープする",
+			question: types.Question{ID: "q1", Statement: `printf("%d, %d", a, b)`, Choices: []string{"a", "b"}, Answer: "a"},
+			wantCell: `"printf(""%d, %d"", a, b)"`,
+		},
+		{
+			name:     "改行を含むコードはセル内の改行として出力する",
+			question: types.Question{ID: "q1", Statement: "x := 1\nfmt.Println(x)\n", Choices: []string{"1", "2"}, Answer: "1"},
+			wantCell: "\"x := 1\nfmt.Println(x)\n\"",
+		},
+		{
+			name:     "選択肢はセル内に1行1要素",
+			question: types.Question{ID: "q1", Statement: "s", Choices: []string{"1, 2", "3"}, Answer: "3"},
+			wantCell: "\"1, 2\n3\"",
+		},
+		{
+			name:     "改行を含む選択肢はJSON配列で出力する",
+			question: types.Question{ID: "q1", Statement: "s", Choices: []string{"1\n2", "3"}, Answer: "3"},
+			wantCell: `"[""1\n2"",""3""]"`,
+		},
+		{
+			name:     "[ で始まる選択肢もJSON配列で出力する",
+			question: types.Question{ID: "q1", Statement: "s", Choices: []string{"[1 2]", "[]"}, Answer: "[]"},
+			wantCell: `"[""[1 2]"",""[]""]"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded := assertRoundTrip(t, csvCodec{}, []types.Question{tt.question})
+			if !strings.Contains(encoded, tt.wantCell) {
+				t.Errorf("encoded CSV does not contain %q\n%s", tt.wantCell, encoded)
+			}
+		})
+	}
+}
+
+func TestCSVCodecDecode(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []types.Question
+	}{
+		{
+			name:  "列の順番は問わず、省略した列は空",
+			input: "answer,id,choices\n2,q1,\"1\n2\"\n",
+			want:  []types.Question{{ID: "q1", Choices: []string{"1", "2"}, Answer: "2"}},
+		},
+		{
+			name:  "Excel が付与する BOM を除去する",
+			input: "\ufeffid,statement\nq1,s\n",
+			want:  []types.Question{{ID: "q1", Statement: "s"}},
+		},
+		{
+			name:  "セル内の CRLF は LF として読み込む",
+			input: "id,statement\r\nq1,\"a\r\nb\"\r\n",
+			want:  []types.Question{{ID: "q1", Statement: "a\nb"}},
+		},
+		{
+			name:  "choiceRationales と references はJSON",
+			input: "id,choiceRationales,references\nq1,\"{\"\"a\"\":\"\"why\"\"}\",\"[{\"\"title\"\":\"\"t\"\",\"\"url\"\":\"\"https://example.com\"\"}]\"\n",
+			want: []types.Question{{
+				ID:               "q1",
+				ChoiceRationales: map[string]string{"a": "why"},
+				References:       []types.Reference{{Title: "t", URL: "https://example.com"}},
+			}},
+		},
+		{
+			name:  "ヘッダーのみ",
+			input: "id,statement\n",
+			want:  []types.Question{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := (csvCodec{}).Decode(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Decode() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCSVCodecDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{name: "未知の列", input: "id,question\nq1,s\n", wantErr: `unknown column "question"`},
+		{name: "重複した列", input: "id,id\nq1,q1\n", wantErr: `duplicate column "id"`},
+		{name: "id 列がない", input: "statement\ns\n", wantErr: `missing column "id"`},
+		{name: "閉じていない引用符", input: "id,statement\nq1,\"s\n", wantErr: "extraneous or missing"},
+		{name: "列数が異なる行", input: "id,statement\nq1,s,extra\n", wantErr: "wrong number of fields"},
+		{name: "JSON配列として読めない選択肢", input: "id,choices\nq1,[1 2\n", wantErr: "row 2: choices"},
+		{name: "数値でない難易度評価", input: "id,rating\nq1,hard\n", wantErr: "row 2: rating"},
+		{name: "真偽値でない replaces", input: "id,replaces\nq1,maybe\n", wantErr: "row 2: replaces"},
+		{name: "JSONとして読めない参考リンク", input: "id,references\nq1,{\n", wantErr: "row 2: references"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := (csvCodec{}).Decode(strings.NewReader(tt.input))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Decode() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
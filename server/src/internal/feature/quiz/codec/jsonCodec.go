@@ -0,0 +1,34 @@
+// server/src/internal/feature/quiz/codec/jsonCodec.go
+package codec
+
+import (
+	"encoding/json"
+	"io"
+	"server/src/internal/feature/quiz/types"
+)
+
+// jsonCodec は問題ファイル（mock.json）と同じJSON配列の形式です。
+type jsonCodec struct{}
+
+func (jsonCodec) Name() string         { return "json" }
+func (jsonCodec) Extensions() []string { return []string{".json"} }
+func (jsonCodec) ContentType() string  { return "application/json; charset=utf-8" }
+
+func (jsonCodec) Decode(r io.Reader) ([]types.Question, error) {
+	var questions []types.Question
+	if err := json.NewDecoder(r).Decode(&questions); err != nil {
+		return nil, err
+	}
+	return questions, nil
+}
+
+func (jsonCodec) Encode(w io.Writer, questions []types.Question) error {
+	if questions == nil {
+		questions = []types.Question{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	// コード中の < や & をそのまま読めるようにします。
+	enc.SetEscapeHTML(false)
+	return enc.Encode(questions)
+}
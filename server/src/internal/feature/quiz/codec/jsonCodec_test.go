@@ -0,0 +1,47 @@
+package codec
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestJSONCodecEncode(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "コード中の < と & はエスケープしない", want: `#include <stdio.h>`},
+		{name: "mock.json と同じキー名", want: `"Id": "single-1"`},
+		{name: "問題文の引用符はそのまま", want: `"Statement": "print(len('abc'))"`},
+	}
+	encoded := encodeString(t, jsonCodec{}, sampleQuestions())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !strings.Contains(encoded, tt.want) {
+				t.Errorf("encoded JSON does not contain %q\n%s", tt.want, encoded)
+			}
+		})
+	}
+	if got := encodeString(t, jsonCodec{}, nil); strings.TrimSpace(got) != "[]" {
+		t.Errorf("Encode(nil) = %q, want []", got)
+	}
+}
+
+func TestJSONCodecDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "空の入力", input: ""},
+		{name: "配列ではない", input: `{"Id": "q1"}`},
+		{name: "閉じていない配列", input: `[{"Id": "q1"}`},
+		{name: "型が異なる", input: `[{"Id": "q1", "Choices": "a"}]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := (jsonCodec{}).Decode(strings.NewReader(tt.input)); err == nil {
+				t.Error("Decode() succeeded, want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,651 @@
+// server/src/internal/feature/quiz/codec/markdownCodec.go
+package codec
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"server/src/internal/feature/quiz/types"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// markdownCodec は問題をMarkdownで記述する形式です。1問は次のように記述します。
+//
+//	## q1
+//
+//	- language: C
+//	- difficulty: Easy
+//	- tags: pointer, string
+//
+//	```c
+//	（問題のコード）
+//	```
+//
+//	### Choices
+//
+//	- [ ] P
+//	- [x] i
+//
+//	### Explanation
+//
+//	（解説）
+//
+//	### Rationales
+//
+//	- n: （選択肢ごとの補足）
+//
+//	### References
+//
+//	- [タイトル](https://example.com)
+//
+// 選択肢は、単一選択・複数選択ではチェックボックスで正解を示し、並べ替えでは番号付きリストで正しい順番に記述します。
+// 自由記述の答えは "### Answer" の後にコードブロックで記述します。
+// 前後の空白や改行を含むなど、そのままでは表現できない値は Go の文字列リテラル（"..."）で出力します。
+type markdownCodec struct{}
+
+func (markdownCodec) Name() string         { return "markdown" }
+func (markdownCodec) Extensions() []string { return []string{".md", ".markdown"} }
+func (markdownCodec) ContentType() string  { return "text/markdown; charset=utf-8" }
+
+// Markdown の見出し
+const (
+	mdSectionChoices     = "Choices"
+	mdSectionAnswer      = "Answer"
+	mdSectionExplanation = "Explanation"
+	mdSectionRationales  = "Rationales"
+	mdSectionReferences  = "References"
+)
+
+var (
+	mdFenceOpen    = regexp.MustCompile("^(`{3,})(.*)$")
+	mdFenceInfo    = regexp.MustCompile(`^[a-z0-9+#-]*$`)
+	mdMetaItem     = regexp.MustCompile(`^- ([A-Za-z]+): (.*)$`)
+	mdCheckItem    = regexp.MustCompile(`^- \[([ xX])\] (.*)$`)
+	mdOrderedItem  = regexp.MustCompile(`^[0-9]+\. (.*)$`)
+	mdLinkItem     = regexp.MustCompile(`^- \[([^\[\]]+)\]\(([^()\s]+)\)$`)
+	mdBacktickRuns = regexp.MustCompile("`+")
+)
+
+func (markdownCodec) Encode(w io.Writer, questions []types.Question) error {
+	out := bufio.NewWriter(w)
+	for i, q := range questions {
+		if i > 0 {
+			out.WriteString("\n")
+		}
+		writeMarkdownQuestion(out, &q)
+	}
+	return out.Flush()
+}
+
+func writeMarkdownQuestion(out *bufio.Writer, q *types.Question) {
+	fmt.Fprintf(out, "## %s\n\n", mdInline(q.ID, ""))
+
+	kind := q.QuestionKind()
+	checkboxes := kind == types.KindSingleChoice || kind == types.KindMultiSelect
+
+	// 選択肢のチェックボックスや Answer セクションで表現できない答えはメタデータに書きます。
+	answerInMeta := q.Answer != "" && !(kind == types.KindFreeText) &&
+		!(kind == types.KindSingleChoice && countOf(q.Choices, q.Answer) == 1)
+	answersInMeta := len(q.Answers) > 0 &&
+		!(kind == types.KindMultiSelect && checkedInOrder(q.Choices, q.Answers))
+
+	type metaItem struct{ key, value string }
+	var meta []metaItem
+	for _, m := range []metaItem{
+		{"kind", string(q.Kind)},
+		{"language", q.Language},
+		{"difficulty", q.Difficulty},
+		{"match", string(q.Match)},
+		{"displayAnswer", q.DisplayAnswer},
+	} {
+		if m.value != "" {
+			meta = append(meta, metaItem{m.key, mdInline(m.value, "")})
+		}
+	}
+	if q.Rating != 0 {
+		meta = append(meta, metaItem{"rating", strconv.Itoa(q.Rating)})
+	}
+	if len(q.Tags) > 0 {
+		meta = append(meta, metaItem{"tags", mdInlineList(q.Tags)})
+	}
+	if answerInMeta {
+		meta = append(meta, metaItem{"answer", mdInline(q.Answer, "")})
+	}
+	if answersInMeta {
+		meta = append(meta, metaItem{"answers", mdInlineList(q.Answers)})
+	}
+	if q.Replaces {
+		meta = append(meta, metaItem{"replaces", "true"})
+	}
+	for _, m := range meta {
+		fmt.Fprintf(out, "- %s: %s\n", m.key, m.value)
+	}
+	if len(meta) > 0 {
+		out.WriteString("\n")
+	}
+
+	info := strings.ToLower(q.Language)
+	if !mdFenceInfo.MatchString(info) {
+		info = ""
+	}
+	writeMarkdownFence(out, info, q.Statement)
+
+	if len(q.Choices) > 0 {
+		fmt.Fprintf(out, "\n### %s\n\n", mdSectionChoices)
+		for i, choice := range q.Choices {
+			text := mdInline(choice, "")
+			switch {
+			case kind == types.KindOrdering:
+				fmt.Fprintf(out, "%d. %s\n", i+1, text)
+			case checkboxes:
+				mark := " "
+				if (kind == types.KindSingleChoice && !answerInMeta && choice == q.Answer) ||
+					(kind == types.KindMultiSelect && !answersInMeta && countOf(q.Answers, choice) > 0) {
+					mark = "x"
+				}
+				fmt.Fprintf(out, "- [%s] %s\n", mark, text)
+			default:
+				if strings.HasPrefix(text, "[") {
+					text = strconv.Quote(choice) // チェックボックスと区別するため
+				}
+				fmt.Fprintf(out, "- %s\n", text)
+			}
+		}
+	}
+
+	if kind == types.KindFreeText && q.Answer != "" {
+		fmt.Fprintf(out, "\n### %s\n\n", mdSectionAnswer)
+		writeMarkdownFence(out, "text", q.Answer)
+	}
+
+	if q.Explanation != "" {
+		fmt.Fprintf(out, "\n### %s\n\n", mdSectionExplanation)
+		if mdRawText(q.Explanation) {
+			fmt.Fprintf(out, "%s\n", q.Explanation)
+		} else {
+			writeMarkdownFence(out, "text", q.Explanation)
+		}
+	}
+
+	if len(q.ChoiceRationales) > 0 {
+		fmt.Fprintf(out, "\n### %s\n\n", mdSectionRationales)
+		keys := make([]string, 0, len(q.ChoiceRationales))
+		for key := range q.ChoiceRationales {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Fprintf(out, "- %s: %s\n", mdInline(key, ":"), mdInline(q.ChoiceRationales[key], ""))
+		}
+	}
+
+	if len(q.References) > 0 {
+		fmt.Fprintf(out, "\n### %s\n\n", mdSectionReferences)
+		for _, ref := range q.References {
+			line := fmt.Sprintf("- [%s](%s)", ref.Title, ref.URL)
+			if m := mdLinkItem.FindStringSubmatch(line); m == nil || m[1] != ref.Title || m[2] != ref.URL ||
+				strings.TrimSpace(ref.Title) != ref.Title || strings.ContainsAny(ref.Title, "\r\n") {
+				line = fmt.Sprintf("- %s %s", strconv.Quote(ref.Title), strconv.Quote(ref.URL))
+			}
+			fmt.Fprintln(out, line)
+		}
+	}
+}
+
+// writeMarkdownFence は内容に含まれるバッククォートより長いフェンスでコードブロックを書きます。
+// コードブロックの内容は開始行と終了行の間の行を改行でつないだものです。
+func writeMarkdownFence(out *bufio.Writer, info, content string) {
+	size := 3
+	for _, run := range mdBacktickRuns.FindAllString(content, -1) {
+		if len(run) >= size {
+			size = len(run) + 1
+		}
+	}
+	fence := strings.Repeat("`", size)
+	fmt.Fprintf(out, "%s%s\n%s\n%s\n", fence, info, content, fence)
+}
+
+// mdInline は1行の値を返します。空文字列や、そのまま書くと読み込み時に区別できない値は文字列リテラルにします。
+// forbidden はそのまま書けない文字（区切り文字など）です。
+func mdInline(s, forbidden string) string {
+	if s == "" || strings.TrimSpace(s) != s || strings.ContainsAny(s, "\r\n") || strings.HasPrefix(s, `"`) ||
+		(forbidden != "" && strings.ContainsAny(s, forbidden)) {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// mdInlineList はリストを ", " 区切りの1行にします。
+func mdInlineList(values []string) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = mdInline(v, ",")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// mdRawText は解説をMarkdownのまま書けるか（見出しやコードブロックと区別できるか）を返します。
+func mdRawText(s string) bool {
+	if strings.TrimSpace(s) != s || strings.Contains(s, "\r") {
+		return false
+	}
+	for _, line := range strings.Split(s, "\n") {
+		if strings.HasPrefix(line, "#") || strings.HasPrefix(line, "```") {
+			return false
+		}
+	}
+	return true
+}
+
+func countOf(values []string, target string) int {
+	n := 0
+	for _, v := range values {
+		if v == target {
+			n++
+		}
+	}
+	return n
+}
+
+// checkedInOrder は answers が choices の部分列（重複なし）で、チェックボックスで表現できるかを返します。
+func checkedInOrder(choices, answers []string) bool {
+	i := 0
+	for _, choice := range choices {
+		if countOf(choices, choice) != 1 {
+			return false
+		}
+		if i < len(answers) && answers[i] == choice {
+			i++
+		}
+	}
+	return i == len(answers)
+}
+
+func (markdownCodec) Decode(r io.Reader) ([]types.Question, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	p := &mdParser{lines: strings.Split(string(data), "\n")}
+	return p.parse()
+}
+
+// mdParser は markdownCodec の読み込み処理です。
+type mdParser struct {
+	lines []string
+	pos   int
+}
+
+func (p *mdParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("markdown: line %d: %s", p.pos+1, fmt.Sprintf(format, args...))
+}
+
+// line は現在の行を、構造の判定用に行末の CR を除いて返します。
+func (p *mdParser) line() string {
+	return strings.TrimSuffix(p.lines[p.pos], "\r")
+}
+
+func (p *mdParser) parse() ([]types.Question, error) {
+	var questions []types.Question
+
+	// 最初の問題の見出しより前の行（ファイルのタイトルなど）は読み飛ばします。
+	for p.pos < len(p.lines) && !strings.HasPrefix(p.line(), "## ") {
+		p.pos++
+	}
+	for p.pos < len(p.lines) {
+		q, err := p.parseQuestion()
+		if err != nil {
+			return nil, err
+		}
+		questions = append(questions, *q)
+	}
+	return questions, nil
+}
+
+func (p *mdParser) parseQuestion() (*types.Question, error) {
+	id, err := mdParseInline(strings.TrimPrefix(p.line(), "## "))
+	if err != nil {
+		return nil, p.errorf("question id: %v", err)
+	}
+	q := &types.Question{ID: id}
+	p.pos++
+
+	var (
+		meta         = make(map[string]string)
+		hasStatement bool
+		checked      []string
+		hasAnswer    bool
+	)
+
+	// メタデータと問題文
+	for p.pos < len(p.lines) {
+		line := p.line()
+		if strings.HasPrefix(line, "## ") || strings.HasPrefix(line, "### ") {
+			break
+		}
+		switch {
+		case strings.TrimSpace(line) == "":
+			p.pos++
+		case mdFenceOpen.MatchString(line):
+			if hasStatement {
+				return nil, p.errorf("question %s has more than one statement code block", id)
+			}
+			if q.Statement, err = p.parseFence(); err != nil {
+				return nil, err
+			}
+			hasStatement = true
+		case mdMetaItem.MatchString(line):
+			m := mdMetaItem.FindStringSubmatch(line)
+			if _, dup := meta[m[1]]; dup {
+				return nil, p.errorf("duplicate %q", m[1])
+			}
+			meta[m[1]] = m[2]
+			p.pos++
+		default:
+			return nil, p.errorf("unexpected line %q", line)
+		}
+	}
+	if !hasStatement {
+		return nil, p.errorf("question %s has no statement code block", id)
+	}
+
+	for key, value := range meta {
+		switch key {
+		case "kind", "language", "difficulty", "match", "displayAnswer", "answer":
+			v, err := mdParseInline(value)
+			if err != nil {
+				return nil, p.errorf("%s: %v", key, err)
+			}
+			switch key {
+			case "kind":
+				q.Kind = types.QuestionKind(v)
+			case "language":
+				q.Language = v
+			case "difficulty":
+				q.Difficulty = v
+			case "match":
+				q.Match = types.MatchMode(v)
+			case "displayAnswer":
+				q.DisplayAnswer = v
+			case "answer":
+				q.Answer = v
+				hasAnswer = true
+			}
+		case "rating":
+			rating, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return nil, p.errorf("rating: %v", err)
+			}
+			q.Rating = rating
+		case "replaces":
+			replaces, err := strconv.ParseBool(strings.TrimSpace(value))
+			if err != nil {
+				return nil, p.errorf("replaces: %v", err)
+			}
+			q.Replaces = replaces
+		case "tags", "answers":
+			values, err := mdParseInlineList(value)
+			if err != nil {
+				return nil, p.errorf("%s: %v", key, err)
+			}
+			if key == "tags" {
+				q.Tags = values
+			} else {
+				q.Answers = values
+			}
+		default:
+			return nil, p.errorf("unknown metadata %q", key)
+		}
+	}
+
+	// セクション
+	seen := make(map[string]bool)
+	for p.pos < len(p.lines) && !strings.HasPrefix(p.line(), "## ") {
+		line := p.line()
+		if strings.TrimSpace(line) == "" {
+			p.pos++
+			continue
+		}
+		if !strings.HasPrefix(line, "### ") {
+			return nil, p.errorf("unexpected line %q", line)
+		}
+		section := strings.TrimSpace(strings.TrimPrefix(line, "### "))
+		if seen[section] {
+			return nil, p.errorf("duplicate section %q", section)
+		}
+		seen[section] = true
+		p.pos++
+
+		switch section {
+		case mdSectionChoices:
+			if checked, err = p.parseChoices(q); err != nil {
+				return nil, err
+			}
+		case mdSectionAnswer:
+			p.skipBlank()
+			if p.pos >= len(p.lines) || !mdFenceOpen.MatchString(p.line()) {
+				return nil, p.errorf("answer must be a code block")
+			}
+			if q.Answer, err = p.parseFence(); err != nil {
+				return nil, err
+			}
+			hasAnswer = true
+		case mdSectionExplanation:
+			if q.Explanation, err = p.parseExplanation(); err != nil {
+				return nil, err
+			}
+		case mdSectionRationales:
+			if q.ChoiceRationales, err = p.parseRationales(); err != nil {
+				return nil, err
+			}
+		case mdSectionReferences:
+			if q.References, err = p.parseReferences(); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, p.errorf("unknown section %q", section)
+		}
+	}
+
+	// メタデータで指定されていない答えはチェックボックスから求めます。
+	switch q.QuestionKind() {
+	case types.KindSingleChoice:
+		if !hasAnswer && len(checked) > 0 {
+			if len(checked) > 1 {
+				return nil, fmt.Errorf("markdown: question %s: single_choice has %d checked choices", id, len(checked))
+			}
+			q.Answer = checked[0]
+		}
+	case types.KindMultiSelect:
+		if _, ok := meta["answers"]; !ok {
+			q.Answers = checked
+		}
+	}
+	return q, nil
+}
+
+// parseFence はコードブロックを読み込み、その内容を返します。
+func (p *mdParser) parseFence() (string, error) {
+	start := p.pos
+	fence := mdFenceOpen.FindStringSubmatch(p.line())[1]
+	p.pos++
+	for ; p.pos < len(p.lines); p.pos++ {
+		if p.line() == fence {
+			content := strings.Join(p.lines[start+1:p.pos], "\n")
+			p.pos++
+			return content, nil
+		}
+	}
+	p.pos = start
+	return "", p.errorf("unclosed code block")
+}
+
+func (p *mdParser) skipBlank() {
+	for p.pos < len(p.lines) && strings.TrimSpace(p.line()) == "" {
+		p.pos++
+	}
+}
+
+// atSectionEnd は次の見出しまたはファイルの終わりに達したかを返します。
+func (p *mdParser) atSectionEnd() bool {
+	if p.pos >= len(p.lines) {
+		return true
+	}
+	line := p.line()
+	return strings.HasPrefix(line, "## ") || strings.HasPrefix(line, "### ")
+}
+
+// parseChoices は選択肢を読み込み、チェックされた選択肢を返します。
+func (p *mdParser) parseChoices(q *types.Question) ([]string, error) {
+	var checked []string
+	for p.skipBlank(); !p.atSectionEnd(); p.skipBlank() {
+		line := p.line()
+		var raw, mark string
+		if m := mdCheckItem.FindStringSubmatch(line); m != nil {
+			mark, raw = m[1], m[2]
+		} else if m := mdOrderedItem.FindStringSubmatch(line); m != nil {
+			raw = m[1]
+		} else if strings.HasPrefix(line, "- ") {
+			raw = strings.TrimPrefix(line, "- ")
+		} else {
+			return nil, p.errorf("unexpected choice %q", line)
+		}
+
+		choice, err := mdParseInline(raw)
+		if err != nil {
+			return nil, p.errorf("choice: %v", err)
+		}
+		q.Choices = append(q.Choices, choice)
+		if mark == "x" || mark == "X" {
+			checked = append(checked, choice)
+		}
+		p.pos++
+	}
+	return checked, nil
+}
+
+// parseExplanation は解説を読み込みます。コードブロックだけの場合はその内容を解説とします。
+func (p *mdParser) parseExplanation() (string, error) {
+	p.skipBlank()
+	if p.pos < len(p.lines) && mdFenceOpen.MatchString(p.line()) {
+		text, err := p.parseFence()
+		if err != nil {
+			return "", err
+		}
+		p.skipBlank()
+		if !p.atSectionEnd() {
+			return "", p.errorf("unexpected line after explanation code block")
+		}
+		return text, nil
+	}
+
+	start := p.pos
+	for !p.atSectionEnd() {
+		p.pos++
+	}
+	end := p.pos
+	for end > start && strings.TrimSpace(p.lines[end-1]) == "" {
+		end--
+	}
+	return strings.Join(p.lines[start:end], "\n"), nil
+}
+
+func (p *mdParser) parseRationales() (map[string]string, error) {
+	rationales := make(map[string]string)
+	for p.skipBlank(); !p.atSectionEnd(); p.skipBlank() {
+		line := p.line()
+		if !strings.HasPrefix(line, "- ") {
+			return nil, p.errorf("unexpected rationale %q", line)
+		}
+		key, rest, err := mdSplitInline(strings.TrimPrefix(line, "- "), ":")
+		if err != nil {
+			return nil, p.errorf("rationale: %v", err)
+		}
+		if !strings.HasPrefix(rest, ": ") {
+			return nil, p.errorf("rationale must be \"- choice: text\"")
+		}
+		value, err := mdParseInline(strings.TrimPrefix(rest, ": "))
+		if err != nil {
+			return nil, p.errorf("rationale: %v", err)
+		}
+		rationales[key] = value
+		p.pos++
+	}
+	return rationales, nil
+}
+
+func (p *mdParser) parseReferences() ([]types.Reference, error) {
+	var references []types.Reference
+	for p.skipBlank(); !p.atSectionEnd(); p.skipBlank() {
+		line := p.line()
+		if m := mdLinkItem.FindStringSubmatch(line); m != nil {
+			references = append(references, types.Reference{Title: m[1], URL: m[2]})
+			p.pos++
+			continue
+		}
+		if !strings.HasPrefix(line, `- "`) {
+			return nil, p.errorf("reference must be \"- [title](url)\"")
+		}
+		title, rest, err := mdSplitInline(strings.TrimPrefix(line, "- "), "")
+		if err != nil {
+			return nil, p.errorf("reference: %v", err)
+		}
+		url, err := strconv.Unquote(strings.TrimPrefix(rest, " "))
+		if err != nil {
+			return nil, p.errorf("reference: %v", err)
+		}
+		references = append(references, types.Reference{Title: title, URL: url})
+		p.pos++
+	}
+	return references, nil
+}
+
+// mdParseInline は mdInline で書いた値を読み込みます。
+func mdParseInline(s string) (string, error) {
+	if strings.HasPrefix(s, `"`) {
+		return strconv.Unquote(s)
+	}
+	return s, nil
+}
+
+// mdSplitInline は先頭の値を読み込み、残りの文字列とともに返します。
+// 引用符で始まらない値は sep の直前（sep が空の場合は行末）までとします。
+func mdSplitInline(s, sep string) (string, string, error) {
+	if strings.HasPrefix(s, `"`) {
+		quoted, err := strconv.QuotedPrefix(s)
+		if err != nil {
+			return "", "", err
+		}
+		value, err := strconv.Unquote(quoted)
+		return value, s[len(quoted):], err
+	}
+	if sep == "" {
+		return s, "", nil
+	}
+	if i := strings.Index(s, sep); i >= 0 {
+		return s[:i], s[i:], nil
+	}
+	return s, "", nil
+}
+
+// mdParseInlineList は mdInlineList で書いたリストを読み込みます。
+func mdParseInlineList(s string) ([]string, error) {
+	var values []string
+	for {
+		value, rest, err := mdSplitInline(s, ",")
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+		if rest == "" {
+			return values, nil
+		}
+		if !strings.HasPrefix(rest, ", ") {
+			return nil, fmt.Errorf("list items must be separated by \", \"")
+		}
+		s = strings.TrimPrefix(rest, ", ")
+	}
+}
@@ -0,0 +1,113 @@
+package codec
+
+import (
+	"reflect"
+	"server/src/internal/feature/quiz/types"
+	"strings"
+	"testing"
+)
+
+func TestMarkdownCodecFences(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement string
+		wantFence string
+	}{
+		{name: "通常のコードは ``` で囲む", statement: "int main(void) { return 0; }", wantFence: "```c\nint main(void) { return 0; }\n```\n"},
+		{name: "``` を含むコードはより長いフェンスで囲む", statement: "s := `\n```\n`", wantFence: "````c\ns := `\n```\n`\n````\n"},
+		{name: "```` を含むコードはさらに長いフェンス", statement: "a\n````\nb", wantFence: "`````c\na\n````\nb\n`````\n"},
+		{name: "見出しや区切り線に見える行もそのまま", statement: "## q2\n---\n- kind: free_text", wantFence: "```c\n## q2\n---\n- kind: free_text\n```\n"},
+		{name: "前後の空行を保つ", statement: "\nx\n\n", wantFence: "```c\n\nx\n\n\n```\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := types.Question{ID: "q1", Language: "C", Statement: tt.statement, Choices: []string{"1", "2"}, Answer: "1"}
+			encoded := assertRoundTrip(t, markdownCodec{}, []types.Question{q})
+			if !strings.Contains(encoded, tt.wantFence) {
+				t.Errorf("encoded Markdown does not contain %q\n%s", tt.wantFence, encoded)
+			}
+		})
+	}
+}
+
+func TestMarkdownCodecExplanationWithFence(t *testing.T) {
+	q := types.Question{
+		ID:          "q1",
+		Statement:   "print(1)",
+		Choices:     []string{"1", "2"},
+		Answer:      "1",
+		Explanation: "次のように書きます。\n```python\nprint(1)\n```",
+	}
+	encoded := assertRoundTrip(t, markdownCodec{}, []types.Question{q})
+	// 解説にコードブロックを含む場合は、解説全体をコードブロックにする
+	if !strings.Contains(encoded, "### Explanation\n\n````text\n") {
+		t.Errorf("explanation was not fenced\n%s", encoded)
+	}
+}
+
+func TestMarkdownCodecDecode(t *testing.T) {
+	input := "# 問題集\n\n前書きは読み飛ばします。\n\n" +
+		"## q1\n\n- language: C\n- difficulty: Easy\n- tags: pointer, string\n\n" +
+		"```c\nchar *s = \"Pi\";\nprintf(\"%c\", s[1]);\n```\n\n" +
+		"### Choices\n\n- [ ] P\n- [x] i\n\n" +
+		"### Explanation\n\ns[1] は2文字目です。\n\n" +
+		"### Rationales\n\n- P: s[0] です。\n\n" +
+		"### References\n\n- [printf](https://example.com/printf)\n\n" +
+		"## q2\n\n- kind: ordering\n\n```\nlines\n```\n\n### Choices\n\n1. a := 1\n2. println(a)\n\n" +
+		"## q3\n\n- kind: free_text\n- match: whitespace\n\n```go\nfmt.Println(1, 2)\n```\n\n### Answer\n\n```text\n1 2\n```\n"
+	want := []types.Question{
+		{
+			ID:               "q1",
+			Language:         "C",
+			Difficulty:       "Easy",
+			Tags:             []string{"pointer", "string"},
+			Statement:        "char *s = \"Pi\";\nprintf(\"%c\", s[1]);",
+			Choices:          []string{"P", "i"},
+			Answer:           "i",
+			Explanation:      "s[1] は2文字目です。",
+			ChoiceRationales: map[string]string{"P": "s[0] です。"},
+			References:       []types.Reference{{Title: "printf", URL: "https://example.com/printf"}},
+		},
+		{ID: "q2", Kind: types.KindOrdering, Statement: "lines", Choices: []string{"a := 1", "println(a)"}},
+		{ID: "q3", Kind: types.KindFreeText, Match: types.MatchWhitespace, Statement: "fmt.Println(1, 2)", Answer: "1 2"},
+	}
+
+	got, err := (markdownCodec{}).Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Decode() = %#v, want %#v", got, want)
+	}
+}
+
+func TestMarkdownCodecDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{name: "閉じていないコードブロック", input: "## q1\n\n```go\nx := 1\n", wantErr: "line 3: unclosed code block"},
+		{name: "問題文のコードブロックがない", input: "## q1\n\n- language: Go\n", wantErr: "no statement code block"},
+		{name: "問題文のコードブロックが2つ", input: "## q1\n\n```\na\n```\n```\nb\n```\n", wantErr: "more than one statement"},
+		{name: "未知のメタデータ", input: "## q1\n\n- level: 3\n\n```\na\n```\n", wantErr: `unknown metadata "level"`},
+		{name: "重複したメタデータ", input: "## q1\n\n- language: C\n- language: Go\n\n```\na\n```\n", wantErr: `duplicate "language"`},
+		{name: "数値でない難易度評価", input: "## q1\n\n- rating: hard\n\n```\na\n```\n", wantErr: "rating"},
+		{name: "真偽値でない replaces", input: "## q1\n\n- replaces: maybe\n\n```\na\n```\n", wantErr: "replaces"},
+		{name: "未知のセクション", input: "## q1\n\n```\na\n```\n\n### Hints\n\n- b\n", wantErr: `unknown section "Hints"`},
+		{name: "重複したセクション", input: "## q1\n\n```\na\n```\n\n### Choices\n\n- [x] a\n\n### Choices\n\n- [ ] b\n", wantErr: `duplicate section "Choices"`},
+		{name: "単一選択に複数のチェック", input: "## q1\n\n```\na\n```\n\n### Choices\n\n- [x] a\n- [x] b\n", wantErr: "2 checked choices"},
+		{name: "自由記述の答えがコードブロックでない", input: "## q1\n\n- kind: free_text\n\n```\na\n```\n\n### Answer\n\n1 2\n", wantErr: "answer must be a code block"},
+		{name: "閉じていない文字列リテラル", input: "## \"q1\n\n```\na\n```\n", wantErr: "question id"},
+		{name: "リストの区切りが不正", input: "## q1\n\n- tags: \"a\"b\n\n```\na\n```\n", wantErr: "tags"},
+		{name: "参考リンクの形式が不正", input: "## q1\n\n```\na\n```\n\n### References\n\n- https://example.com\n", wantErr: "reference must be"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := (markdownCodec{}).Decode(strings.NewReader(tt.input))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Decode() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
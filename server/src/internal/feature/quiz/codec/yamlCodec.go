@@ -0,0 +1,155 @@
+// server/src/internal/feature/quiz/codec/yamlCodec.go
+package codec
+
+import (
+	"io"
+	"server/src/internal/feature/quiz/types"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// yamlCodec はYAMLのシーケンス形式です。複数行の文字列（問題のコードなど）はブロックスカラー（|）で出力します。
+type yamlCodec struct{}
+
+func (yamlCodec) Name() string         { return "yaml" }
+func (yamlCodec) Extensions() []string { return []string{".yaml", ".yml"} }
+func (yamlCodec) ContentType() string  { return "application/yaml; charset=utf-8" }
+
+func (yamlCodec) Decode(r io.Reader) ([]types.Question, error) {
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+
+	var questions []types.Question
+	if err := dec.Decode(&questions); err != nil && err != io.EOF {
+		return nil, err
+	}
+	return questions, nil
+}
+
+// Encode は yaml.Node を組み立てて出力します。
+// yaml.v3 の自動的なスタイル選択では、先頭がタブや改行の複数行文字列を正しく出力できないため、
+// 文字列ごとにスタイルを指定します。キーは Question の yaml タグと同じです。
+func (yamlCodec) Encode(w io.Writer, questions []types.Question) error {
+	root := &yaml.Node{Kind: yaml.SequenceNode}
+	for i := range questions {
+		root.Content = append(root.Content, questionNode(&questions[i]))
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(root); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+func questionNode(q *types.Question) *yaml.Node {
+	m := &yaml.Node{Kind: yaml.MappingNode}
+	add := func(key string, value *yaml.Node) {
+		m.Content = append(m.Content, stringNode(key), value)
+	}
+	addString := func(key, value string, omitEmpty bool) {
+		if value != "" || !omitEmpty {
+			add(key, stringNode(value))
+		}
+	}
+	addList := func(key string, values []string) {
+		if len(values) > 0 {
+			add(key, stringListNode(values))
+		}
+	}
+
+	addString("id", q.ID, false)
+	addString("kind", string(q.Kind), true)
+	addString("language", q.Language, true)
+	addString("difficulty", q.Difficulty, true)
+	if q.Rating != 0 {
+		add("rating", &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.Itoa(q.Rating)})
+	}
+	addList("tags", q.Tags)
+	addString("statement", q.Statement, false)
+	addList("choices", q.Choices)
+	addString("answer", q.Answer, true)
+	addList("answers", q.Answers)
+	addString("match", string(q.Match), true)
+	addString("displayAnswer", q.DisplayAnswer, true)
+	addString("explanation", q.Explanation, true)
+
+	if len(q.ChoiceRationales) > 0 {
+		keys := make([]string, 0, len(q.ChoiceRationales))
+		for key := range q.ChoiceRationales {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		rationales := &yaml.Node{Kind: yaml.MappingNode}
+		for _, key := range keys {
+			rationales.Content = append(rationales.Content, stringNode(key), stringNode(q.ChoiceRationales[key]))
+		}
+		add("choiceRationales", rationales)
+	}
+
+	if len(q.References) > 0 {
+		references := &yaml.Node{Kind: yaml.SequenceNode}
+		for _, ref := range q.References {
+			references.Content = append(references.Content, &yaml.Node{
+				Kind:    yaml.MappingNode,
+				Content: []*yaml.Node{stringNode("title"), stringNode(ref.Title), stringNode("url"), stringNode(ref.URL)},
+			})
+		}
+		add("references", references)
+	}
+	if q.Replaces {
+		add("replaces", &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: "true"})
+	}
+	return m
+}
+
+func stringListNode(values []string) *yaml.Node {
+	n := &yaml.Node{Kind: yaml.SequenceNode}
+	for _, v := range values {
+		n.Content = append(n.Content, stringNode(v))
+	}
+	return n
+}
+
+// stringNode は文字列のノードを返します。複数行の文字列は、ブロックスカラーで同じ文字列に読み戻せる場合だけ
+// ブロックスカラーにし、それ以外はダブルクォートで出力します。
+func stringNode(s string) *yaml.Node {
+	n := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: s}
+	if strings.Contains(s, "\n") {
+		n.Style = yaml.DoubleQuotedStyle
+		if literalSafe(s) {
+			n.Style = yaml.LiteralStyle
+		}
+	}
+	return n
+}
+
+// literalSafe は文字列をブロックスカラーで出力しても失われる情報がないかを返します。
+func literalSafe(s string) bool {
+	if strings.ContainsAny(s[:1], " \t\n") || strings.Contains(s, "\r") {
+		return false
+	}
+	for _, line := range strings.Split(s, "\n") {
+		if strings.HasSuffix(line, " ") || strings.HasSuffix(line, "\t") {
+			return false
+		}
+	}
+
+	doc := &yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: "k"},
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: s, Style: yaml.LiteralStyle},
+	}}
+	data, err := yaml.Marshal(doc)
+	if err != nil {
+		return false
+	}
+	var decoded map[string]string
+	if err := yaml.Unmarshal(data, &decoded); err != nil {
+		return false
+	}
+	return decoded["k"] == s
+}
@@ -0,0 +1,115 @@
+package codec
+
+import (
+	"reflect"
+	"server/src/internal/feature/quiz/types"
+	"strings"
+	"testing"
+)
+
+func TestYAMLCodecMultilineStrings(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement string
+		want      string
+	}{
+		{
+			name:      "複数行のコードはブロックスカラー",
+			statement: "int main(void) {\n    return 0;\n}\n",
+			want:      "statement: |\n    int main(void) {\n        return 0;\n    }\n",
+		},
+		{
+			name:      "末尾の改行がないコードは |- で出力",
+			statement: "x := 1\nfmt.Println(x)",
+			want:      "statement: |-\n    x := 1\n    fmt.Println(x)\n",
+		},
+		{
+			name:      "タブでインデントされたコード",
+			statement: "func main() {\n\tprintln(1)\n}",
+			want:      "statement: |-\n    func main() {\n    \tprintln(1)\n    }\n",
+		},
+		{
+			name:      "先頭がインデントされたコードはダブルクォート",
+			statement: "    indented\nnext",
+			want:      `statement: "    indented\nnext"`,
+		},
+		{
+			name:      "行末の空白を含むコードはダブルクォート",
+			statement: "a  \nb",
+			want:      `statement: "a  \nb"`,
+		},
+		{
+			name:      "CRLF を含むコードはダブルクォート",
+			statement: "a\r\nb",
+			want:      `statement: "a\r\nb"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := types.Question{ID: "q1", Statement: tt.statement, Choices: []string{"1", "2"}, Answer: "1"}
+			encoded := assertRoundTrip(t, yamlCodec{}, []types.Question{q})
+			if !strings.Contains(encoded, tt.want) {
+				t.Errorf("encoded YAML does not contain %q\n%s", tt.want, encoded)
+			}
+		})
+	}
+}
+
+func TestYAMLCodecDecode(t *testing.T) {
+	input := `- id: q1
+  language: Go
+  statement: |
+    package main
+
+    func main() {
+    	println("hi")
+    }
+  kind: free_text
+  answer: hi
+  tags: [basics, output]
+`
+	want := []types.Question{{
+		ID:        "q1",
+		Kind:      types.KindFreeText,
+		Language:  "Go",
+		Statement: "package main\n\nfunc main() {\n\tprintln(\"hi\")\n}\n",
+		Answer:    "hi",
+		Tags:      []string{"basics", "output"},
+	}}
+
+	got, err := (yamlCodec{}).Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Decode() = %#v, want %#v", got, want)
+	}
+
+	empty, err := (yamlCodec{}).Decode(strings.NewReader(""))
+	if err != nil || len(empty) != 0 {
+		t.Errorf("Decode(\"\") = %v, %v, want no questions", empty, err)
+	}
+}
+
+func TestYAMLCodecDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{name: "未知のキー", input: "- id: q1\n  question: s\n", wantErr: "field question not found"},
+		{name: "シーケンスではない", input: "id: q1\n", wantErr: "cannot unmarshal"},
+		{name: "インデントの崩れ", input: "- id: q1\n statement: s\n", wantErr: "yaml:"},
+		{name: "閉じていない引用符", input: "- id: \"q1\n", wantErr: "yaml:"},
+		{name: "数値でない難易度評価", input: "- id: q1\n  rating: hard\n", wantErr: "cannot unmarshal"},
+		{name: "リストに文字列以外", input: "- id: q1\n  choices: {a: b}\n", wantErr: "cannot unmarshal"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := (yamlCodec{}).Decode(strings.NewReader(tt.input))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Decode() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+// server/src/internal/feature/quiz/handler/questionAdminHandler.go
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"server/src/internal/feature/quiz/codec"
+	"server/src/internal/feature/quiz/service"
+	"server/src/internal/feature/quiz/types"
+
+	"github.com/labstack/echo/v4"
+)
+
+// QuestionAdminHandler は問題バンク管理APIのリクエストを処理します。
+type QuestionAdminHandler struct {
+	service *service.QuestionAdminService
+}
+
+func NewQuestionAdminHandler(svc *service.QuestionAdminService) *QuestionAdminHandler {
+	return &QuestionAdminHandler{service: svc}
+}
+
+// ListQuestions は GET /admin/questions のリクエストを処理します。
+// language, difficulty, tag, kind クエリパラメータで絞り込めます。
+func (h *QuestionAdminHandler) ListQuestions(c echo.Context) error {
+	filter := service.QuestionFilter{
+		Language:   c.QueryParam("language"),
+		Difficulty: c.QueryParam("difficulty"),
+		Tag:        c.QueryParam("tag"),
+		Kind:       c.QueryParam("kind"),
+	}
+	return c.JSON(http.StatusOK, h.service.List(filter))
+}
+
+// GetQuestion は GET /admin/questions/:id のリクエストを処理します。
+func (h *QuestionAdminHandler) GetQuestion(c echo.Context) error {
+	question, err := h.service.Get(c.Param("id"))
+	if err != nil {
+		return questionErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, question)
+}
+
+// CreateQuestion は POST /admin/questions のリクエストを処理します。
+func (h *QuestionAdminHandler) CreateQuestion(c echo.Context) error {
+	question := new(types.Question)
+	if err := c.Bind(question); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+	if err := h.service.Create(question); err != nil {
+		return questionErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusCreated, question)
+}
+
+// UpdateQuestion は PUT /admin/questions/:id のリクエストを処理します。
+func (h *QuestionAdminHandler) UpdateQuestion(c echo.Context) error {
+	question := new(types.Question)
+	if err := c.Bind(question); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+	if err := h.service.Update(c.Param("id"), question); err != nil {
+		return questionErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, question)
+}
+
+// DeleteQuestion は DELETE /admin/questions/:id のリクエストを処理します。
+func (h *QuestionAdminHandler) DeleteQuestion(c echo.Context) error {
+	if err := h.service.Delete(c.Param("id")); err != nil {
+		return questionErrorResponse(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ExportQuestions は GET /admin/questions/export のリクエストを処理します。
+// format クエリパラメータ（json, yaml, csv, markdown。既定は json）の形式でダウンロードします。
+// 一覧と同じクエリパラメータで絞り込めます。
+func (h *QuestionAdminHandler) ExportQuestions(c echo.Context) error {
+	format := c.QueryParam("format")
+	if format == "" {
+		format = "json"
+	}
+	cd, err := codec.Lookup(format)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	filter := service.QuestionFilter{
+		Language:   c.QueryParam("language"),
+		Difficulty: c.QueryParam("difficulty"),
+		Tag:        c.QueryParam("tag"),
+		Kind:       c.QueryParam("kind"),
+	}
+	var buf bytes.Buffer
+	if err := cd.Encode(&buf, h.service.List(filter)); err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="questions%s"`, cd.Extensions()[0]))
+	return c.Blob(http.StatusOK, cd.ContentType(), buf.Bytes())
+}
+
+// ImportQuestions は POST /admin/questions/import のリクエストを処理します。
+// リクエストボディの形式は format クエリパラメータ、なければ Content-Type から判定します（既定は json）。
+func (h *QuestionAdminHandler) ImportQuestions(c echo.Context) error {
+	cd, err := importCodec(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	questions, err := cd.Decode(c.Request().Body)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	result, err := h.service.Import(questions)
+	if err != nil {
+		return questionErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+// importCodec は一括登録のリクエストボディの形式を判定します。
+func importCodec(c echo.Context) (codec.Codec, error) {
+	if format := c.QueryParam("format"); format != "" {
+		return codec.Lookup(format)
+	}
+	mediaType, _, _ := mime.ParseMediaType(c.Request().Header.Get(echo.HeaderContentType))
+	for _, name := range codec.Names() {
+		cd, _ := codec.Lookup(name)
+		if ct, _, _ := mime.ParseMediaType(cd.ContentType()); ct == mediaType {
+			return cd, nil
+		}
+	}
+	return codec.Lookup("json")
+}
+
+// questionErrorResponse はエラーの種類に応じたステータスコードでレスポンスを返します。
+func questionErrorResponse(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidQuestion):
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	case errors.Is(err, service.ErrQuestionNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	case errors.Is(err, service.ErrQuestionExists), errors.Is(err, service.ErrQuestionReadOnly), errors.Is(err, service.ErrQuestionReplaces):
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	default:
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+}
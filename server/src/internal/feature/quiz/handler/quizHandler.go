@@ -2,6 +2,7 @@
 package handler
 
 import (
+	"errors"
 	"log"
 	"net/http"
 	"server/src/internal/feature/quiz/service"
@@ -30,6 +31,9 @@ func NewQuizHandler(hub *websocket.RoomHub, svc *service.QuizService) *QuizHandl
 func (h *QuizHandler) StartGame(c echo.Context) error {
 	roomID := c.Param("roomId")
 	if err := h.service.StartGame(roomID); err != nil {
+		if errors.Is(err, service.ErrRoomOwned) {
+			return c.JSON(http.StatusForbidden, map[string]string{"error": err.Error()})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 	return c.JSON(http.StatusOK, map[string]string{"message": "Game started successfully"})
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"os"
+
+	"github.com/labstack/echo/v4"
+	echomiddleware "github.com/labstack/echo/v4/middleware"
+)
+
+// AdminAuth は管理APIへのリクエストを認証します。
+// Authorization: Bearer <ADMIN_TOKEN> ヘッダーが環境変数 ADMIN_TOKEN と一致する場合のみ許可し、
+// ADMIN_TOKEN が未設定の場合は管理API自体を無効にします。
+func AdminAuth() echo.MiddlewareFunc {
+	token := os.Getenv("ADMIN_TOKEN")
+	if token == "" {
+		return func(next echo.HandlerFunc) echo.HandlerFunc {
+			return func(c echo.Context) error {
+				return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "admin API is disabled (ADMIN_TOKEN is not set)"})
+			}
+		}
+	}
+
+	return echomiddleware.KeyAuthWithConfig(echomiddleware.KeyAuthConfig{
+		Validator: func(key string, c echo.Context) (bool, error) {
+			return subtle.ConstantTimeCompare([]byte(key), []byte(token)) == 1, nil
+		},
+		ErrorHandler: func(err error, c echo.Context) error {
+			return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		},
+	})
+}
@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestAdminAuth(t *testing.T) {
+	tests := []struct {
+		name          string
+		token         string
+		authorization string
+		want          int
+	}{
+		{name: "ADMIN_TOKEN 未設定なら無効", token: "", authorization: "Bearer anything", want: http.StatusServiceUnavailable},
+		{name: "トークンが一致", token: "secret", authorization: "Bearer secret", want: http.StatusOK},
+		{name: "トークンが不一致", token: "secret", authorization: "Bearer wrong", want: http.StatusUnauthorized},
+		{name: "ヘッダーなし", token: "secret", want: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("ADMIN_TOKEN", tt.token)
+			e := echo.New()
+			e.GET("/admin", func(c echo.Context) error { return c.NoContent(http.StatusOK) }, AdminAuth())
+
+			req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+			if tt.authorization != "" {
+				req.Header.Set(echo.HeaderAuthorization, tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"server/src/internal/database"
+	"server/src/internal/feature/quiz/types"
+)
+
+type QuestionRepository struct {
+	db *database.DBHandler
+}
+
+func NewQuestionRepository(db *database.DBHandler) *QuestionRepository {
+	return &QuestionRepository{db: db}
+}
+
+// FindAll は DynamoDB に保存されているすべての問題を取得
+func (r *QuestionRepository) FindAll() ([]types.Question, error) {
+	return r.db.ListQuestions()
+}
+
+// Save は DynamoDB に問題を保存（作成・上書き）
+func (r *QuestionRepository) Save(question *types.Question) error {
+	return r.db.WriteQuestion(question)
+}
+
+// Delete は DynamoDB から問題を削除
+func (r *QuestionRepository) Delete(id string) error {
+	return r.db.DeleteQuestion(id)
+}
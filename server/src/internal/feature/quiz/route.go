@@ -2,7 +2,11 @@
 package quiz
 
 import (
+	"log"
+	"server/src/internal/database"
 	"server/src/internal/feature/quiz/handler"
+	"server/src/internal/feature/quiz/middleware"
+	"server/src/internal/feature/quiz/repository"
 	"server/src/internal/feature/quiz/service"
 	"server/src/internal/feature/quiz/websocket"
 
@@ -18,3 +22,24 @@ func RegisterRoutes(g *echo.Group, hub *websocket.RoomHub, quizSvc *service.Quiz
 	g.GET("/ws/:roomId", h.ServeWs)
 	g.POST("/start/:roomId", h.StartGame) // ホストがゲームを開始するエンドポイント
 }
+
+// RegisterAdminRoutes は問題バンク管理APIの依存関係を解決し、ルートを登録します。
+// すべてのルートは ADMIN_TOKEN による認証が必要です。
+func RegisterAdminRoutes(g *echo.Group, db *database.DBHandler, quizSvc *service.QuizService) {
+	repo := repository.NewQuestionRepository(db)
+	svc := service.NewQuestionAdminService(repo, quizSvc)
+	// ストレージに保存された問題を問題バンクに反映（失敗しても問題ファイルのみで起動を続ける）
+	if err := svc.Reload(); err != nil {
+		log.Printf("warning: cannot load questions from storage: %v", err)
+	}
+	h := handler.NewQuestionAdminHandler(svc)
+
+	g.Use(middleware.AdminAuth())
+	g.GET("/questions", h.ListQuestions)
+	g.POST("/questions", h.CreateQuestion)
+	g.GET("/questions/export", h.ExportQuestions)
+	g.POST("/questions/import", h.ImportQuestions)
+	g.GET("/questions/:id", h.GetQuestion)
+	g.PUT("/questions/:id", h.UpdateQuestion)
+	g.DELETE("/questions/:id", h.DeleteQuestion)
+}
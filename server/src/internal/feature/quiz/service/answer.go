@@ -0,0 +1,194 @@
+// server/src/internal/feature/quiz/service/answer.go
+package service
+
+import (
+	"errors"
+	"fmt"
+	"server/src/internal/feature/quiz/types"
+	"strings"
+)
+
+// evaluation は1回答分の判定結果です。
+type evaluation struct {
+	ChoiceID  string   // 選択式の場合に選ばれた選択肢ID
+	ChoiceIDs []string // 複数選択・並べ替えの場合に選ばれた選択肢ID
+	Display   string   // 結果表示用の回答内容
+	IsCorrect bool
+	Credit    float64 // 部分点の割合（0〜1）
+}
+
+// evaluateAnswer は問題の形式に応じて回答を判定します。
+// 回答の形式が不正な場合はエラーを返します。
+func evaluateAnswer(q *types.Question, payload map[string]interface{}) (evaluation, error) {
+	switch q.QuestionKind() {
+	case types.KindFreeText:
+		return evaluateFreeText(q, payload)
+	case types.KindSingleChoice:
+		return evaluateSingleChoice(q, payload)
+	case types.KindMultiSelect:
+		return evaluateMultiSelect(q, payload)
+	case types.KindOrdering:
+		return evaluateOrdering(q, payload)
+	default:
+		return evaluation{}, fmt.Errorf("unsupported question kind %q", q.Kind)
+	}
+}
+
+// GradeAnswer はゲームの外（ソロ練習など）で1問分の回答を判定します。
+// ペイロードの形式は WebSocket の answer メッセージと同じです。得点は計算しません。
+func GradeAnswer(q *types.Question, payload map[string]interface{}) (types.PlayerAnswer, error) {
+	result, err := evaluateAnswer(q, payload)
+	if err != nil {
+		return types.PlayerAnswer{}, err
+	}
+	return types.PlayerAnswer{
+		Answered:  true,
+		ChoiceID:  result.ChoiceID,
+		ChoiceIDs: result.ChoiceIDs,
+		Choice:    result.Display,
+		IsCorrect: result.IsCorrect,
+		Credit:    result.Credit,
+	}, nil
+}
+
+// evaluateSingleChoice は選択式の回答（choiceId）を判定します。
+func evaluateSingleChoice(q *types.Question, payload map[string]interface{}) (evaluation, error) {
+	choiceID, _ := payload["choiceId"].(string)
+	if choiceID == "" {
+		// 旧クライアント互換: 選択肢の文字列で回答された場合はIDに変換する
+		if text, ok := payload["answer"].(string); ok {
+			choiceID = types.ChoiceID(q.ID, text)
+		}
+	}
+	choice, ok := q.FindChoice(choiceID)
+	if !ok {
+		return evaluation{}, fmt.Errorf("unknown choice %q", choiceID)
+	}
+	isCorrect := choice.Text == q.Answer
+	return evaluation{
+		ChoiceID:  choice.ID,
+		Display:   choice.Text,
+		IsCorrect: isCorrect,
+		Credit:    fullCredit(isCorrect),
+	}, nil
+}
+
+// evaluateMultiSelect は複数選択の回答（choiceIds）を判定します。
+// 部分点は（選んだ正解の数 - 選んだ不正解の数）/ 正解の数 で、0未満にはなりません。
+func evaluateMultiSelect(q *types.Question, payload map[string]interface{}) (evaluation, error) {
+	selected, err := choiceIDList(q, payload["choiceIds"])
+	if err != nil {
+		return evaluation{}, err
+	}
+
+	correct := make(map[string]bool, len(q.Answers))
+	for _, id := range q.CorrectChoiceIDs() {
+		correct[id] = true
+	}
+	hits, misses := 0, 0
+	texts := make([]string, len(selected))
+	for i, choice := range selected {
+		texts[i] = choice.Text
+		if correct[choice.ID] {
+			hits++
+		} else {
+			misses++
+		}
+	}
+
+	credit := float64(hits-misses) / float64(len(correct))
+	if credit < 0 {
+		credit = 0
+	}
+	isCorrect := hits == len(correct) && misses == 0
+	return evaluation{
+		ChoiceIDs: choiceIDs(selected),
+		Display:   strings.Join(texts, ", "),
+		IsCorrect: isCorrect,
+		Credit:    credit,
+	}, nil
+}
+
+// evaluateOrdering は並べ替えの回答（order）を判定します。
+// 回答はすべての選択肢を1回ずつ含む必要があり、部分点は正しい位置にある行の割合です。
+func evaluateOrdering(q *types.Question, payload map[string]interface{}) (evaluation, error) {
+	ordered, err := choiceIDList(q, payload["order"])
+	if err != nil {
+		return evaluation{}, err
+	}
+	if len(ordered) != len(q.Choices) {
+		return evaluation{}, fmt.Errorf("order must contain all %d lines", len(q.Choices))
+	}
+
+	expected := q.CorrectChoiceIDs()
+	matched := 0
+	texts := make([]string, len(ordered))
+	for i, choice := range ordered {
+		texts[i] = choice.Text
+		if choice.ID == expected[i] {
+			matched++
+		}
+	}
+	return evaluation{
+		ChoiceIDs: choiceIDs(ordered),
+		Display:   strings.Join(texts, "\n"),
+		IsCorrect: matched == len(expected),
+		Credit:    float64(matched) / float64(len(expected)),
+	}, nil
+}
+
+// choiceIDList はペイロードの選択肢ID配列を検証し、対応する選択肢を返します。
+// 配列でない場合、未知のIDや重複したIDを含む場合はエラーを返します。
+func choiceIDList(q *types.Question, raw interface{}) ([]types.Choice, error) {
+	items, ok := raw.([]interface{})
+	if !ok || len(items) == 0 {
+		return nil, errors.New("a non-empty array of choice ids is required")
+	}
+	choices := make([]types.Choice, 0, len(items))
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		id, ok := item.(string)
+		if !ok {
+			return nil, errors.New("choice ids must be strings")
+		}
+		if seen[id] {
+			return nil, fmt.Errorf("duplicate choice %q", id)
+		}
+		seen[id] = true
+		choice, ok := q.FindChoice(id)
+		if !ok {
+			return nil, fmt.Errorf("unknown choice %q", id)
+		}
+		choices = append(choices, choice)
+	}
+	return choices, nil
+}
+
+func choiceIDs(choices []types.Choice) []string {
+	ids := make([]string, len(choices))
+	for i, choice := range choices {
+		ids[i] = choice.ID
+	}
+	return ids
+}
+
+// fullCredit は正誤のみで判定する形式の部分点（0 または 1）を返します。
+func fullCredit(isCorrect bool) float64 {
+	if isCorrect {
+		return 1
+	}
+	return 0
+}
+
+// evaluateFreeText は自由記述の回答（text）を、問題の照合方法に従って判定します。
+func evaluateFreeText(q *types.Question, payload map[string]interface{}) (evaluation, error) {
+	text, ok := payload["text"].(string)
+	if !ok {
+		return evaluation{}, errors.New("text is required")
+	}
+	isCorrect, err := q.Match.Match(q.Answer, text)
+	if err != nil {
+		return evaluation{}, err
+	}
+	return evaluation{Display: text, IsCorrect: isCorrect, Credit: fullCredit(isCorrect)}, nil
+}
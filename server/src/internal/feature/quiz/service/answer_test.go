@@ -0,0 +1,144 @@
+package service
+
+import (
+	"reflect"
+	"server/src/internal/feature/quiz/types"
+	"testing"
+)
+
+func TestEvaluateAnswer(t *testing.T) {
+	single := &types.Question{ID: "q1", Choices: []string{"1", "2"}, Answer: "2"}
+	multi := &types.Question{ID: "q4", Kind: types.KindMultiSelect, Choices: []string{"a", "b", "c", "d"}, Answers: []string{"a", "b", "c"}}
+	ordering := &types.Question{ID: "q5", Kind: types.KindOrdering, Choices: []string{"l1", "l2", "l3", "l4"}}
+	ids := func(q *types.Question, texts ...string) []interface{} {
+		list := make([]interface{}, len(texts))
+		for i, text := range texts {
+			list[i] = types.ChoiceID(q.ID, text)
+		}
+		return list
+	}
+	strs := func(list []interface{}) []string {
+		out := make([]string, len(list))
+		for i, item := range list {
+			out[i] = item.(string)
+		}
+		return out
+	}
+	freeText := &types.Question{ID: "q2", Kind: types.KindFreeText, Answer: "Hello", Match: types.MatchCaseInsensitive}
+
+	tests := []struct {
+		name     string
+		question *types.Question
+		payload  map[string]interface{}
+		want     evaluation
+		wantErr  bool
+	}{
+		{
+			name:     "選択式: 選択肢IDで回答",
+			question: single,
+			payload:  map[string]interface{}{"choiceId": types.ChoiceID("q1", "2")},
+			want:     evaluation{ChoiceID: types.ChoiceID("q1", "2"), Display: "2", IsCorrect: true, Credit: 1},
+		},
+		{
+			name:     "選択式: 選択肢の文字列で回答",
+			question: single,
+			payload:  map[string]interface{}{"answer": "1"},
+			want:     evaluation{ChoiceID: types.ChoiceID("q1", "1"), Display: "1"},
+		},
+		{
+			name:     "選択式: 不明な選択肢",
+			question: single,
+			payload:  map[string]interface{}{"choiceId": "c_00000000"},
+			wantErr:  true,
+		},
+		{
+			name:     "自由記述: 照合方法に従って判定",
+			question: freeText,
+			payload:  map[string]interface{}{"text": "hello\n"},
+			want:     evaluation{Display: "hello\n", IsCorrect: true, Credit: 1},
+		},
+		{
+			name:     "自由記述: text がない",
+			question: freeText,
+			payload:  map[string]interface{}{"choiceId": "c_00000000"},
+			wantErr:  true,
+		},
+		{
+			name:     "複数選択: すべての正解を選べば満点",
+			question: multi,
+			payload:  map[string]interface{}{"choiceIds": ids(multi, "c", "a", "b")},
+			want:     evaluation{ChoiceIDs: strs(ids(multi, "c", "a", "b")), Display: "c, a, b", IsCorrect: true, Credit: 1},
+		},
+		{
+			name:     "複数選択: 正解の一部のみは部分点",
+			question: multi,
+			payload:  map[string]interface{}{"choiceIds": ids(multi, "a", "b")},
+			want:     evaluation{ChoiceIDs: strs(ids(multi, "a", "b")), Display: "a, b", Credit: 2.0 / 3},
+		},
+		{
+			name:     "複数選択: 不正解の選択で部分点を相殺",
+			question: multi,
+			payload:  map[string]interface{}{"choiceIds": ids(multi, "a", "b", "c", "d")},
+			want:     evaluation{ChoiceIDs: strs(ids(multi, "a", "b", "c", "d")), Display: "a, b, c, d", Credit: 2.0 / 3},
+		},
+		{
+			name:     "複数選択: 部分点は0未満にならない",
+			question: multi,
+			payload:  map[string]interface{}{"choiceIds": ids(multi, "d")},
+			want:     evaluation{ChoiceIDs: strs(ids(multi, "d")), Display: "d"},
+		},
+		{
+			name:     "複数選択: 重複した選択肢",
+			question: multi,
+			payload:  map[string]interface{}{"choiceIds": ids(multi, "a", "a")},
+			wantErr:  true,
+		},
+		{
+			name:     "複数選択: 空の配列",
+			question: multi,
+			payload:  map[string]interface{}{"choiceIds": []interface{}{}},
+			wantErr:  true,
+		},
+		{
+			name:     "並べ替え: 正しい順番",
+			question: ordering,
+			payload:  map[string]interface{}{"order": ids(ordering, "l1", "l2", "l3", "l4")},
+			want:     evaluation{ChoiceIDs: strs(ids(ordering, "l1", "l2", "l3", "l4")), Display: "l1\nl2\nl3\nl4", IsCorrect: true, Credit: 1},
+		},
+		{
+			name:     "並べ替え: 正しい位置の行の割合で部分点",
+			question: ordering,
+			payload:  map[string]interface{}{"order": ids(ordering, "l1", "l3", "l2", "l4")},
+			want:     evaluation{ChoiceIDs: strs(ids(ordering, "l1", "l3", "l2", "l4")), Display: "l1\nl3\nl2\nl4", Credit: 0.5},
+		},
+		{
+			name:     "並べ替え: 行が足りない",
+			question: ordering,
+			payload:  map[string]interface{}{"order": ids(ordering, "l1", "l2", "l3")},
+			wantErr:  true,
+		},
+		{
+			name:     "並べ替え: 文字列以外の要素",
+			question: ordering,
+			payload:  map[string]interface{}{"order": []interface{}{1, 2, 3, 4}},
+			wantErr:  true,
+		},
+		{
+			name:     "不明な形式",
+			question: &types.Question{ID: "q3", Kind: "essay"},
+			payload:  map[string]interface{}{"text": "x"},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := evaluateAnswer(tt.question, tt.payload)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("evaluateAnswer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("evaluateAnswer() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
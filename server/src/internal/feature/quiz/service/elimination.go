@@ -0,0 +1,140 @@
+// server/src/internal/feature/quiz/service/elimination.go
+package service
+
+import (
+	"log"
+	"server/src/internal/feature/quiz/types"
+	"sort"
+)
+
+const (
+	ELIMINATED_WRONG_ANSWER = "wrong_answer"
+	ELIMINATED_NO_ANSWER    = "no_answer"
+)
+
+// isActivePlayer はプレイヤーが現在の問題に回答できるかを返します。
+// 脱落モードでは、ゲーム開始時に参加していて脱落していないプレイヤーのみ回答できます。
+func isActivePlayer(state *types.GameState, userID string) bool {
+	if !seatedPlayer(state, userID) {
+		return false
+	}
+	if state.Settings.Mode != types.GameModeElimination {
+		return true
+	}
+	return state.PlayerStatuses[userID] == types.PlayerActive
+}
+
+// activePlayerIDs は脱落していないプレイヤーのIDを UserID 順に返します。
+func activePlayerIDs(state *types.GameState) []string {
+	ids := make([]string, 0, len(state.PlayerStatuses))
+	for userID, status := range state.PlayerStatuses {
+		if status == types.PlayerActive {
+			ids = append(ids, userID)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// eliminatePlayers は締め切った問題に正解できなかったプレイヤーを脱落させ、player_eliminated を送信します。
+// 2人以上残っていて全員が同時に正解できなかった場合は勝者がいなくなるため、誰も脱落させません。
+// 1人で遊んでいる場合は比べる相手がいないため、正解できなければそのまま脱落します。
+func (s *QuizService) eliminatePlayers(roomID string, state *types.GameState) {
+	if state.Settings.Mode != types.GameModeElimination {
+		return
+	}
+
+	active := activePlayerIDs(state)
+	var eliminated []types.Elimination
+	for _, userID := range active {
+		answer, answered := state.Answers[userID]
+		switch {
+		case !answered:
+			eliminated = append(eliminated, types.Elimination{UserID: userID, QuestionNumber: state.QuestionNumber, Reason: ELIMINATED_NO_ANSWER})
+		case !answer.IsCorrect:
+			eliminated = append(eliminated, types.Elimination{UserID: userID, QuestionNumber: state.QuestionNumber, Reason: ELIMINATED_WRONG_ANSWER})
+		}
+	}
+	if len(eliminated) == 0 {
+		return
+	}
+	if len(eliminated) == len(active) && len(active) > 1 {
+		log.Printf("All remaining players missed question %d in room %s; nobody is eliminated", state.QuestionNumber, roomID)
+		return
+	}
+
+	for _, e := range eliminated {
+		state.PlayerStatuses[e.UserID] = types.PlayerEliminated
+		state.Eliminations = append(state.Eliminations, e)
+	}
+	remaining := activePlayerIDs(state)
+	for _, e := range eliminated {
+		s.broadcast(&types.Message{
+			Type: "player_eliminated",
+			Payload: map[string]interface{}{
+				"userId":         e.UserID,
+				"questionNumber": e.QuestionNumber,
+				"reason":         e.Reason,
+				"remaining":      remaining,
+			},
+			RoomID: roomID,
+		})
+	}
+	log.Printf("Room %s: %d players eliminated on question %d, %d remaining", roomID, len(eliminated), state.QuestionNumber, len(remaining))
+}
+
+// survivorDecided は脱落モードで勝者が決まったか（残り1人以下になったか）を返します。
+// 1人で遊んでいる場合は、正解できずに脱落する（残り0人になる）か、出題候補がなくなるまで続けます。
+func survivorDecided(state *types.GameState) bool {
+	if state.Settings.Mode != types.GameModeElimination {
+		return false
+	}
+	remaining := len(activePlayerIDs(state))
+	return remaining == 0 || (remaining == 1 && len(state.PlayerStatuses) > 1)
+}
+
+// eliminationRanking は脱落モードの最終順位を作成します。
+// 最後まで残ったプレイヤーが上位で、脱落したプレイヤーは後に脱落したほど上位になります。
+// 同じ問題で脱落したプレイヤー同士（最後まで残ったプレイヤー同士）は得点で順位を決めます。
+func eliminationRanking(state *types.GameState) []types.PlayerResult {
+	eliminatedAt := make(map[string]int, len(state.Eliminations))
+	for _, e := range state.Eliminations {
+		eliminatedAt[e.UserID] = e.QuestionNumber
+	}
+
+	results := make([]types.PlayerResult, 0, len(state.Scores))
+	for userID, score := range state.Scores {
+		result := types.PlayerResult{UserID: userID, Score: score, Status: types.PlayerActive}
+		if status, ok := state.PlayerStatuses[userID]; !ok || status == types.PlayerEliminated {
+			// 途中から接続したプレイヤーは、誰よりも先に脱落した扱いにする
+			result.Status = types.PlayerEliminated
+			result.EliminatedAt = eliminatedAt[userID]
+		}
+		results = append(results, result)
+	}
+
+	// 生き残ったプレイヤーは脱落した誰よりも後まで残ったものとして比較する
+	survived := func(r types.PlayerResult) int {
+		if r.Status == types.PlayerActive {
+			return state.QuestionNumber + 1
+		}
+		return r.EliminatedAt
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if survived(results[i]) != survived(results[j]) {
+			return survived(results[i]) > survived(results[j])
+		}
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].UserID < results[j].UserID
+	})
+	for i := range results {
+		if i > 0 && survived(results[i]) == survived(results[i-1]) && results[i].Score == results[i-1].Score {
+			results[i].Rank = results[i-1].Rank
+		} else {
+			results[i].Rank = i + 1
+		}
+	}
+	return results
+}
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"fmt"
+	"server/src/internal/feature/quiz/types"
+	"testing"
+	"time"
+)
+
+// eliminationState は脱落モードで statuses のプレイヤーが参加し、answers の回答を受け付けた問題の状態を返します。
+func eliminationState(statuses map[string]types.PlayerStatus, answers map[string]bool) *types.GameState {
+	state := activeState(2, time.Now().Add(time.Minute))
+	state.Settings.Mode = types.GameModeElimination
+	state.Settings.AnswerMode = types.AnswerModeEveryone
+	state.PlayerStatuses = statuses
+	state.Scores = make(map[string]int)
+	for userID := range statuses {
+		state.Scores[userID] = 0
+	}
+	for userID, isCorrect := range answers {
+		state.Answers[userID] = types.PlayerAnswer{Answered: true, IsCorrect: isCorrect}
+	}
+	return state
+}
+
+func TestEliminatePlayers(t *testing.T) {
+	active, eliminated := types.PlayerActive, types.PlayerEliminated
+	tests := []struct {
+		name           string
+		classic        bool
+		statuses       map[string]types.PlayerStatus
+		answers        map[string]bool // Key: UserID, Value: 正解したか
+		wantEliminated []string        // 脱落の記録（"UserID:理由"）
+	}{
+		{
+			name:           "不正解と未回答のプレイヤーが脱落",
+			statuses:       map[string]types.PlayerStatus{"alice": active, "bob": active, "carol": active},
+			answers:        map[string]bool{"alice": true, "bob": false},
+			wantEliminated: []string{"bob:" + ELIMINATED_WRONG_ANSWER, "carol:" + ELIMINATED_NO_ANSWER},
+		},
+		{
+			name:     "全員正解なら誰も脱落しない",
+			statuses: map[string]types.PlayerStatus{"alice": active, "bob": active},
+			answers:  map[string]bool{"alice": true, "bob": true},
+		},
+		{
+			name:     "残っている全員が不正解なら誰も脱落しない",
+			statuses: map[string]types.PlayerStatus{"alice": active, "bob": active, "carol": eliminated},
+			answers:  map[string]bool{"alice": false},
+		},
+		{
+			name:           "1人で遊んでいて不正解なら脱落する",
+			statuses:       map[string]types.PlayerStatus{"alice": active},
+			answers:        map[string]bool{"alice": false},
+			wantEliminated: []string{"alice:" + ELIMINATED_WRONG_ANSWER},
+		},
+		{
+			name:           "脱落済みのプレイヤーは再び脱落しない",
+			statuses:       map[string]types.PlayerStatus{"alice": active, "bob": active, "carol": eliminated},
+			answers:        map[string]bool{"alice": true},
+			wantEliminated: []string{"bob:" + ELIMINATED_NO_ANSWER},
+		},
+		{
+			name:     "classic モードでは脱落しない",
+			classic:  true,
+			statuses: map[string]types.PlayerStatus{"alice": active, "bob": active},
+			answers:  map[string]bool{"alice": true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService()
+			state := eliminationState(tt.statuses, tt.answers)
+			if tt.classic {
+				state.Settings.Mode = types.GameModeClassic
+			}
+
+			s.eliminatePlayers(TEST_ROOM_ID, state)
+
+			var got []string
+			for _, e := range state.Eliminations {
+				got = append(got, e.UserID+":"+e.Reason)
+				if state.PlayerStatuses[e.UserID] != eliminated {
+					t.Errorf("%s status = %q, want eliminated", e.UserID, state.PlayerStatuses[e.UserID])
+				}
+				if e.QuestionNumber != state.QuestionNumber {
+					t.Errorf("%s QuestionNumber = %d, want %d", e.UserID, e.QuestionNumber, state.QuestionNumber)
+				}
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.wantEliminated) {
+				t.Errorf("eliminations = %v, want %v", got, tt.wantEliminated)
+			}
+			// 脱落したプレイヤーごとに player_eliminated を送信する
+			messages := drainMessageTypes(s)
+			if len(messages) != len(tt.wantEliminated) {
+				t.Errorf("messages = %v, want %d player_eliminated", messages, len(tt.wantEliminated))
+			}
+			for _, messageType := range messages {
+				if messageType != "player_eliminated" {
+					t.Errorf("message type = %q, want player_eliminated", messageType)
+				}
+			}
+		})
+	}
+}
+
+func TestSurvivorDecided(t *testing.T) {
+	active, eliminated := types.PlayerActive, types.PlayerEliminated
+	tests := []struct {
+		name     string
+		classic  bool
+		statuses map[string]types.PlayerStatus
+		want     bool
+	}{
+		{name: "2人以上残っている", statuses: map[string]types.PlayerStatus{"alice": active, "bob": active, "carol": eliminated}, want: false},
+		{name: "残り1人で勝者が決まる", statuses: map[string]types.PlayerStatus{"alice": active, "bob": eliminated}, want: true},
+		{name: "全員脱落", statuses: map[string]types.PlayerStatus{"alice": eliminated, "bob": eliminated}, want: true},
+		{name: "1人で遊んでいる間は続ける", statuses: map[string]types.PlayerStatus{"alice": active}, want: false},
+		{name: "1人で遊んでいて脱落したら終了", statuses: map[string]types.PlayerStatus{"alice": eliminated}, want: true},
+		{name: "classic モードでは判定しない", classic: true, statuses: map[string]types.PlayerStatus{"alice": active, "bob": eliminated}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := eliminationState(tt.statuses, nil)
+			if tt.classic {
+				state.Settings.Mode = types.GameModeClassic
+			}
+			if got := survivorDecided(state); got != tt.want {
+				t.Errorf("survivorDecided() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEliminationRanking(t *testing.T) {
+	state := eliminationState(map[string]types.PlayerStatus{
+		"alice": types.PlayerActive,
+		"bob":   types.PlayerEliminated,
+		"carol": types.PlayerEliminated,
+		"dave":  types.PlayerEliminated,
+	}, nil)
+	state.QuestionNumber = 4
+	state.Scores = map[string]int{"alice": 10, "bob": 30, "carol": 20, "dave": 20, "late": 50}
+	state.Eliminations = []types.Elimination{
+		{UserID: "carol", QuestionNumber: 2},
+		{UserID: "dave", QuestionNumber: 2},
+		{UserID: "bob", QuestionNumber: 3},
+	}
+
+	var got []string
+	for _, r := range eliminationRanking(state) {
+		got = append(got, fmt.Sprintf("%d:%s:%s:%d", r.Rank, r.UserID, r.Status, r.EliminatedAt))
+	}
+	// 生き残ったプレイヤーが得点に関係なく1位、後に脱落したほど上位、同じ問題で脱落した同点は同順位、途中参加は最下位
+	want := []string{
+		"1:alice:active:0",
+		"2:bob:eliminated:3",
+		"3:carol:eliminated:2",
+		"3:dave:eliminated:2",
+		"5:late:eliminated:0",
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("ranking = %v, want %v", got, want)
+	}
+}
+
+func TestProcessAnswerEliminatedPlayer(t *testing.T) {
+	s := newTestService()
+	joinPlayers(t, s, "alice", "bob", "carol")
+	state := eliminationState(map[string]types.PlayerStatus{
+		"alice": types.PlayerActive,
+		"bob":   types.PlayerActive,
+		"carol": types.PlayerEliminated,
+	}, nil)
+	installGame(s, state)
+
+	// 脱落したプレイヤーの回答は受け付けない
+	s.processAnswer(TEST_ROOM_ID, "carol", state, map[string]interface{}{"choiceId": types.ChoiceID("q1", "2")})
+	if message := nextMessage(t, s); message.Type != "answer_error" || message.UserID != "carol" {
+		t.Fatalf("message = %+v, want answer_error to carol", message)
+	}
+	if _, answered := state.Answers["carol"]; answered {
+		t.Error("eliminated player's answer was recorded")
+	}
+
+	// 観戦者は待たずに、残っているプレイヤー全員の回答で締め切る
+	s.processAnswer(TEST_ROOM_ID, "alice", state, map[string]interface{}{"choiceId": types.ChoiceID("q1", "2")})
+	if !state.IsQuestionActive {
+		t.Fatal("question closed before every active player answered")
+	}
+	s.processAnswer(TEST_ROOM_ID, "bob", state, map[string]interface{}{"choiceId": types.ChoiceID("q1", "1")})
+	stopGameTimers(state)
+	if state.IsQuestionActive {
+		t.Fatal("question is still active after every active player answered")
+	}
+	if state.PlayerStatuses["bob"] != types.PlayerEliminated {
+		t.Errorf("bob status = %q, want eliminated", state.PlayerStatuses["bob"])
+	}
+}
+
+func TestNextQuestionEndsWhenSurvivorDecided(t *testing.T) {
+	s := newTestService(testQuestions(3)...)
+	state := eliminationState(map[string]types.PlayerStatus{"alice": types.PlayerActive, "bob": types.PlayerEliminated}, nil)
+	state.IsQuestionActive = false
+	state.TotalQuestions = 3
+	state.UsedQuestionIDs = []string{"q1"}
+	state.QuestionPool = buildQuestionPool(s.questions, state.Settings)
+	installGame(s, state)
+
+	s.nextQuestion(TEST_ROOM_ID, state)
+
+	if got, want := drainMessageTypes(s), []string{"game_summary", "game_over"}; fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("messages = %v, want %v", got, want)
+	}
+	if _, ok := s.game(TEST_ROOM_ID); ok {
+		t.Error("game state was not removed")
+	}
+}
@@ -0,0 +1,22 @@
+package service
+
+import "errors"
+
+var (
+	ErrQuestionNotFound = errors.New("question not found")
+	ErrQuestionExists   = errors.New("question ID already exists")
+	ErrQuestionReadOnly = errors.New("question is defined in the bundled question bank and cannot be deleted")
+	ErrInvalidQuestion  = errors.New("invalid question")
+	ErrQuestionReplaces = errors.New("question ID is defined in the question files; set replaces to override it")
+
+	ErrNoGameInProgress = errors.New("no game in progress")
+	ErrNotHostControl   = errors.New("only the host can control the game")
+	ErrHostControlOff   = errors.New("host controls are disabled in this room")
+	ErrRoomOwned        = errors.New("the game in this room can only be started by the feature that manages it")
+	ErrGamePaused       = errors.New("game is paused")
+	ErrGameNotPaused    = errors.New("game is not paused")
+	ErrGameInProgress   = errors.New("game is in progress")
+	ErrGameBusy         = errors.New("game is busy, please retry")
+	ErrNoFinishedGame   = errors.New("no finished game to rematch")
+	ErrNoRematch        = errors.New("rematch has not been requested")
+)
@@ -0,0 +1,125 @@
+// server/src/internal/feature/quiz/service/gameLoop.go
+package service
+
+import (
+	"server/src/internal/feature/quiz/types"
+	"sync"
+)
+
+// GAME_LOOP_INBOX_SIZE は1つのゲームの inbox に積んでおける処理の数です。
+// クライアントからのメッセージで inbox が埋まっている場合、そのメッセージは ErrGameBusy で拒否します。
+const GAME_LOOP_INBOX_SIZE = 64
+
+// gameLoop は1つのルームのゲームを進行する goroutine（アクター）です。
+//
+// ゲーム状態はこの goroutine だけが読み書きし、回答・タイマー・ホストの操作はすべて inbox に積んで順番に処理します。
+// ルームごとに独立しているため、あるルームの処理が遅れても他のルームの回答を止めることはなく、
+// ゲーム状態を扱う関数はロックを取る必要がありません。
+type gameLoop struct {
+	roomID string
+	state  *types.GameState
+	inbox  chan func()
+	done   chan struct{}
+	exited chan struct{} // run が終了すると閉じる
+	once   sync.Once
+}
+
+func newGameLoop(roomID string, state *types.GameState) *gameLoop {
+	return &gameLoop{
+		roomID: roomID,
+		state:  state,
+		inbox:  make(chan func(), GAME_LOOP_INBOX_SIZE),
+		done:   make(chan struct{}),
+		exited: make(chan struct{}),
+	}
+}
+
+// run は inbox に積まれた処理を順番に実行します。ゲームが終了すると、すべてのタイマーを止めて終了します。
+func (g *gameLoop) run() {
+	defer close(g.exited)
+	defer stopGameTimers(g.state)
+	for {
+		select {
+		case <-g.done:
+			return
+		default:
+		}
+
+		select {
+		case <-g.done:
+			return
+		case event := <-g.inbox:
+			event()
+		}
+	}
+}
+
+// send は処理を inbox に積みます。ゲームが既に終了している場合は破棄し、false を返します。
+func (g *gameLoop) send(event func()) bool {
+	select {
+	case <-g.done:
+		return false
+	default:
+	}
+
+	select {
+	case g.inbox <- event:
+		return true
+	case <-g.done:
+		return false
+	}
+}
+
+// trySend は処理を inbox に積みます。待たずに積めない場合は ErrGameBusy を返します。
+// ハブのRunループから呼び出すため、ゲームの goroutine の処理が詰まっていてもブロックしません。
+func (g *gameLoop) trySend(event func()) error {
+	select {
+	case <-g.done:
+		return ErrNoGameInProgress
+	default:
+	}
+
+	select {
+	case g.inbox <- event:
+		return nil
+	default:
+		return ErrGameBusy
+	}
+}
+
+// stop はゲームの goroutine を終了させます。何度呼び出しても構いません。
+func (g *gameLoop) stop() {
+	g.once.Do(func() {
+		close(g.done)
+	})
+}
+
+// game は進行中のゲームを返します。
+func (s *QuizService) game(roomID string) (*gameLoop, bool) {
+	s.gamesMu.Lock()
+	defer s.gamesMu.Unlock()
+	g, ok := s.games[roomID]
+	return g, ok
+}
+
+// dispatch はルームで進行中のゲームの goroutine で fn を実行します。
+// ハブのRunループから呼び出すため待機はせず、ゲームがない場合は ErrNoGameInProgress を、inbox が埋まっている場合は ErrGameBusy を返します。
+func (s *QuizService) dispatch(roomID string, fn func(state *types.GameState)) error {
+	g, ok := s.game(roomID)
+	if !ok {
+		return ErrNoGameInProgress
+	}
+	return g.trySend(func() {
+		fn(g.state)
+	})
+}
+
+// dispatchTo は state のゲームがまだ進行中の場合のみ、そのゲームの goroutine で fn を実行します。
+// タイマーから使用し、終了・再開始したゲームに古いタイマーの処理が届かないようにします。
+func (s *QuizService) dispatchTo(roomID string, state *types.GameState, fn func()) {
+	g, ok := s.game(roomID)
+	if !ok || g.state != state {
+		return
+	}
+	g.send(fn)
+}
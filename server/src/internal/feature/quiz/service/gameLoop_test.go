@@ -0,0 +1,212 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"server/src/internal/feature/quiz/types"
+	"testing"
+	"time"
+)
+
+func TestGameLoopRunsEventsInOrder(t *testing.T) {
+	g := newGameLoop(TEST_ROOM_ID, activeState(1, time.Now().Add(time.Minute)))
+	go g.run()
+	defer g.stop()
+
+	var order []int
+	for i := 1; i <= 3; i++ {
+		i := i
+		g.send(func() { order = append(order, i) })
+	}
+	inGame(t, g, func() {})
+
+	if fmt.Sprint(order) != "[1 2 3]" {
+		t.Errorf("order = %v, want [1 2 3]", order)
+	}
+}
+
+func TestGameLoopStop(t *testing.T) {
+	state := activeState(1, time.Now().Add(time.Minute))
+	state.QuestionTimer = time.AfterFunc(time.Hour, func() {})
+	timer := state.QuestionTimer
+	g := newGameLoop(TEST_ROOM_ID, state)
+	exited := make(chan struct{})
+	go func() {
+		g.run()
+		close(exited)
+	}()
+
+	g.stop()
+	g.stop() // 2回目以降は何もしない
+
+	select {
+	case <-exited:
+	case <-time.After(time.Second):
+		t.Fatal("game loop did not exit")
+	}
+	if g.send(func() { t.Error("event ran after stop") }) {
+		t.Error("send() = true after stop, want false")
+	}
+	if timer.Stop() {
+		t.Error("question timer was not stopped when the game loop exited")
+	}
+}
+
+func TestDispatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		running bool
+		want    error
+	}{
+		{name: "進行中のゲームで実行する", running: true},
+		{name: "ゲームがなければ実行しない", running: false, want: ErrNoGameInProgress},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService()
+			state := activeState(1, time.Now().Add(time.Minute))
+			if tt.running {
+				runGame(t, s, state)
+			}
+
+			received := make(chan *types.GameState, 1)
+			err := s.dispatch(TEST_ROOM_ID, func(got *types.GameState) { received <- got })
+
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("dispatch() error = %v, want %v", err, tt.want)
+			}
+			if tt.want != nil {
+				return
+			}
+			select {
+			case got := <-received:
+				if got != state {
+					t.Error("dispatched function received another game state")
+				}
+			case <-time.After(time.Second):
+				t.Fatal("dispatched function did not run")
+			}
+		})
+	}
+}
+
+func TestDispatchToIgnoresReplacedGame(t *testing.T) {
+	s := newTestService()
+	oldState := activeState(1, time.Now().Add(time.Minute))
+	g := installGame(s, activeState(1, time.Now().Add(time.Minute)))
+
+	// 置き換えられたゲームのタイマーからの処理は新しいゲームに届けない
+	s.dispatchTo(TEST_ROOM_ID, oldState, func() { t.Error("stale event was dispatched") })
+	if len(g.inbox) != 0 {
+		t.Fatalf("len(inbox) = %d, want 0", len(g.inbox))
+	}
+
+	s.dispatchTo(TEST_ROOM_ID, g.state, func() {})
+	if len(g.inbox) != 1 {
+		t.Errorf("len(inbox) = %d, want 1", len(g.inbox))
+	}
+}
+
+func TestStartGameReplacesRunningGame(t *testing.T) {
+	s := newTestService(testQuestions(3)...)
+	if err := s.StartGame(TEST_ROOM_ID); err != nil {
+		t.Fatalf("StartGame() error = %v", err)
+	}
+	first, _ := s.game(TEST_ROOM_ID)
+
+	if err := s.StartGame(TEST_ROOM_ID); err != nil {
+		t.Fatalf("StartGame() error = %v", err)
+	}
+	second, _ := s.game(TEST_ROOM_ID)
+	defer second.stop()
+
+	if second == first {
+		t.Fatal("game loop was not replaced")
+	}
+	if first.send(func() {}) {
+		t.Error("replaced game loop is still running")
+	}
+}
+
+func TestProcessClientMessageAnswer(t *testing.T) {
+	s := newTestService()
+	state := activeState(1, time.Now().Add(time.Minute))
+	g := runGame(t, s, state)
+
+	s.ProcessClientMessage(TEST_ROOM_ID, "alice", []byte(`{"type":"answer","payload":{"answer":"2"}}`))
+
+	if message := nextMessage(t, s); message.Type != "answer_result" {
+		t.Fatalf("message type = %q, want answer_result", message.Type)
+	}
+	var score int
+	inGame(t, g, func() { score = state.Scores["alice"] })
+	if score == 0 {
+		t.Error("answer was not scored in the game loop")
+	}
+}
+
+func TestGameLoopTrySend(t *testing.T) {
+	tests := []struct {
+		name    string
+		queued  int
+		stopped bool
+		want    error
+	}{
+		{name: "空きがあれば積む", queued: 0},
+		{name: "inbox が埋まっていれば待たずに拒否", queued: GAME_LOOP_INBOX_SIZE, want: ErrGameBusy},
+		{name: "終了したゲームには積まない", stopped: true, want: ErrNoGameInProgress},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// goroutine を起動しないため、積んだ処理は取り出されない
+			g := newGameLoop(TEST_ROOM_ID, activeState(1, time.Now().Add(time.Minute)))
+			for i := 0; i < tt.queued; i++ {
+				g.send(func() {})
+			}
+			if tt.stopped {
+				g.stop()
+			}
+
+			if err := g.trySend(func() {}); !errors.Is(err, tt.want) {
+				t.Errorf("trySend() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestProcessClientMessageBusyGame(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    string
+	}{
+		{name: "回答は answer_error で再送を促す", message: `{"type":"answer","payload":{"answer":"2"}}`, want: "answer_error"},
+		{name: "ホストの操作は control_error で再送を促す", message: `{"type":"pause"}`, want: "control_error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService()
+			g := installGame(s, hostState(time.Minute))
+			for i := 0; i < GAME_LOOP_INBOX_SIZE; i++ {
+				g.send(func() {})
+			}
+
+			done := make(chan struct{})
+			go func() {
+				s.ProcessClientMessage(TEST_ROOM_ID, "alice", []byte(tt.message))
+				close(done)
+			}()
+			select {
+			case <-done:
+			case <-time.After(time.Second):
+				t.Fatal("ProcessClientMessage blocked on a busy game")
+			}
+
+			message := nextMessage(t, s)
+			payload := message.Payload.(map[string]interface{})
+			if message.Type != tt.want || message.UserID != "alice" || payload["message"] != ErrGameBusy.Error() {
+				t.Fatalf("message = %+v, want %s %q to alice", message, tt.want, ErrGameBusy)
+			}
+		})
+	}
+}
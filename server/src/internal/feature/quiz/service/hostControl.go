@@ -0,0 +1,166 @@
+// server/src/internal/feature/quiz/service/hostControl.go
+package service
+
+import (
+	"log"
+	"server/src/internal/feature/quiz/types"
+	"time"
+)
+
+// ホストがゲームの進行を操作する WebSocket メッセージの種類です。
+const (
+	CONTROL_PAUSE         = "pause"         // 制限時間を止め、回答の受付を停止する
+	CONTROL_RESUME        = "resume"        // 一時停止した時点の残り時間から再開する
+	CONTROL_SKIP_QUESTION = "skip_question" // 現在の問題を締め切って正解を公開し、次の問題へ進む
+	CONTROL_ABORT_GAME    = "abort_game"    // 現在の順位でゲームを終了する
+)
+
+// CLOSE_REASON_SKIPPED はホストが問題をスキップしたときの question_result の reason です。
+const CLOSE_REASON_SKIPPED = "skipped"
+
+// processHostControl はホストからのゲーム進行の操作を処理し、結果を全員に送信します。
+// ホスト以外からの操作や、実行できない状態での操作は control_error で操作したユーザーにのみ通知します。
+// 他の機能が管理するルーム（デイリーチャレンジなど）では、全員が同じ条件で挑戦できるよう操作を受け付けません。
+func (s *QuizService) processHostControl(roomID, userID, command string, state *types.GameState) {
+	if state.Settings.Owner != "" {
+		s.sendControlError(roomID, userID, command, ErrHostControlOff)
+		return
+	}
+	if state.Settings.HostID == "" || state.Settings.HostID != userID {
+		s.sendControlError(roomID, userID, command, ErrNotHostControl)
+		return
+	}
+
+	var err error
+	switch command {
+	case CONTROL_PAUSE:
+		err = s.pauseGame(roomID, userID, state)
+	case CONTROL_RESUME:
+		err = s.resumeGame(roomID, userID, state)
+	case CONTROL_SKIP_QUESTION:
+		err = s.skipQuestion(roomID, userID, state)
+	case CONTROL_ABORT_GAME:
+		s.abortGame(roomID, userID, state)
+	}
+	if err != nil {
+		s.sendControlError(roomID, userID, command, err)
+	}
+}
+
+// sendControlError は実行できなかった操作の理由を、操作したユーザーにのみ通知します。
+func (s *QuizService) sendControlError(roomID, userID, command string, err error) {
+	s.broadcast(&types.Message{
+		Type: "control_error",
+		Payload: map[string]interface{}{
+			"command": command,
+			"message": err.Error(),
+		},
+		RoomID: roomID,
+		UserID: userID,
+	})
+}
+
+// pauseGame は制限時間または現在の段階のタイマーを止め、残り時間を記録します。
+// カウントダウン・正解発表・休憩の途中で停止した場合も、再開するまで次の問題へ進みません。
+func (s *QuizService) pauseGame(roomID, userID string, state *types.GameState) error {
+	if state.Paused {
+		return ErrGamePaused
+	}
+
+	now := time.Now()
+	state.Paused = true
+	state.PausedAt = now
+	payload := map[string]interface{}{
+		"pausedBy":       userID,
+		"questionNumber": state.QuestionNumber,
+		"phase":          state.Phase,
+	}
+	if state.IsQuestionActive {
+		stopQuestionTimer(state)
+		state.RemainingTime = state.QuestionDeadline.Sub(now)
+	} else {
+		cancelPhase(state)
+		state.RemainingTime = state.PhaseEndsAt.Sub(now)
+	}
+	if state.RemainingTime < 0 {
+		state.RemainingTime = 0
+	}
+	payload["remaining"] = state.RemainingTime.Milliseconds()
+
+	log.Printf("Game paused in room %s at question %d", roomID, state.QuestionNumber)
+	s.broadcast(&types.Message{Type: "game_paused", Payload: payload, RoomID: roomID})
+	return nil
+}
+
+// resumeGame は一時停止した時点の残り時間で制限時間を再開します。
+// 出題時刻も停止していた時間だけ遅らせ、早く答えるほど高得点になる得点計算に停止中の時間を含めないようにします。
+func (s *QuizService) resumeGame(roomID, userID string, state *types.GameState) error {
+	if !state.Paused {
+		return ErrGameNotPaused
+	}
+
+	now := time.Now()
+	state.Paused = false
+	payload := map[string]interface{}{
+		"resumedBy":      userID,
+		"questionNumber": state.QuestionNumber,
+		"phase":          state.Phase,
+		"serverTime":     now.UnixMilli(),
+	}
+	if state.IsQuestionActive {
+		state.QuestionStartedAt = state.QuestionStartedAt.Add(now.Sub(state.PausedAt))
+		state.QuestionDeadline = now.Add(state.RemainingTime)
+		s.startQuestionTimer(roomID, state, state.RemainingTime)
+		payload["deadline"] = state.QuestionDeadline.UnixMilli()
+	} else {
+		state.PhaseEndsAt = now.Add(state.RemainingTime)
+		s.startPhaseTimer(roomID, state, state.RemainingTime)
+		payload["phaseEndsAt"] = state.PhaseEndsAt.UnixMilli()
+	}
+
+	log.Printf("Game resumed in room %s at question %d", roomID, state.QuestionNumber)
+	s.broadcast(&types.Message{Type: "game_resumed", Payload: payload, RoomID: roomID})
+	return nil
+}
+
+// skipQuestion は出題中の問題を締め切って正解を公開し、正解発表の後に次の問題へ進めます。
+// カウントダウン・正解発表・休憩の途中でスキップした場合は、すぐに次の問題へ進みます。一時停止中はスキップできません。
+func (s *QuizService) skipQuestion(roomID, userID string, state *types.GameState) error {
+	if state.Paused {
+		return ErrGamePaused
+	}
+
+	log.Printf("Question %d skipped in room %s", state.QuestionNumber, roomID)
+	s.broadcast(&types.Message{
+		Type: "question_skipped",
+		Payload: map[string]interface{}{
+			"skippedBy":      userID,
+			"questionNumber": state.QuestionNumber,
+		},
+		RoomID: roomID,
+	})
+	if state.IsQuestionActive {
+		s.closeQuestion(roomID, state, CLOSE_REASON_SKIPPED)
+	} else {
+		s.nextQuestion(roomID, state)
+	}
+	return nil
+}
+
+// abortGame は出題中の問題を採点せずに、現在の順位でゲームを終了します。
+func (s *QuizService) abortGame(roomID, userID string, state *types.GameState) {
+	state.IsQuestionActive = false
+	state.Aborted = true
+
+	log.Printf("Game aborted in room %s at question %d", roomID, state.QuestionNumber)
+	s.broadcast(&types.Message{
+		Type: "game_aborted",
+		Payload: withTeams(state, map[string]interface{}{
+			"abortedBy":      userID,
+			"questionNumber": state.QuestionNumber,
+			"scores":         snapshotScores(state.Scores),
+		}),
+		RoomID: roomID,
+	})
+	s.endGame(roomID, state)
+}
@@ -0,0 +1,277 @@
+package service
+
+import (
+	"fmt"
+	"server/src/internal/feature/quiz/types"
+	"testing"
+	"time"
+)
+
+// hostState は alice がホストのゲームで、出題中の問題の状態を返します。
+func hostState(deadline time.Duration) *types.GameState {
+	state := activeState(1, time.Now().Add(deadline))
+	state.Settings.HostID = "alice"
+	state.QuestionStartedAt = time.Now()
+	state.TotalQuestions = 1
+	return state
+}
+
+func TestProcessHostControlRejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		owner   string
+		paused  bool
+		userID  string
+		command string
+		want    error
+	}{
+		{name: "他の機能が管理するルームではホストも操作できない", owner: "daily", userID: "alice", command: CONTROL_PAUSE, want: ErrHostControlOff},
+		{name: "ホスト以外は操作できない", userID: "bob", command: CONTROL_PAUSE, want: ErrNotHostControl},
+		{name: "ホスト以外は中断できない", userID: "bob", command: CONTROL_ABORT_GAME, want: ErrNotHostControl},
+		{name: "一時停止中に一時停止", paused: true, userID: "alice", command: CONTROL_PAUSE, want: ErrGamePaused},
+		{name: "一時停止していないのに再開", userID: "alice", command: CONTROL_RESUME, want: ErrGameNotPaused},
+		{name: "一時停止中はスキップできない", paused: true, userID: "alice", command: CONTROL_SKIP_QUESTION, want: ErrGamePaused},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService()
+			state := hostState(time.Minute)
+			state.Paused = tt.paused
+			state.Settings.Owner = tt.owner
+			installGame(s, state)
+
+			s.processHostControl(TEST_ROOM_ID, tt.userID, tt.command, state)
+
+			message := nextMessage(t, s)
+			if message.Type != "control_error" || message.UserID != tt.userID {
+				t.Fatalf("message = %+v, want control_error to %s", message, tt.userID)
+			}
+			payload := message.Payload.(map[string]interface{})
+			if payload["command"] != tt.command || payload["message"] != tt.want.Error() {
+				t.Errorf("payload = %v, want %q for %q", payload, tt.want, tt.command)
+			}
+			assertNoMessage(t, s)
+			if state.Paused != tt.paused || !state.IsQuestionActive {
+				t.Error("rejected command changed the game state")
+			}
+		})
+	}
+}
+
+func TestPauseAndResume(t *testing.T) {
+	s := newTestService()
+	state := hostState(10 * time.Second)
+	installGame(s, state)
+	s.startQuestionTimer(TEST_ROOM_ID, state, 10*time.Second)
+	defer stopGameTimers(state)
+
+	s.processHostControl(TEST_ROOM_ID, "alice", CONTROL_PAUSE, state)
+	message := nextMessage(t, s)
+	if message.Type != "game_paused" {
+		t.Fatalf("message type = %q, want game_paused", message.Type)
+	}
+	if !state.Paused || state.QuestionTimer != nil {
+		t.Fatalf("Paused = %v, timer running = %v, want paused without timer", state.Paused, state.QuestionTimer != nil)
+	}
+	if remaining := state.RemainingTime; remaining <= 9*time.Second || remaining > 10*time.Second {
+		t.Errorf("RemainingTime = %v, want about 10s", remaining)
+	}
+
+	// 一時停止中は回答を受け付けない
+	s.processAnswer(TEST_ROOM_ID, "bob", state, map[string]interface{}{"choiceId": types.ChoiceID("q1", "2")})
+	if message := nextMessage(t, s); message.Type != "answer_error" || message.UserID != "bob" {
+		t.Fatalf("message = %+v, want answer_error to bob", message)
+	}
+	if _, answered := state.Answers["bob"]; answered {
+		t.Error("answer was recorded while paused")
+	}
+
+	// 停止していた時間だけ出題時刻と締切を遅らせる
+	startedAt := state.QuestionStartedAt
+	state.PausedAt = state.PausedAt.Add(-time.Hour)
+	s.processHostControl(TEST_ROOM_ID, "alice", CONTROL_RESUME, state)
+	message = nextMessage(t, s)
+	if message.Type != "game_resumed" {
+		t.Fatalf("message type = %q, want game_resumed", message.Type)
+	}
+	if state.Paused || state.QuestionTimer == nil {
+		t.Fatalf("Paused = %v, timer running = %v, want resumed with timer", state.Paused, state.QuestionTimer != nil)
+	}
+	if shifted := state.QuestionStartedAt.Sub(startedAt); shifted < time.Hour {
+		t.Errorf("QuestionStartedAt shifted by %v, want at least 1h", shifted)
+	}
+	if left := time.Until(state.QuestionDeadline); left <= 9*time.Second || left > 10*time.Second {
+		t.Errorf("deadline in %v, want about 10s", left)
+	}
+	if payload := message.Payload.(map[string]interface{}); payload["deadline"] != state.QuestionDeadline.UnixMilli() {
+		t.Errorf("deadline = %v, want %d", payload["deadline"], state.QuestionDeadline.UnixMilli())
+	}
+	assertNoMessage(t, s)
+}
+
+func TestPauseAndResumePhase(t *testing.T) {
+	s := newTestService(testQuestions(2)...)
+	state := hostState(0)
+	state.IsQuestionActive = false
+	state.TotalQuestions = 2
+	state.UsedQuestionIDs = []string{"q1"}
+	state.QuestionPool = buildQuestionPool(s.questions, state.Settings)
+	g := runGame(t, s, state)
+	inGame(t, g, func() {
+		s.schedulePhase(TEST_ROOM_ID, state, types.PhaseReveal, 50*time.Millisecond)
+	})
+
+	// 正解発表中に止めた場合は、再開するまで次の問題へ進まない
+	var timerRunning bool
+	inGame(t, g, func() {
+		s.processHostControl(TEST_ROOM_ID, "alice", CONTROL_PAUSE, state)
+		timerRunning = state.PhaseTimer != nil
+	})
+	message := nextMessage(t, s)
+	payload := message.Payload.(map[string]interface{})
+	if message.Type != "game_paused" || payload["phase"] != types.PhaseReveal || timerRunning {
+		t.Fatalf("message = %+v, phase timer running = %v, want game_paused in reveal", message, timerRunning)
+	}
+	time.Sleep(100 * time.Millisecond)
+	assertNoMessage(t, s)
+
+	var phaseEndsAt time.Time
+	inGame(t, g, func() {
+		s.processHostControl(TEST_ROOM_ID, "alice", CONTROL_RESUME, state)
+		phaseEndsAt = state.PhaseEndsAt
+	})
+	message = nextMessage(t, s)
+	payload = message.Payload.(map[string]interface{})
+	if message.Type != "game_resumed" || payload["phaseEndsAt"] != phaseEndsAt.UnixMilli() {
+		t.Fatalf("message = %+v, want game_resumed with phaseEndsAt", message)
+	}
+	if message := nextMessage(t, s); message.Type != "question_start" {
+		t.Fatalf("message type = %q, want question_start after the remaining reveal time", message.Type)
+	}
+}
+
+func TestSkipQuestion(t *testing.T) {
+	tests := []struct {
+		name   string
+		active bool
+		mode   types.GameMode
+		want   []string
+	}{
+		{name: "出題中は締め切って正解を公開", active: true, want: []string{"question_skipped", "question_result"}},
+		{name: "スキップした問題では脱落しない", active: true, mode: types.GameModeElimination, want: []string{"question_skipped", "question_result"}},
+		{name: "正解発表中はすぐに次へ", active: false, want: []string{"question_skipped", "game_summary", "game_over"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(testQuestions(1)...)
+			state := hostState(time.Minute)
+			state.IsQuestionActive = tt.active
+			state.UsedQuestionIDs = []string{"q1"}
+			if tt.mode != "" {
+				state.Settings.Mode = tt.mode
+				state.PlayerStatuses = map[string]types.PlayerStatus{"alice": types.PlayerActive, "bob": types.PlayerActive}
+				// 通常の締切なら未回答の bob が脱落する
+				state.Answers["alice"] = types.PlayerAnswer{Answered: true, IsCorrect: true}
+			}
+			installGame(s, state)
+
+			s.processHostControl(TEST_ROOM_ID, "alice", CONTROL_SKIP_QUESTION, state)
+			stopGameTimers(state)
+
+			if got := drainMessageTypes(s); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Fatalf("messages = %v, want %v", got, tt.want)
+			}
+			if len(state.Eliminations) > 0 {
+				t.Errorf("eliminations = %v, want none", state.Eliminations)
+			}
+		})
+	}
+}
+
+func TestSkipQuestionResultReason(t *testing.T) {
+	s := newTestService()
+	state := hostState(time.Minute)
+	installGame(s, state)
+
+	s.processHostControl(TEST_ROOM_ID, "alice", CONTROL_SKIP_QUESTION, state)
+	nextMessage(t, s)
+	message := nextMessage(t, s)
+	if reason := message.Payload.(map[string]interface{})["reason"]; reason != CLOSE_REASON_SKIPPED {
+		t.Errorf("reason = %v, want %s", reason, CLOSE_REASON_SKIPPED)
+	}
+}
+
+func TestAbortGame(t *testing.T) {
+	s := newTestService()
+	results := make(chan types.GameResult, 1)
+	s.OnGameOver(func(result types.GameResult) { results <- result })
+	state := hostState(time.Minute)
+	state.Scores = map[string]int{"alice": 10, "bob": 20}
+	installGame(s, state)
+
+	s.processHostControl(TEST_ROOM_ID, "alice", CONTROL_ABORT_GAME, state)
+
+	var got []string
+	for message := popMessage(s); message != nil; message = popMessage(s) {
+		got = append(got, message.Type)
+		if message.Type == "game_summary" && message.Payload.(map[string]interface{})["aborted"] != true {
+			t.Error("game_summary does not report the abort")
+		}
+	}
+	if want := []string{"game_aborted", "game_summary", "game_over"}; fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("messages = %v, want %v", got, want)
+	}
+	select {
+	case result := <-results:
+		if !result.Aborted || result.Results[0].UserID != "bob" {
+			t.Errorf("result = %+v, want aborted with bob first", result)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("game over listener was not called")
+	}
+	if _, ok := s.game(TEST_ROOM_ID); ok {
+		t.Error("game state was not removed")
+	}
+}
+
+func TestProcessClientMessageRoutesHostControl(t *testing.T) {
+	s := newTestService()
+	s.ProcessClientMessage(TEST_ROOM_ID, "alice", []byte(`{"type":"pause"}`))
+	message := nextMessage(t, s)
+	payload := message.Payload.(map[string]interface{})
+	if message.Type != "control_error" || payload["message"] != ErrNoGameInProgress.Error() {
+		t.Errorf("message = %+v, want control_error %q", message, ErrNoGameInProgress)
+	}
+}
+
+func TestAbortGameTeamStandings(t *testing.T) {
+	tests := []struct {
+		name      string
+		team      bool
+		wantTeams bool
+	}{
+		{name: "team モードはチームの順位を含める", team: true, wantTeams: true},
+		{name: "それ以外のモードは含めない", team: false, wantTeams: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService()
+			state := hostState(time.Minute)
+			if tt.team {
+				state = teamState(false)
+				state.Settings.HostID = "alice"
+			}
+			installGame(s, state)
+
+			s.processHostControl(TEST_ROOM_ID, "alice", CONTROL_ABORT_GAME, state)
+
+			message := nextMessage(t, s)
+			if message.Type != "game_aborted" {
+				t.Fatalf("message type = %q, want game_aborted", message.Type)
+			}
+			if _, ok := message.Payload.(map[string]interface{})["teams"]; ok != tt.wantTeams {
+				t.Errorf("payload has teams = %v, want %v", ok, tt.wantTeams)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+// server/src/internal/feature/quiz/service/outbox.go
+package service
+
+import (
+	"log"
+	"server/src/internal/feature/quiz/types"
+)
+
+// OUTBOX_ROOM_LIMIT は1つのルームについて送信キューに積んでおけるメッセージの数です。
+// ハブへの送信が追いつかない場合でも、1つのルームのメッセージでメモリを使い果たさないようにします。
+const OUTBOX_ROOM_LIMIT = 256
+
+// DROPPABLE_MESSAGE_TYPES は送信キューが埋まっている場合に間引いてよいメッセージです。
+// いずれも後続のメッセージ（次の途中経過・正解発表・最終結果）で同じ情報が届くか、リクエストを送ったクライアントへのエラーの返信です。
+var DROPPABLE_MESSAGE_TYPES = map[string]bool{
+	"leaderboard_interim": true,
+	"player_answered":     true,
+	"answer_error":        true,
+	"control_error":       true,
+}
+
+// runOutbox はキューに積まれたメッセージを順番にハブへ送信します。
+func (s *QuizService) runOutbox() {
+	for range s.outboxReady {
+		for {
+			message, ok := s.popOutbox()
+			if !ok {
+				break
+			}
+			s.hub.Broadcast <- message
+		}
+	}
+}
+
+// popOutbox は送信キューの先頭のメッセージを取り出します。
+func (s *QuizService) popOutbox() (*types.Message, bool) {
+	s.outboxMu.Lock()
+	defer s.outboxMu.Unlock()
+	if len(s.outbox) == 0 {
+		return nil, false
+	}
+	message := s.outbox[0]
+	s.outbox[0] = nil
+	s.outbox = s.outbox[1:]
+	s.releaseOutbox(message.RoomID, 1)
+	return message, true
+}
+
+// broadcast はメッセージを送信キューに積みます。呼び出し元がハブを待つことはありません。
+// ゲームの goroutine から hub.Broadcast に直接送信すると、ハブのRunループ（ProcessClientMessage でゲームの inbox に積む側）と
+// 相互にブロックする可能性があるため、必ずこのメソッドを経由します。
+//
+// ルームのメッセージが OUTBOX_ROOM_LIMIT に達している場合、間引いてよいメッセージは同じ種類の送信待ちのメッセージを新しい内容で置き換えるか、
+// それもなければ破棄します。それ以外のメッセージは送信待ちの間引いてよいメッセージを1つ破棄して積みます。
+// 間引けるメッセージがない場合は、クライアントに欠けたメッセージを送らないよう、そのルームの送信待ちのメッセージをすべて破棄してゲームを止めます。
+func (s *QuizService) broadcast(message *types.Message) {
+	roomID := message.RoomID
+	s.outboxMu.Lock()
+	if s.overflowed[roomID] {
+		s.outboxMu.Unlock()
+		return
+	}
+	if s.outboxRooms[roomID] >= OUTBOX_ROOM_LIMIT {
+		if DROPPABLE_MESSAGE_TYPES[message.Type] {
+			s.coalesceOutbox(message)
+			s.outboxMu.Unlock()
+			return
+		}
+		if !s.dropOutbox(roomID, func(queued *types.Message) bool { return DROPPABLE_MESSAGE_TYPES[queued.Type] }, 1) {
+			s.dropOutbox(roomID, func(*types.Message) bool { return true }, len(s.outbox))
+			s.overflowed[roomID] = true
+			s.outboxMu.Unlock()
+			log.Printf("warning: outbox for room %s is full; stopping the game", roomID)
+			go s.closeOverflowedRoom(roomID)
+			return
+		}
+	}
+	s.outbox = append(s.outbox, message)
+	s.outboxRooms[roomID]++
+	s.outboxMu.Unlock()
+
+	select {
+	case s.outboxReady <- struct{}{}:
+	default:
+		// runOutbox は既に通知を受けており、キューが空になるまで送信を続ける
+	}
+}
+
+// coalesceOutbox はルームの送信待ちのメッセージのうち、message と同じ種類・宛先の最も新しいものを message で置き換えます。
+// 置き換えるメッセージがない場合は message を破棄します。outboxMu を保持して呼び出します。
+func (s *QuizService) coalesceOutbox(message *types.Message) {
+	for i := len(s.outbox) - 1; i >= 0; i-- {
+		queued := s.outbox[i]
+		if queued.RoomID == message.RoomID && queued.Type == message.Type && queued.UserID == message.UserID {
+			s.outbox[i] = message
+			return
+		}
+	}
+}
+
+// dropOutbox はルームの送信待ちのメッセージのうち、drop が true を返すものを古い順に最大 limit 個破棄します。
+// 1つも破棄しなかった場合は false を返します。outboxMu を保持して呼び出します。
+func (s *QuizService) dropOutbox(roomID string, drop func(*types.Message) bool, limit int) bool {
+	kept := s.outbox[:0]
+	dropped := 0
+	for _, queued := range s.outbox {
+		if dropped < limit && queued.RoomID == roomID && drop(queued) {
+			dropped++
+			continue
+		}
+		kept = append(kept, queued)
+	}
+	for i := len(kept); i < len(s.outbox); i++ {
+		s.outbox[i] = nil
+	}
+	s.outbox = kept
+	s.releaseOutbox(roomID, dropped)
+	return dropped > 0
+}
+
+// releaseOutbox はルームの送信待ちのメッセージの数を n 減らします。outboxMu を保持して呼び出します。
+func (s *QuizService) releaseOutbox(roomID string, n int) {
+	s.outboxRooms[roomID] -= n
+	if s.outboxRooms[roomID] <= 0 {
+		delete(s.outboxRooms, roomID)
+	}
+}
+
+// closeOverflowedRoom は送信が追いつかなくなったルームのゲームを止め、その後のメッセージの送信を再開します。
+func (s *QuizService) closeOverflowedRoom(roomID string) {
+	if s.closeGame(roomID) {
+		log.Printf("Game in room %s stopped because its messages could not be delivered", roomID)
+	}
+	s.outboxMu.Lock()
+	delete(s.overflowed, roomID)
+	s.outboxMu.Unlock()
+}
@@ -0,0 +1,94 @@
+// server/src/internal/feature/quiz/service/outbox_test.go
+package service
+
+import (
+	"fmt"
+	"server/src/internal/feature/quiz/types"
+	"testing"
+	"time"
+)
+
+// fillOutbox はテスト用ルームの送信キューを messageType のメッセージで上限まで埋めます。
+func fillOutbox(s *QuizService, messageType string) {
+	for i := 0; i < OUTBOX_ROOM_LIMIT; i++ {
+		s.broadcast(&types.Message{Type: messageType, Payload: i, RoomID: TEST_ROOM_ID})
+	}
+}
+
+func TestBroadcastFullOutbox(t *testing.T) {
+	tests := []struct {
+		name        string
+		queued      string
+		message     string
+		wantLast    string // キューの最後のメッセージの種類と内容
+		wantPending int    // キューに残るテスト用ルームのメッセージの数
+	}{
+		{name: "間引いてよいメッセージは同じ種類の送信待ちのメッセージを置き換える", queued: "leaderboard_interim", message: "leaderboard_interim", wantLast: "leaderboard_interim new", wantPending: OUTBOX_ROOM_LIMIT},
+		{name: "同じ種類の送信待ちのメッセージがなければ破棄する", queued: "question_result", message: "player_answered", wantLast: fmt.Sprintf("question_result %d", OUTBOX_ROOM_LIMIT-1), wantPending: OUTBOX_ROOM_LIMIT},
+		{name: "それ以外のメッセージは間引いてよいメッセージを破棄して積む", queued: "leaderboard_interim", message: "question_start", wantLast: "question_start new", wantPending: OUTBOX_ROOM_LIMIT},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService()
+			fillOutbox(s, tt.queued)
+
+			s.broadcast(&types.Message{Type: tt.message, Payload: "new", RoomID: TEST_ROOM_ID})
+
+			s.outboxMu.Lock()
+			defer s.outboxMu.Unlock()
+			last := s.outbox[len(s.outbox)-1]
+			if got := fmt.Sprintf("%s %v", last.Type, last.Payload); got != tt.wantLast {
+				t.Errorf("last message = %s, want %s", got, tt.wantLast)
+			}
+			if len(s.outbox) != tt.wantPending || s.outboxRooms[TEST_ROOM_ID] != tt.wantPending {
+				t.Errorf("queued = %d (counted %d), want %d", len(s.outbox), s.outboxRooms[TEST_ROOM_ID], tt.wantPending)
+			}
+		})
+	}
+}
+
+func TestBroadcastOverflowStopsGame(t *testing.T) {
+	s := newTestService()
+	results := make(chan types.GameResult, 1)
+	s.OnGameOver(func(result types.GameResult) { results <- result })
+	g := runGame(t, s, hostState(time.Minute))
+	s.broadcast(&types.Message{Type: "question_start", RoomID: "other-room"})
+	fillOutbox(s, "question_result")
+
+	s.broadcast(&types.Message{Type: "question_start", RoomID: TEST_ROOM_ID})
+
+	// 欠けたメッセージを送らないよう、ルームの送信待ちのメッセージを破棄してゲームを止める
+	select {
+	case result := <-results:
+		if !result.Closed {
+			t.Errorf("result = %+v, want the closed game", result)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("game was not stopped")
+	}
+	if g.send(func() {}) {
+		t.Error("game loop is still running")
+	}
+	if got := drainMessageTypes(s); fmt.Sprint(got) != "[question_start]" {
+		t.Errorf("messages = %v, want only the other room's question_start", got)
+	}
+
+	// ゲームを止めた後は、そのルームのメッセージの送信を再開する
+	deadline := time.Now().Add(time.Second)
+	for {
+		s.outboxMu.Lock()
+		overflowed := s.overflowed[TEST_ROOM_ID]
+		s.outboxMu.Unlock()
+		if !overflowed {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("room stayed overflowed")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	s.broadcast(&types.Message{Type: "rematch_status", RoomID: TEST_ROOM_ID})
+	if message := nextMessage(t, s); message.Type != "rematch_status" {
+		t.Errorf("message = %s, want rematch_status", message.Type)
+	}
+}
@@ -0,0 +1,352 @@
+// server/src/internal/feature/quiz/service/questionAdminService.go
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"server/src/internal/feature/quiz/repository"
+	"server/src/internal/feature/quiz/source"
+	"server/src/internal/feature/quiz/types"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// QuestionFilter は問題一覧の絞り込み条件です。空のフィールドは条件に含めません。
+type QuestionFilter struct {
+	Language   string
+	Difficulty string
+	Tag        string
+	Kind       string
+}
+
+// ImportResult は一括登録の結果です。
+type ImportResult struct {
+	Created int `json:"created"` // 新しく追加した問題の数
+	Updated int `json:"updated"` // 既存の問題を上書きした数
+}
+
+// QuestionAdminService は管理APIからの問題バンクの編集を担当します。
+//
+// 問題バンクは、問題ソース（ファイル・ディレクトリ）から読み込んだ問題の上に、ストレージに保存された問題を重ねたものです。
+// ストレージの問題で問題ファイルの問題を上書きするには、Replaces を指定して同じIDで保存します。削除するとファイルの内容に戻ります。
+// Replaces を指定せずにIDが重なる問題は、意図しない衝突として保存を拒否します。
+// 変更のたびに QuizService の問題バンクを差し替えるため、再起動は不要です。
+//
+// 答えの検証（QUESTION_VERIFY）はストレージの問題にも適用します。strict モードでは答えが一致しない問題の登録・更新を拒否し、
+// 起動時に読み込んだ問題のうち一致しないものは問題バンクから除外します（ストレージからは削除しません）。
+type QuestionAdminService struct {
+	repo    *repository.QuestionRepository
+	storage source.QuestionSource
+	quiz    *QuizService
+	stored  map[string]types.Question // ストレージに保存された問題（Key: 問題ID）
+	mu      sync.Mutex
+}
+
+// NewQuestionAdminService は新しいサービスインスタンスを生成します。
+func NewQuestionAdminService(repo *repository.QuestionRepository, quiz *QuizService) *QuestionAdminService {
+	return &QuestionAdminService{
+		repo:    repo,
+		storage: source.NewStorageSource(repo),
+		quiz:    quiz,
+		stored:  make(map[string]types.Question),
+	}
+}
+
+// Reload はストレージから問題を読み込み直し、問題バンクに反映します。
+// 検証に失敗した問題と、Replaces を指定せずに問題ファイルの問題とIDが重なる問題はログに出力して読み飛ばします。
+// 答えの検証は反映した後にバックグラウンドで行います。
+func (s *QuestionAdminService) Reload() error {
+	questions, err := s.storage.Load()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	base := make(map[string]bool)
+	for _, q := range s.quiz.BaseQuestions() {
+		base[q.ID] = true
+	}
+	s.stored = make(map[string]types.Question, len(questions))
+	for _, q := range questions {
+		if err := validateQuestion(&q); err != nil {
+			log.Printf("warning: skipping stored question: %v", err)
+			continue
+		}
+		if base[q.ID] && !q.Replaces {
+			log.Printf("warning: skipping stored question %s: %v", q.ID, ErrQuestionReplaces)
+			continue
+		}
+		s.stored[q.ID] = q
+	}
+	if err := s.publish(); err != nil {
+		return err
+	}
+	if mode := questionVerifyMode(); mode != VERIFY_OFF {
+		go s.verifyStored(context.Background(), mode, s.storedQuestions())
+	}
+	return nil
+}
+
+// List は条件に一致する問題バンクの問題を返します。
+func (s *QuestionAdminService) List(filter QuestionFilter) []types.Question {
+	questions := s.quiz.Questions()
+	result := make([]types.Question, 0, len(questions))
+	for _, q := range questions {
+		if filter.Matches(&q) {
+			result = append(result, q)
+		}
+	}
+	return result
+}
+
+// Get は問題バンクから問題を1件取得します。
+func (s *QuestionAdminService) Get(id string) (*types.Question, error) {
+	for _, q := range s.quiz.Questions() {
+		if q.ID == id {
+			return &q, nil
+		}
+	}
+	return nil, ErrQuestionNotFound
+}
+
+// Create は新しい問題を保存します。IDは問題バンク全体で一意である必要があります。
+func (s *QuestionAdminService) Create(q *types.Question) error {
+	if err := validateQuestion(q); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidQuestion, err)
+	}
+	if err := verifyNewAnswers([]types.Question{*q}); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.exists(q.ID) {
+		return ErrQuestionExists
+	}
+	return s.save(q)
+}
+
+// Update は既存の問題を上書きします。問題ファイルの問題を指定した場合は、ストレージ側で上書きします
+// （Replaces を指定していない場合は ErrQuestionReplaces を返します）。
+func (s *QuestionAdminService) Update(id string, q *types.Question) error {
+	if q.ID == "" {
+		q.ID = id
+	}
+	if q.ID != id {
+		return fmt.Errorf("%w: id in body (%s) does not match path (%s)", ErrInvalidQuestion, q.ID, id)
+	}
+	if err := validateQuestion(q); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidQuestion, err)
+	}
+	if err := verifyNewAnswers([]types.Question{*q}); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.exists(id) {
+		return ErrQuestionNotFound
+	}
+	return s.save(q)
+}
+
+// Import は複数の問題をまとめてストレージに保存します。既存のIDの問題は上書きします。
+// 1問でも検証に失敗した場合や、Replaces を指定せずに問題ファイルの問題とIDが重なる場合は何も保存しません。
+func (s *QuestionAdminService) Import(questions []types.Question) (*ImportResult, error) {
+	if err := validateQuestions(questions); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidQuestion, err)
+	}
+	if err := verifyNewAnswers(questions); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// 問題バンク全体として出題できることを確認してから保存する
+	result := &ImportResult{}
+	err := s.quiz.UpdateQuestionOverlay(s.storedWith(questions...), func() error {
+		for i := range questions {
+			q := &questions[i]
+			exists := s.exists(q.ID)
+			if err := s.repo.Save(q); err != nil {
+				return fmt.Errorf("question %s: %w", q.ID, err)
+			}
+			s.stored[q.ID] = *q
+			if exists {
+				result.Updated++
+			} else {
+				result.Created++
+			}
+		}
+		return nil
+	})
+	switch {
+	case errors.Is(err, ErrInvalidQuestion), errors.Is(err, ErrQuestionReplaces):
+		return nil, err
+	case err != nil:
+		// 保存済みの問題は反映してからエラーを返します。
+		if pubErr := s.publish(); pubErr != nil {
+			log.Printf("warning: cannot publish imported questions: %v", pubErr)
+		}
+		return result, err
+	}
+	return result, nil
+}
+
+// Delete はストレージから問題を削除します。問題ファイルの問題は削除できません。
+func (s *QuestionAdminService) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.stored[id]; !ok {
+		if s.exists(id) {
+			return ErrQuestionReadOnly
+		}
+		return ErrQuestionNotFound
+	}
+	remaining := s.storedWith()
+	delete(remaining, id)
+	if err := s.quiz.UpdateQuestionOverlay(remaining, func() error { return s.repo.Delete(id) }); err != nil {
+		return err
+	}
+	s.stored = remaining
+	return nil
+}
+
+// save はストレージに保存し、問題バンクに反映します。呼び出し側で s.mu をロックしてください。
+// 問題バンクに重ねられない問題は、ストレージに保存する前にエラーを返します。
+func (s *QuestionAdminService) save(q *types.Question) error {
+	stored := s.storedWith(*q)
+	if err := s.quiz.UpdateQuestionOverlay(stored, func() error { return s.repo.Save(q) }); err != nil {
+		return err
+	}
+	s.stored = stored
+	return nil
+}
+
+// exists は問題ソースまたはストレージに同じIDの問題があるかを返します。
+func (s *QuestionAdminService) exists(id string) bool {
+	if _, ok := s.stored[id]; ok {
+		return true
+	}
+	for _, q := range s.quiz.BaseQuestions() {
+		if q.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// storedWith はストレージの問題に questions を追加・上書きしたコピーを返します。s.stored は変更しません。
+func (s *QuestionAdminService) storedWith(questions ...types.Question) map[string]types.Question {
+	stored := make(map[string]types.Question, len(s.stored)+len(questions))
+	for id, q := range s.stored {
+		stored[id] = q
+	}
+	for _, q := range questions {
+		stored[q.ID] = q
+	}
+	return stored
+}
+
+// storedQuestions はストレージの問題を ID 順のスライスで返します。呼び出し側で s.mu をロックしてください。
+func (s *QuestionAdminService) storedQuestions() []types.Question {
+	questions := make([]types.Question, 0, len(s.stored))
+	for _, q := range s.stored {
+		questions = append(questions, q)
+	}
+	sort.Slice(questions, func(i, j int) bool { return questions[i].ID < questions[j].ID })
+	return questions
+}
+
+// verifyStored はストレージから読み込んだ問題の答えを検証します。
+// strict モードでは一致しなかった問題を問題バンクから外します。検証中に更新・削除された問題はそのままにします。
+func (s *QuestionAdminService) verifyStored(ctx context.Context, mode string, questions []types.Question) {
+	mismatched := verifyAnswers(ctx, questions)
+	if mode != VERIFY_STRICT || len(mismatched) == 0 {
+		return
+	}
+	verified := make(map[string]types.Question, len(questions))
+	for _, q := range questions {
+		verified[q.ID] = q
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	remaining := s.storedWith()
+	excluded := 0
+	for _, result := range mismatched {
+		if q, ok := remaining[result.QuestionID]; ok && reflect.DeepEqual(q, verified[result.QuestionID]) {
+			delete(remaining, result.QuestionID)
+			excluded++
+		}
+	}
+	if excluded == 0 {
+		return
+	}
+	if err := s.quiz.SetQuestionOverlay(remaining); err != nil {
+		log.Printf("warning: keeping stored questions with mismatched answers: %v", err)
+		return
+	}
+	s.stored = remaining
+	log.Printf("Excluded %d stored questions with mismatched answers", excluded)
+}
+
+// verifyNewAnswers は登録・更新する問題の答えを検証します。
+// strict モードでは保存前に検証し、答えが一致しない問題があればエラーを返します。
+// warn モードでは保存を待たせず、バックグラウンドで検証して不一致をログに出力します。
+func verifyNewAnswers(questions []types.Question) error {
+	switch questionVerifyMode() {
+	case VERIFY_OFF:
+		return nil
+	case VERIFY_STRICT:
+		mismatched := verifyAnswers(context.Background(), questions)
+		if len(mismatched) == 0 {
+			return nil
+		}
+		details := make([]string, 0, len(mismatched))
+		for _, result := range mismatched {
+			details = append(details, fmt.Sprintf("question %s: answer %q does not match the execution result %q", result.QuestionID, result.Expected, result.Actual))
+		}
+		return fmt.Errorf("%w: %s", ErrInvalidQuestion, strings.Join(details, "; "))
+	default:
+		go verifyAnswers(context.Background(), append([]types.Question(nil), questions...))
+		return nil
+	}
+}
+
+// publish はストレージの問題を QuizService の問題バンクに重ねます。
+func (s *QuestionAdminService) publish() error {
+	return s.quiz.SetQuestionOverlay(s.stored)
+}
+
+// Matches は問題が絞り込み条件に一致するかを返します。
+func (f QuestionFilter) Matches(q *types.Question) bool {
+	if f.Language != "" && !strings.EqualFold(q.Language, f.Language) {
+		return false
+	}
+	if f.Difficulty != "" && !strings.EqualFold(q.Difficulty, f.Difficulty) {
+		return false
+	}
+	if f.Kind != "" && string(q.QuestionKind()) != f.Kind {
+		return false
+	}
+	if f.Tag != "" {
+		for _, tag := range q.Tags {
+			if strings.EqualFold(tag, f.Tag) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
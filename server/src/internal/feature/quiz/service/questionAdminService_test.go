@@ -0,0 +1,412 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"server/src/internal/feature/quiz/source"
+	"server/src/internal/feature/quiz/types"
+	"testing"
+	"time"
+)
+
+// newTestAdminService はストレージに触れる前に終わる処理を検査するための管理サービスを作成します。
+func newTestAdminService(base []types.Question, stored ...types.Question) *QuestionAdminService {
+	quiz := newTestService(base...)
+	admin := NewQuestionAdminService(nil, quiz)
+	for _, q := range stored {
+		admin.stored[q.ID] = q
+	}
+	return admin
+}
+
+func TestQuestionFilterMatches(t *testing.T) {
+	q := types.Question{ID: "q1", Language: "Go", Difficulty: "Easy", Tags: []string{"Slices", "maps"}}
+	tests := []struct {
+		name   string
+		filter QuestionFilter
+		want   bool
+	}{
+		{name: "条件なし", filter: QuestionFilter{}, want: true},
+		{name: "言語は大文字小文字を区別しない", filter: QuestionFilter{Language: "go"}, want: true},
+		{name: "言語が異なる", filter: QuestionFilter{Language: "C"}, want: false},
+		{name: "難易度", filter: QuestionFilter{Difficulty: "EASY"}, want: true},
+		{name: "タグのいずれかに一致", filter: QuestionFilter{Tag: "slices"}, want: true},
+		{name: "タグに一致しない", filter: QuestionFilter{Tag: "channels"}, want: false},
+		{name: "形式は未指定を single_choice とみなす", filter: QuestionFilter{Kind: "single_choice"}, want: true},
+		{name: "形式が異なる", filter: QuestionFilter{Kind: "free_text"}, want: false},
+		{name: "複数条件はすべて満たす必要がある", filter: QuestionFilter{Language: "Go", Difficulty: "Hard"}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Matches(&q); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuestionAdminServiceRejects(t *testing.T) {
+	stored := validQuestion()
+	stored.ID = "stored"
+	invalid := validQuestion()
+	invalid.Answer = "3"
+	tests := []struct {
+		name string
+		call func(s *QuestionAdminService) error
+		want error
+	}{
+		{name: "不正な問題は作成できない", call: func(s *QuestionAdminService) error { q := invalid; return s.Create(&q) }, want: ErrInvalidQuestion},
+		{name: "問題ファイルと同じIDは作成できない", call: func(s *QuestionAdminService) error { q := validQuestion(); return s.Create(&q) }, want: ErrQuestionExists},
+		{name: "保存済みと同じIDは作成できない", call: func(s *QuestionAdminService) error { q := stored; return s.Create(&q) }, want: ErrQuestionExists},
+		{name: "パスと本文のIDが異なる", call: func(s *QuestionAdminService) error { q := validQuestion(); return s.Update("other", &q) }, want: ErrInvalidQuestion},
+		{name: "存在しない問題は更新できない", call: func(s *QuestionAdminService) error { q := validQuestion(); q.ID = ""; return s.Update("missing", &q) }, want: ErrQuestionNotFound},
+		{name: "問題ファイルの問題は削除できない", call: func(s *QuestionAdminService) error { return s.Delete("q1") }, want: ErrQuestionReadOnly},
+		{name: "1問でも不正なら一括登録しない", call: func(s *QuestionAdminService) error {
+			_, err := s.Import([]types.Question{validQuestion(), invalid})
+			return err
+		}, want: ErrInvalidQuestion},
+		{name: "存在しない問題は削除できない", call: func(s *QuestionAdminService) error { return s.Delete("missing") }, want: ErrQuestionNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestAdminService([]types.Question{validQuestion()}, stored)
+			if err := tt.call(s); !errors.Is(err, tt.want) {
+				t.Errorf("error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuestionAdminServicePublish(t *testing.T) {
+	base := testQuestions(2)
+	override := base[1]
+	override.Statement = "overridden"
+	override.Replaces = true
+	extraB, extraA := testQuestions(9)[8], testQuestions(5)[4]
+	s := newTestAdminService(base, override, extraB, extraA)
+
+	if err := s.publish(); err != nil {
+		t.Fatalf("publish() error = %v", err)
+	}
+
+	questions := s.quiz.Questions()
+	ids := make([]string, len(questions))
+	for i, q := range questions {
+		ids[i] = q.ID
+	}
+	// 問題ファイルの順番を保ち、ストレージにのみある問題はID順で末尾に追加する
+	if want := []string{"q1", "q2", "q5", "q9"}; fmt.Sprint(ids) != fmt.Sprint(want) {
+		t.Fatalf("questions = %v, want %v", ids, want)
+	}
+	if questions[1].Statement != "overridden" {
+		t.Errorf("q2 was not overridden: %q", questions[1].Statement)
+	}
+}
+
+func TestSetQuestionOverlayRejectsInvalidBank(t *testing.T) {
+	s := newTestService(testQuestions(1)...)
+	invalid := testQuestions(2)[1]
+	invalid.Answer = "c"
+
+	if err := s.SetQuestionOverlay(map[string]types.Question{invalid.ID: invalid}); err == nil {
+		t.Fatal("SetQuestionOverlay() succeeded with an invalid question")
+	}
+	if got := len(s.Questions()); got != 1 {
+		t.Errorf("bank has %d questions after a rejected overlay, want 1", got)
+	}
+}
+
+func TestQuestionAdminServiceRejectsCollisions(t *testing.T) {
+	// ストレージは nil のため、拒否されずに書き込もうとすると失敗する
+	collision := testQuestions(2)[1]
+	collision.Statement = "stored"
+	tests := []struct {
+		name string
+		call func(s *QuestionAdminService) error
+	}{
+		{name: "Replaces なしで問題ファイルの問題を更新", call: func(s *QuestionAdminService) error { q := collision; return s.Update(q.ID, &q) }},
+		{name: "Replaces なしで問題ファイルの問題と同じIDを一括登録", call: func(s *QuestionAdminService) error {
+			result, err := s.Import([]types.Question{testQuestions(3)[2], collision})
+			if result != nil {
+				t.Errorf("Import() result = %+v, want nil", result)
+			}
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestAdminService(testQuestions(2))
+			if err := tt.call(s); !errors.Is(err, ErrQuestionReplaces) {
+				t.Errorf("error = %v, want %v", err, ErrQuestionReplaces)
+			}
+			if got := bankIDs(s.quiz); got != "[q1 q2]" {
+				t.Errorf("questions = %s, want [q1 q2]", got)
+			}
+		})
+	}
+}
+
+func TestUpdateQuestionOverlay(t *testing.T) {
+	invalid := testQuestions(2)[1]
+	invalid.Answer = "c"
+	boom := errors.New("boom")
+	tests := []struct {
+		name          string
+		stored        types.Question
+		persistErr    error
+		wantErr       error
+		wantPersisted bool
+		want          string
+	}{
+		{name: "検証を通ったら保存してから反映する", stored: testQuestions(2)[1], wantPersisted: true, want: "[q1 q2]"},
+		{name: "検証に失敗したら保存しない", stored: invalid, wantErr: ErrInvalidQuestion, want: "[q1]"},
+		{name: "保存に失敗したら反映しない", stored: testQuestions(2)[1], persistErr: boom, wantErr: boom, wantPersisted: true, want: "[q1]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(testQuestions(1)...)
+			persisted := false
+
+			err := s.UpdateQuestionOverlay(map[string]types.Question{tt.stored.ID: tt.stored}, func() error {
+				persisted = true
+				return tt.persistErr
+			})
+
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Errorf("UpdateQuestionOverlay() error = %v, want %v", err, tt.wantErr)
+			}
+			if persisted != tt.wantPersisted {
+				t.Errorf("persisted = %v, want %v", persisted, tt.wantPersisted)
+			}
+			if got := bankIDs(s); got != tt.want {
+				t.Errorf("questions = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateQuestionOverlayBlocksReload(t *testing.T) {
+	// 保存中に問題ファイルから q2 が消えても、検証した問題バンクを反映し終えるまで読み込み直しを待たせる
+	path := filepath.Join(t.TempDir(), "questions.json")
+	writeQuestionFile(t, path, testQuestions(2))
+	s := newTestService(testQuestions(2)...)
+	s.sources = []source.QuestionSource{source.NewFileSource(path)}
+	writeQuestionFile(t, path, testQuestions(1))
+	overlay := testQuestions(3)[2]
+
+	persisting := make(chan struct{})
+	release := make(chan struct{})
+	updated := make(chan error, 1)
+	go func() {
+		updated <- s.UpdateQuestionOverlay(map[string]types.Question{overlay.ID: overlay}, func() error {
+			close(persisting)
+			<-release
+			return nil
+		})
+	}()
+	<-persisting
+	reloaded := make(chan error, 1)
+	go func() { reloaded <- s.ReloadQuestionSources() }()
+
+	select {
+	case err := <-reloaded:
+		t.Fatalf("ReloadQuestionSources() returned %v while the overlay was being saved", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(release)
+
+	if err := <-updated; err != nil {
+		t.Fatalf("UpdateQuestionOverlay() error = %v", err)
+	}
+	if err := <-reloaded; err != nil {
+		t.Fatalf("ReloadQuestionSources() error = %v", err)
+	}
+	if got := bankIDs(s); got != "[q1 q3]" {
+		t.Errorf("questions = %s, want [q1 q3]", got)
+	}
+}
+
+func TestQuestionAdminServiceReload(t *testing.T) {
+	invalid := testQuestions(3)[2]
+	invalid.Answer = "c"
+	collision := testQuestions(1)[0]
+	collision.Statement = "stored"
+	replacement := collision
+	replacement.Replaces = true
+	tests := []struct {
+		name          string
+		storage       *fakeSource
+		wantIDs       []string
+		wantStatement string // 空でなければ q1 の問題文を確認する
+		wantErr       bool
+	}{
+		{name: "ストレージの問題を重ねる", storage: &fakeSource{name: "storage", questions: testQuestions(2)[1:]}, wantIDs: []string{"q1", "q2"}},
+		{name: "不正な問題は読み飛ばす", storage: &fakeSource{name: "storage", questions: []types.Question{invalid}}, wantIDs: []string{"q1"}},
+		{name: "Replaces なしで問題ファイルと重なる問題は読み飛ばす", storage: &fakeSource{name: "storage", questions: []types.Question{collision}}, wantIDs: []string{"q1"}, wantStatement: "question 1"},
+		{name: "Replaces 付きの問題は問題ファイルの問題を上書きする", storage: &fakeSource{name: "storage", questions: []types.Question{replacement}}, wantIDs: []string{"q1"}, wantStatement: "stored"},
+		{name: "読み込みに失敗したら問題バンクを変更しない", storage: &fakeSource{name: "storage", questions: testQuestions(2)[1:], err: errors.New("boom")}, wantIDs: []string{"q1"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestAdminService(testQuestions(1))
+			s.storage = tt.storage
+			if err := s.Reload(); (err != nil) != tt.wantErr {
+				t.Fatalf("Reload() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var ids []string
+			for _, q := range s.quiz.Questions() {
+				ids = append(ids, q.ID)
+			}
+			if fmt.Sprint(ids) != fmt.Sprint(tt.wantIDs) {
+				t.Errorf("questions = %v, want %v", ids, tt.wantIDs)
+			}
+			if q, _ := s.quiz.Question("q1"); tt.wantStatement != "" && q.Statement != tt.wantStatement {
+				t.Errorf("q1 statement = %q, want %q", q.Statement, tt.wantStatement)
+			}
+		})
+	}
+}
+
+func TestCheckQuestionOverlay(t *testing.T) {
+	invalid := testQuestions(2)[1]
+	invalid.Answer = "c"
+	tests := []struct {
+		name    string
+		stored  []types.Question
+		wantErr bool
+	}{
+		{name: "重ねられる問題", stored: testQuestions(2)[1:]},
+		{name: "重ねると不正になる問題", stored: []types.Question{invalid}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(testQuestions(1)...)
+			stored := make(map[string]types.Question)
+			for _, q := range tt.stored {
+				stored[q.ID] = q
+			}
+
+			if err := s.CheckQuestionOverlay(stored); (err != nil) != tt.wantErr {
+				t.Fatalf("CheckQuestionOverlay() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			// 確認だけで問題バンクは変更しない
+			if got := bankIDs(s); got != "[q1]" {
+				t.Errorf("questions = %s, want [q1]", got)
+			}
+		})
+	}
+}
+
+func TestQuestionAdminServiceChecksBankBeforeWriting(t *testing.T) {
+	// ストレージに不正な問題が残っている場合、問題バンク全体が検証を通らないため書き込まない
+	// （ストレージは nil のため、書き込もうとすると失敗する）
+	broken := testQuestions(3)[2]
+	broken.Answer = "c"
+	valid := testQuestions(2)[1]
+	tests := []struct {
+		name string
+		call func(s *QuestionAdminService) error
+	}{
+		{name: "作成", call: func(s *QuestionAdminService) error { q := valid; return s.Create(&q) }},
+		{name: "一括登録", call: func(s *QuestionAdminService) error {
+			_, err := s.Import([]types.Question{valid})
+			return err
+		}},
+		{name: "削除", call: func(s *QuestionAdminService) error { return s.Delete("q4") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			other := testQuestions(4)[3]
+			s := newTestAdminService(testQuestions(1), broken, other)
+			if err := tt.call(s); !errors.Is(err, ErrInvalidQuestion) {
+				t.Errorf("error = %v, want %v", err, ErrInvalidQuestion)
+			}
+		})
+	}
+}
+
+func TestQuestionAdminServiceRejectsMismatchedAnswers(t *testing.T) {
+	requirePython(t)
+	t.Setenv("QUESTION_VERIFY", VERIFY_STRICT)
+	wrong := types.Question{ID: "s-wrong", Language: "Python", Statement: "print(1)", Choices: []string{"1", "2"}, Answer: "2"}
+	right := types.Question{ID: "s-ok", Language: "Python", Statement: "print(2)", Choices: []string{"1", "2"}, Answer: "2"}
+	// ストレージは nil のため、検証で拒否されずに書き込もうとすると失敗する
+	s := newTestAdminService(testQuestions(1))
+
+	q := wrong
+	if err := s.Create(&q); !errors.Is(err, ErrInvalidQuestion) {
+		t.Errorf("Create() error = %v, want %v", err, ErrInvalidQuestion)
+	}
+	if _, err := s.Import([]types.Question{right, wrong}); !errors.Is(err, ErrInvalidQuestion) {
+		t.Errorf("Import() error = %v, want %v", err, ErrInvalidQuestion)
+	}
+}
+
+func TestVerifyNewAnswers(t *testing.T) {
+	requirePython(t)
+	wrong := types.Question{ID: "s-wrong", Language: "Python", Statement: "print(1)", Choices: []string{"1", "2"}, Answer: "2"}
+	right := types.Question{ID: "s-ok", Language: "Python", Statement: "print(2)", Choices: []string{"1", "2"}, Answer: "2"}
+	tests := []struct {
+		name      string
+		mode      string
+		questions []types.Question
+		wantErr   bool
+	}{
+		{name: "off は検証しない", mode: VERIFY_OFF, questions: []types.Question{wrong}},
+		{name: "warn は保存を止めない", mode: VERIFY_WARN, questions: []types.Question{wrong}},
+		{name: "strict で答えが一致する", mode: VERIFY_STRICT, questions: []types.Question{right}},
+		{name: "strict で答えが一致しない問題を含む", mode: VERIFY_STRICT, questions: []types.Question{right, wrong}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("QUESTION_VERIFY", tt.mode)
+
+			err := verifyNewAnswers(tt.questions)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("verifyNewAnswers() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidQuestion) {
+				t.Errorf("error = %v, want %v", err, ErrInvalidQuestion)
+			}
+		})
+	}
+}
+
+func TestVerifyStored(t *testing.T) {
+	requirePython(t)
+	wrong := types.Question{ID: "s-wrong", Language: "Python", Statement: "print(1)", Choices: []string{"1", "2"}, Answer: "2"}
+	right := types.Question{ID: "s-ok", Language: "Python", Statement: "print(2)", Choices: []string{"1", "2"}, Answer: "2"}
+	fixed := wrong
+	fixed.Answer = "1"
+	tests := []struct {
+		name    string
+		mode    string
+		updated *types.Question // 検証中に管理APIで更新された問題
+		want    string
+	}{
+		{name: "warn は問題バンクを変えない", mode: VERIFY_WARN, want: "[q1 s-ok s-wrong]"},
+		{name: "strict は答えが一致しない問題を外す", mode: VERIFY_STRICT, want: "[q1 s-ok]"},
+		{name: "strict でも検証中に更新された問題は外さない", mode: VERIFY_STRICT, updated: &fixed, want: "[q1 s-ok s-wrong]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestAdminService(testQuestions(1), right, wrong)
+			if err := s.publish(); err != nil {
+				t.Fatal(err)
+			}
+			verifying := s.storedQuestions()
+			if tt.updated != nil {
+				s.stored[tt.updated.ID] = *tt.updated
+			}
+
+			s.verifyStored(context.Background(), tt.mode, verifying)
+
+			if got := bankIDs(s.quiz); got != tt.want {
+				t.Errorf("questions = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
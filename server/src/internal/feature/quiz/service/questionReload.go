@@ -0,0 +1,103 @@
+// server/src/internal/feature/quiz/service/questionReload.go
+package service
+
+import (
+	"errors"
+	"log"
+	"maps"
+	"os"
+	"server/src/internal/feature/quiz/source"
+	"strings"
+	"time"
+)
+
+// QUESTION_WATCH_INTERVAL は問題ファイルの変更を確認する間隔です。
+const QUESTION_WATCH_INTERVAL = 2 * time.Second
+
+// questionWatchEnabled は環境変数 QUESTION_WATCH で問題ファイルの監視が有効になっているかを返します。
+func questionWatchEnabled() bool {
+	switch strings.ToLower(os.Getenv("QUESTION_WATCH")) {
+	case "1", "true", "on":
+		return true
+	}
+	return false
+}
+
+// ReloadQuestionSources は問題ソースを読み込み直し、問題バンクを差し替えます。
+//
+// 読み込みや検証で1つでも問題があった場合は、現在の問題バンクをそのまま使い続けてエラーを返します。
+// 答えの検証（QUESTION_VERIFY）は差し替えた後にバックグラウンドで行います。
+// 進行中のゲームは開始時に抽出した出題候補を使い続けるため、影響を受けません。
+func (s *QuizService) ReloadQuestionSources() error {
+	questions, problems := mergeQuestionSources(s.sources)
+	if len(problems) > 0 {
+		return errors.Join(problems...)
+	}
+	if len(questions) == 0 {
+		return errors.New("no questions loaded from configured sources")
+	}
+
+	s.updateMu.Lock()
+	defer s.updateMu.Unlock()
+	s.mu.Lock()
+	merged, err := mergeQuestionOverlay(questions, s.overlay)
+	if err != nil {
+		s.mu.Unlock()
+		return err
+	}
+	s.base = questions
+	s.questions = merged
+	s.mu.Unlock()
+	log.Printf("Question bank reloaded: %d questions", len(merged))
+
+	s.verifyQuestionsInBackground(questions)
+	return nil
+}
+
+// watchQuestionSources は問題ファイルの更新日時とサイズを定期的に確認し、変更があれば問題バンクを読み込み直します。
+// ディレクトリのソースでは、ファイルの追加・削除も変更として扱います。
+func (s *QuizService) watchQuestionSources(interval time.Duration) {
+	last := questionFileStamps(s.sources)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		current := questionFileStamps(s.sources)
+		if maps.Equal(current, last) {
+			continue
+		}
+		last = current
+		if err := s.ReloadQuestionSources(); err != nil {
+			log.Printf("warning: keeping the current question bank: %v", err)
+		}
+	}
+}
+
+// fileStamp はファイルの変更を検出するための情報です。
+type fileStamp struct {
+	modTime int64 // 更新日時（UnixNano）
+	size    int64
+}
+
+// questionFileStamps は問題ソースのファイルごとの fileStamp を返します。存在しないファイルは含めません。
+func questionFileStamps(sources []source.QuestionSource) map[string]fileStamp {
+	stamps := make(map[string]fileStamp)
+	for _, src := range sources {
+		backed, ok := src.(source.FileBacked)
+		if !ok {
+			continue
+		}
+		files, err := backed.Files()
+		if err != nil {
+			continue
+		}
+		for _, path := range files {
+			info, err := os.Stat(path)
+			if err != nil {
+				continue
+			}
+			stamps[path] = fileStamp{modTime: info.ModTime().UnixNano(), size: info.Size()}
+		}
+	}
+	return stamps
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"server/src/internal/feature/quiz/codec"
+	"server/src/internal/feature/quiz/source"
+	"server/src/internal/feature/quiz/types"
+	"testing"
+	"time"
+)
+
+// writeQuestionFile は問題を JSON 形式でファイルに書き込みます。
+func writeQuestionFile(t *testing.T, path string, questions []types.Question) {
+	t.Helper()
+	c, err := codec.Lookup("json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	if err := c.Encode(file, questions); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func bankIDs(s *QuizService) string {
+	var ids []string
+	for _, q := range s.Questions() {
+		ids = append(ids, q.ID)
+	}
+	return fmt.Sprint(ids)
+}
+
+func TestQuestionWatchEnabled(t *testing.T) {
+	tests := []struct {
+		env  string
+		want bool
+	}{
+		{env: "", want: false},
+		{env: "true", want: true},
+		{env: "TRUE", want: true},
+		{env: "1", want: true},
+		{env: "on", want: true},
+		{env: "false", want: false},
+		{env: "yes", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.env, func(t *testing.T) {
+			t.Setenv("QUESTION_WATCH", tt.env)
+			if got := questionWatchEnabled(); got != tt.want {
+				t.Errorf("questionWatchEnabled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReloadQuestionSources(t *testing.T) {
+	overlay := testQuestions(9)[8]
+	invalid := testQuestions(1)[0]
+	invalid.Answer = "c"
+	tests := []struct {
+		name    string
+		content []types.Question
+		want    string
+		wantErr bool
+	}{
+		{name: "変更後の問題にストレージの問題を重ねる", content: testQuestions(3), want: "[q1 q2 q3 q9]"},
+		{name: "不正な問題があれば現在の問題バンクを維持する", content: append(testQuestions(3), invalid), want: "[q1 q9]", wantErr: true},
+		{name: "問題が空なら現在の問題バンクを維持する", content: []types.Question{}, want: "[q1 q9]", wantErr: true},
+		{name: "ストレージの問題と Replaces なしで重なる問題が追加されたら現在の問題バンクを維持する", content: testQuestions(9), want: "[q1 q9]", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "bank.json")
+			s := newTestService(testQuestions(1)...)
+			s.sources = []source.QuestionSource{source.NewFileSource(path)}
+			if err := s.SetQuestionOverlay(map[string]types.Question{overlay.ID: overlay}); err != nil {
+				t.Fatal(err)
+			}
+
+			writeQuestionFile(t, path, tt.content)
+			if err := s.ReloadQuestionSources(); (err != nil) != tt.wantErr {
+				t.Fatalf("ReloadQuestionSources() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := bankIDs(s); got != tt.want {
+				t.Errorf("questions = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuestionFileStamps(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(t *testing.T, dir string)
+		want   bool // 変更を検出するか
+	}{
+		{name: "変更なし", change: func(t *testing.T, dir string) {}, want: false},
+		{name: "内容の変更", change: func(t *testing.T, dir string) {
+			writeQuestionFile(t, filepath.Join(dir, "a.json"), testQuestions(2))
+		}, want: true},
+		{name: "更新日時の変更", change: func(t *testing.T, dir string) {
+			later := time.Now().Add(time.Hour)
+			if err := os.Chtimes(filepath.Join(dir, "a.json"), later, later); err != nil {
+				t.Fatal(err)
+			}
+		}, want: true},
+		{name: "ファイルの追加", change: func(t *testing.T, dir string) {
+			writeQuestionFile(t, filepath.Join(dir, "b.json"), testQuestions(1))
+		}, want: true},
+		{name: "ファイルの削除", change: func(t *testing.T, dir string) {
+			if err := os.Remove(filepath.Join(dir, "a.json")); err != nil {
+				t.Fatal(err)
+			}
+		}, want: true},
+		{name: "対応していないファイルの追加", change: func(t *testing.T, dir string) {
+			if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("x"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeQuestionFile(t, filepath.Join(dir, "a.json"), testQuestions(1))
+			sources := []source.QuestionSource{source.NewDirSource(dir), source.NewEmbeddedSource()}
+
+			before := questionFileStamps(sources)
+			tt.change(t, dir)
+			if changed := !maps.Equal(before, questionFileStamps(sources)); changed != tt.want {
+				t.Errorf("changed = %v, want %v", changed, tt.want)
+			}
+		})
+	}
+}
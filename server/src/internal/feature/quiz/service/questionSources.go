@@ -0,0 +1,149 @@
+// server/src/internal/feature/quiz/service/questionSources.go
+package service
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"server/src/internal/feature/quiz/source"
+	"server/src/internal/feature/quiz/types"
+	"sort"
+	"strings"
+)
+
+// DEFAULT_QUESTION_SOURCES は環境変数 QUESTION_SOURCES が未設定の場合に読み込む問題ファイルです。
+const DEFAULT_QUESTION_SOURCES = "../mock/mock.json"
+
+// questionSourcesFromEnv は環境変数 QUESTION_SOURCES（カンマ区切りのファイルまたはディレクトリ）から問題ソースを作成します。
+// 先に指定したソースほど優先されます。
+func questionSourcesFromEnv() []source.QuestionSource {
+	paths := os.Getenv("QUESTION_SOURCES")
+	if strings.TrimSpace(paths) == "" {
+		paths = DEFAULT_QUESTION_SOURCES
+	}
+
+	var sources []source.QuestionSource
+	for _, path := range strings.Split(paths, ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			sources = append(sources, source.ForPath(path))
+		}
+	}
+	return sources
+}
+
+// mergeQuestionSources は複数のソースの問題を1つの問題バンクに合成します。
+//
+// 読み込みに失敗したソースや検証に失敗した問題は読み飛ばし、その内容を problems として返します。
+// 複数のソースに同じIDの問題がある場合は先のソースの問題を使用し、後の問題は衝突として problems に含めます。
+func mergeQuestionSources(sources []source.QuestionSource) (questions []types.Question, problems []error) {
+	origin := make(map[string]string) // Key: 問題ID, Value: 読み込んだソースの名前
+	for _, src := range expandQuestionSources(sources, &problems) {
+		loaded, err := src.Load()
+		if err != nil {
+			problems = append(problems, fmt.Errorf("%s: %w", src.Name(), err))
+		}
+		for i := range loaded {
+			q := loaded[i]
+			if err := validateQuestion(&q); err != nil {
+				problems = append(problems, fmt.Errorf("%s: %w", src.Name(), err))
+				continue
+			}
+			if first, ok := origin[q.ID]; ok {
+				problems = append(problems, fmt.Errorf("%s: question %s conflicts with the one in %s", src.Name(), q.ID, first))
+				continue
+			}
+			origin[q.ID] = src.Name()
+			questions = append(questions, q)
+		}
+	}
+	return questions, problems
+}
+
+// expandQuestionSources はディレクトリなどのまとめられたソースを個別のソースに展開します。
+func expandQuestionSources(sources []source.QuestionSource, problems *[]error) []source.QuestionSource {
+	expanded := make([]source.QuestionSource, 0, len(sources))
+	for _, src := range sources {
+		composite, ok := src.(source.Composite)
+		if !ok {
+			expanded = append(expanded, src)
+			continue
+		}
+		children, err := composite.Sources()
+		if err != nil {
+			*problems = append(*problems, fmt.Errorf("%s: %w", src.Name(), err))
+			continue
+		}
+		expanded = append(expanded, children...)
+	}
+	return expanded
+}
+
+// loadQuestionBank はソースから問題バンクを読み込みます。問題があってもサーバーは停止せず、警告をログに出力します。
+// どのソースからも問題を読み込めなかった場合は、バイナリに組み込まれた既定の問題バンクを使用します。
+func loadQuestionBank(sources []source.QuestionSource) []types.Question {
+	questions, problems := mergeQuestionSources(sources)
+	for _, problem := range problems {
+		log.Printf("warning: %v", problem)
+	}
+
+	if len(questions) == 0 {
+		embedded := source.NewEmbeddedSource()
+		log.Printf("warning: no questions loaded from configured sources; using %s", embedded.Name())
+		questions, problems = mergeQuestionSources([]source.QuestionSource{embedded})
+		for _, problem := range problems {
+			log.Printf("warning: %v", problem)
+		}
+	}
+
+	log.Printf("Loaded %d questions from %d sources", len(questions), len(sources))
+	return questions
+}
+
+// mergeQuestionOverlay は問題ソースの問題にストレージの問題を重ね、問題バンク全体を検証します。
+// Replaces を指定していないストレージの問題が問題ソースの問題とIDが重なる場合は、意図しない上書きとして ErrQuestionReplaces を返します。
+func mergeQuestionOverlay(base []types.Question, overlay map[string]types.Question) ([]types.Question, error) {
+	if ids := overlayCollisions(base, overlay); len(ids) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrQuestionReplaces, strings.Join(ids, ", "))
+	}
+	merged := applyQuestionOverlay(base, overlay)
+	if err := validateQuestions(merged); err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
+// overlayCollisions は Replaces を指定せずに問題ソースの問題と同じIDを使っているストレージの問題のIDを、ID順で返します。
+func overlayCollisions(base []types.Question, overlay map[string]types.Question) []string {
+	var ids []string
+	for _, q := range base {
+		if stored, ok := overlay[q.ID]; ok && !stored.Replaces {
+			ids = append(ids, q.ID)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// applyQuestionOverlay は問題ソースの問題にストレージの問題を重ねた問題バンクを返します。
+// 問題ソースの順番を保ち、ストレージにのみある問題はID順で末尾に追加します。
+func applyQuestionOverlay(base []types.Question, overlay map[string]types.Question) []types.Question {
+	merged := make([]types.Question, 0, len(base)+len(overlay))
+	overridden := make(map[string]bool)
+	for _, q := range base {
+		if stored, ok := overlay[q.ID]; ok {
+			q = stored
+			overridden[q.ID] = true
+		}
+		merged = append(merged, q)
+	}
+
+	extra := make([]types.Question, 0, len(overlay))
+	for id, q := range overlay {
+		if !overridden[id] {
+			extra = append(extra, q)
+		}
+	}
+	sort.Slice(extra, func(i, j int) bool { return extra[i].ID < extra[j].ID })
+
+	return append(merged, extra...)
+}
@@ -0,0 +1,238 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"server/src/internal/feature/quiz/source"
+	"server/src/internal/feature/quiz/types"
+	"strings"
+	"testing"
+)
+
+// fakeSource はテスト用の問題ソースです。
+type fakeSource struct {
+	name      string
+	questions []types.Question
+	err       error
+}
+
+func (s *fakeSource) Name() string                    { return s.name }
+func (s *fakeSource) Load() ([]types.Question, error) { return s.questions, s.err }
+
+// withID は validQuestion() の ID と問題文を変えたものを返します。
+func withID(id, statement string) types.Question {
+	q := validQuestion()
+	q.ID = id
+	q.Statement = statement
+	return q
+}
+
+func TestMergeQuestionSources(t *testing.T) {
+	invalid := withID("bad", "invalid")
+	invalid.Answer = "3"
+	tests := []struct {
+		name         string
+		sources      []source.QuestionSource
+		wantIDs      []string
+		wantProblems []string
+	}{
+		{
+			name:    "ソースの順に合成する",
+			sources: []source.QuestionSource{&fakeSource{name: "a", questions: []types.Question{withID("q1", "a")}}, &fakeSource{name: "b", questions: []types.Question{withID("q2", "b")}}},
+			wantIDs: []string{"q1", "q2"},
+		},
+		{
+			name:         "IDの衝突は先のソースを優先して報告する",
+			sources:      []source.QuestionSource{&fakeSource{name: "a", questions: []types.Question{withID("q1", "a")}}, &fakeSource{name: "b", questions: []types.Question{withID("q1", "b"), withID("q2", "b")}}},
+			wantIDs:      []string{"q1", "q2"},
+			wantProblems: []string{"b: question q1 conflicts with the one in a"},
+		},
+		{
+			name:         "不正な問題は読み飛ばす",
+			sources:      []source.QuestionSource{&fakeSource{name: "a", questions: []types.Question{invalid, withID("q1", "a")}}},
+			wantIDs:      []string{"q1"},
+			wantProblems: []string{"a: "},
+		},
+		{
+			name:         "読み込みに失敗したソースがあっても他のソースを読み込む",
+			sources:      []source.QuestionSource{&fakeSource{name: "broken", err: errors.New("boom")}, &fakeSource{name: "b", questions: []types.Question{withID("q2", "b")}}},
+			wantIDs:      []string{"q2"},
+			wantProblems: []string{"broken: boom"},
+		},
+		{
+			name:         "一部だけ読み込めたソースは読み込めた問題を使う",
+			sources:      []source.QuestionSource{&fakeSource{name: "partial", questions: []types.Question{withID("q1", "a")}, err: errors.New("x.json: bad")}},
+			wantIDs:      []string{"q1"},
+			wantProblems: []string{"partial: x.json: bad"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			questions, problems := mergeQuestionSources(tt.sources)
+			var ids []string
+			for _, q := range questions {
+				ids = append(ids, q.ID)
+			}
+			if strings.Join(ids, ",") != strings.Join(tt.wantIDs, ",") {
+				t.Errorf("IDs = %v, want %v", ids, tt.wantIDs)
+			}
+			if len(problems) != len(tt.wantProblems) {
+				t.Fatalf("problems = %v, want %d problems", problems, len(tt.wantProblems))
+			}
+			for i, want := range tt.wantProblems {
+				if !strings.HasPrefix(problems[i].Error(), want) {
+					t.Errorf("problems[%d] = %q, want prefix %q", i, problems[i], want)
+				}
+			}
+		})
+	}
+
+	t.Run("衝突した問題は先のソースの内容を使う", func(t *testing.T) {
+		questions, _ := mergeQuestionSources([]source.QuestionSource{
+			&fakeSource{name: "a", questions: []types.Question{withID("q1", "from a")}},
+			&fakeSource{name: "b", questions: []types.Question{withID("q1", "from b")}},
+		})
+		if len(questions) != 1 || questions[0].Statement != "from a" {
+			t.Errorf("questions = %+v, want q1 from a", questions)
+		}
+	})
+}
+
+func TestQuestionSourcesFromEnv(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "bank.json")
+	if err := os.WriteFile(file, []byte("[]"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name  string
+		env   string
+		names []string
+	}{
+		{name: "未設定なら既定のファイル", env: "", names: []string{"file " + DEFAULT_QUESTION_SOURCES}},
+		{name: "空白のみは未設定とみなす", env: "  ", names: []string{"file " + DEFAULT_QUESTION_SOURCES}},
+		{name: "カンマ区切りで指定した順", env: file + ", " + dir, names: []string{"file " + file, "directory " + dir}},
+		{name: "空の要素は無視する", env: file + ",,", names: []string{"file " + file}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("QUESTION_SOURCES", tt.env)
+			var names []string
+			for _, src := range questionSourcesFromEnv() {
+				names = append(names, src.Name())
+			}
+			if strings.Join(names, "|") != strings.Join(tt.names, "|") {
+				t.Errorf("sources = %v, want %v", names, tt.names)
+			}
+		})
+	}
+}
+
+func TestLoadQuestionBank(t *testing.T) {
+	embedded, err := source.NewEmbeddedSource().Load()
+	if err != nil {
+		t.Fatalf("embedded Load() error = %v", err)
+	}
+	tests := []struct {
+		name    string
+		sources []source.QuestionSource
+		want    int
+	}{
+		{name: "読み込めた問題を使う", sources: []source.QuestionSource{&fakeSource{name: "a", questions: []types.Question{withID("q1", "a")}}}, want: 1},
+		{name: "ソースがなければ組み込みの問題", sources: nil, want: len(embedded)},
+		{name: "すべて失敗したら組み込みの問題", sources: []source.QuestionSource{&fakeSource{name: "broken", err: errors.New("boom")}}, want: len(embedded)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := loadQuestionBank(tt.sources); len(got) != tt.want {
+				t.Errorf("len(loadQuestionBank()) = %d, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeQuestionSourcesExpandsDirectories(t *testing.T) {
+	dir := t.TempDir()
+	writeQuestionFile(t, filepath.Join(dir, "a.json"), testQuestions(2))
+	writeQuestionFile(t, filepath.Join(dir, "b.json"), testQuestions(3)[1:])
+
+	questions, problems := mergeQuestionSources([]source.QuestionSource{source.NewDirSource(dir)})
+	if len(questions) != 3 {
+		t.Errorf("len(questions) = %d, want 3", len(questions))
+	}
+	// ディレクトリ内の衝突もファイル単位で報告する
+	want := "file " + filepath.Join(dir, "b.json") + ": question q2 conflicts with the one in file " + filepath.Join(dir, "a.json")
+	if len(problems) != 1 || problems[0].Error() != want {
+		t.Errorf("problems = %v, want [%s]", problems, want)
+	}
+}
+
+func TestMergeQuestionOverlay(t *testing.T) {
+	base := testQuestions(3)
+	collision := base[1]
+	collision.Statement = "overridden"
+	replacement := collision
+	replacement.Replaces = true
+	tests := []struct {
+		name    string
+		overlay map[string]types.Question
+		want    string
+		wantErr error
+	}{
+		{name: "ストレージにのみある問題を追加", overlay: map[string]types.Question{"q9": withID("q9", "q9")}, want: "[q1 q2 q3 q9]"},
+		{name: "Replaces 付きの問題は上書きする", overlay: map[string]types.Question{"q2": replacement}, want: "[q1 q2 q3]"},
+		{name: "Replaces なしで重なる問題は拒否する", overlay: map[string]types.Question{"q2": collision}, wantErr: ErrQuestionReplaces},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, err := mergeQuestionOverlay(base, tt.overlay)
+
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("mergeQuestionOverlay() error = %v, want %v", err, tt.wantErr)
+			}
+			var ids []string
+			for _, q := range merged {
+				ids = append(ids, q.ID)
+			}
+			if tt.wantErr == nil && fmt.Sprint(ids) != tt.want {
+				t.Errorf("IDs = %v, want %v", ids, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyQuestionOverlay(t *testing.T) {
+	base := testQuestions(3)
+	override := base[1]
+	override.Statement = "overridden"
+	tests := []struct {
+		name    string
+		overlay map[string]types.Question
+		want    string
+	}{
+		{name: "重ねる問題なし", overlay: nil, want: "[q1 q2 q3]"},
+		{name: "同じIDの問題は元の位置で上書きする", overlay: map[string]types.Question{"q2": override}, want: "[q1 q2 q3]"},
+		{name: "ストレージにのみある問題はID順で末尾", overlay: map[string]types.Question{"z": withID("z", "z"), "m": withID("m", "m")}, want: "[q1 q2 q3 m z]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged := applyQuestionOverlay(base, tt.overlay)
+			var ids []string
+			for _, q := range merged {
+				ids = append(ids, q.ID)
+			}
+			if got := fmt.Sprint(ids); got != tt.want {
+				t.Errorf("IDs = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("上書きした内容を使う", func(t *testing.T) {
+		merged := applyQuestionOverlay(base, map[string]types.Question{"q2": override})
+		if merged[1].Statement != "overridden" || base[1].Statement == "overridden" {
+			t.Errorf("merged[1] = %q, base[1] = %q", merged[1].Statement, base[1].Statement)
+		}
+	})
+}
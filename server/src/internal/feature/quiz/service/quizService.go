@@ -2,10 +2,15 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
 	"math/rand"
 	"os"
+	"server/src/internal/feature/quiz/codec"
+	"server/src/internal/feature/quiz/source"
 	"server/src/internal/feature/quiz/types"
 	"server/src/internal/feature/quiz/websocket"
 	"sort"
@@ -13,52 +18,229 @@ import (
 	"time"
 )
 
-const TOTAL_QUESTIONS = 2 // types.Questionの要素数
-
 type QuizService struct {
-	hub        *websocket.RoomHub
-	questions  []types.Question
-	gameStates map[string]*types.GameState
-	mu         sync.RWMutex
+	hub          *websocket.RoomHub
+	questions    []types.Question          // 出題に使用する問題バンク（base に overlay を重ねたもの）
+	sources      []source.QuestionSource   // 問題ソース（ファイル・ディレクトリ）
+	base         []types.Question          // 問題ソースから読み込んだ問題
+	overlay      map[string]types.Question // ストレージに保存された問題（Key: 問題ID）
+	mu           sync.RWMutex              // 問題バンク・答え検証の取り消し・ゲーム終了時のリスナーを保護する
+	updateMu     sync.Mutex                // 問題バンクの差し替え（検証・ストレージへの保存・反映の一連の処理）を直列化する
+	cancelVerify context.CancelFunc        // バックグラウンドで実行中の答え検証を取り消す
+	games        map[string]*gameLoop      // 進行中のゲーム（Key: ルームID）
+	rematches    map[string]*types.Rematch // 終了したゲームの再戦の受付状態（Key: ルームID）
+	gamesMu      sync.Mutex                // games と rematches を保護する（ゲーム状態そのものは各ゲームの goroutine が扱う）
+	outbox       []*types.Message          // ハブへ送信するメッセージのキュー（送信順を保持）
+	outboxRooms  map[string]int            // outbox に積まれているルームごとのメッセージの数（Key: ルームID）
+	overflowed   map[string]bool           // outbox が溢れたため送信を止めたルーム（Key: ルームID）
+	outboxMu     sync.Mutex                // outbox・outboxRooms・overflowed を保護する
+	outboxReady  chan struct{}             // outbox にメッセージが積まれたことを runOutbox に通知する
+
+	gameOverListeners []func(types.GameResult) // ゲーム終了時に呼び出す関数
 }
 
 func NewQuizService(hub *websocket.RoomHub) *QuizService {
-	questions, err := loadQuestions("../mock/mock.json")
-	if err != nil {
-		log.Fatalf("error: cannot load questions: %v", err)
+	sources := questionSourcesFromEnv()
+	questions := loadQuestionBank(sources)
+	s := &QuizService{
+		hub:         hub,
+		questions:   questions,
+		sources:     sources,
+		base:        questions,
+		games:       make(map[string]*gameLoop),
+		rematches:   make(map[string]*types.Rematch),
+		outboxRooms: make(map[string]int),
+		overflowed:  make(map[string]bool),
+		outboxReady: make(chan struct{}, 1),
 	}
-	return &QuizService{
-		hub:        hub,
-		questions:  questions,
-		gameStates: make(map[string]*types.GameState),
+	go s.runOutbox()
+	s.verifyQuestionsInBackground(questions)
+	if questionWatchEnabled() {
+		go s.watchQuestionSources(QUESTION_WATCH_INTERVAL)
 	}
+	return s
 }
 
-func (s *QuizService) StartGame(roomID string) error {
+// Questions は現在の問題バンクを返します。返したスライスは変更しないでください。
+func (s *QuizService) Questions() []types.Question {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.questions
+}
+
+// Question は問題バンクから問題を1件取得します。
+func (s *QuizService) Question(id string) (*types.Question, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for i := range s.questions {
+		if s.questions[i].ID == id {
+			q := s.questions[i]
+			return &q, true
+		}
+	}
+	return nil, false
+}
+
+// BaseQuestions は問題ソースから読み込んだ問題（ストレージの問題を重ねる前）を返します。
+func (s *QuizService) BaseQuestions() []types.Question {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.base
+}
+
+// SetQuestionOverlay はストレージに保存された問題を問題バンクに重ね、問題バンクを差し替えます。
+// 進行中のゲームは開始時に抽出した出題候補を使い続けるため、影響を受けません。
+func (s *QuizService) SetQuestionOverlay(stored map[string]types.Question) error {
+	s.updateMu.Lock()
+	defer s.updateMu.Unlock()
+	return s.setQuestionOverlay(stored)
+}
+
+// UpdateQuestionOverlay はストレージの問題を stored に置き換えた問題バンクが検証を通ることを確認してから persist でストレージに書き込み、
+// 成功した場合に問題バンクを差し替えます。検証に失敗した場合は persist を呼び出さずに ErrInvalidQuestion
+// （問題ファイルの問題を Replaces なしで上書きしようとした場合は ErrQuestionReplaces）を返します。
+// 検証から差し替えまでの間に問題ソースの読み込み直しなどで問題バンクが変わらないよう、一連の処理を updateMu で直列化します。
+func (s *QuizService) UpdateQuestionOverlay(stored map[string]types.Question, persist func() error) error {
+	s.updateMu.Lock()
+	defer s.updateMu.Unlock()
+
+	if err := s.CheckQuestionOverlay(stored); err != nil {
+		if errors.Is(err, ErrQuestionReplaces) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", ErrInvalidQuestion, err)
+	}
+	if err := persist(); err != nil {
+		return err
+	}
+	return s.setQuestionOverlay(stored)
+}
+
+// setQuestionOverlay は SetQuestionOverlay の本体です。呼び出し側で s.updateMu をロックしてください。
+func (s *QuizService) setQuestionOverlay(stored map[string]types.Question) error {
+	overlay := make(map[string]types.Question, len(stored))
+	for id, q := range stored {
+		overlay[id] = q
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	questions, err := mergeQuestionOverlay(s.base, overlay)
+	if err != nil {
+		return err
+	}
+	s.overlay = overlay
+	s.questions = questions
+	log.Printf("Question bank replaced: %d questions", len(questions))
+	return nil
+}
+
+// CheckQuestionOverlay はストレージの問題を問題バンクに重ねた場合に、問題バンク全体が検証を通るかを確認します。問題バンクは変更しません。
+func (s *QuizService) CheckQuestionOverlay(stored map[string]types.Question) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, err := mergeQuestionOverlay(s.base, stored)
+	return err
+}
+
+// OnGameOver はゲーム終了時に呼び出す関数を登録します。
+// listener はゲームの goroutine とは別の goroutine から呼び出されるため、QuizService のメソッドを呼び出せます。
+func (s *QuizService) OnGameOver(listener func(types.GameResult)) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	s.gameOverListeners = append(s.gameOverListeners, listener)
+}
+
+// StartGame はルームのゲームを開始し、ゲームを進行する goroutine を起動します。
+// 同じルームで進行中のゲームがある場合は、そのゲームを終了して新しいゲームに置き換えます。
+// 他の機能が管理するルームは ErrRoomOwned を返し、開始しません（StartOwnedGame を使用します）。
+func (s *QuizService) StartGame(roomID string) error {
+	return s.startGame(roomID, "")
+}
 
-	playerIDs := s.hub.GetClientIDs(roomID)
+// StartOwnedGame はルームを管理する機能（owner）からルームのゲームを開始します。
+// ルームの管理者が owner と異なる場合は ErrRoomOwned を返します。
+func (s *QuizService) StartOwnedGame(roomID, owner string) error {
+	return s.startGame(roomID, owner)
+}
+
+func (s *QuizService) startGame(roomID, owner string) error {
+	settings := s.loadGameSettings(roomID)
+	if settings.Owner != owner {
+		return ErrRoomOwned
+	}
+
+	rematch := s.takeRematch(roomID)
+	pool := buildQuestionPool(s.Questions(), settings)
+	pool = excludeRematchQuestions(roomID, settings, rematch, pool)
+	if len(pool) == 0 {
+		return errors.New("no questions available for this room")
+	}
+	totalQuestions := totalQuestionCount(settings, len(pool))
+	if settings.Mode != types.GameModeElimination && totalQuestions < settings.QuestionCount {
+		log.Printf("Room %s requested %d questions but only %d are available", roomID, settings.QuestionCount, totalQuestions)
+	}
+
+	playerIDs := s.rematchRoster(roomID, rematch)
 	initialScores := make(map[string]int)
+	statuses := make(map[string]types.PlayerStatus)
 	for _, id := range playerIDs {
 		initialScores[id] = 0 // 全員のスコアを0で初期化
+		statuses[id] = types.PlayerActive
 	}
 
-	// 既存のゲームステートがあればリセット
+	seed := newGameSeed(settings.Seed)
 	newState := &types.GameState{
+		Settings:         settings,
 		Scores:           initialScores, // 初期化されたスコアマップを使用
-		AnsweredUsers:    make(map[string]bool),
+		Streaks:          make(map[string]int),
+		Answers:          make(map[string]types.PlayerAnswer),
 		QuestionNumber:   0,
+		TotalQuestions:   totalQuestions,
 		IsQuestionActive: false,
 		UsedQuestionIDs:  make([]string, 0), // 出題済み問題IDを初期化
+		QuestionPool:     pool,
+		Seed:             seed,
+		Rand:             rand.New(rand.NewSource(seed)),
+		PlayerStatuses:   statuses,
+		ClosedRoster:     rematch != nil,
+	}
+	if settings.Mode == types.GameModeTeam {
+		newState.Teams, newState.TeamOf = buildTeams(settings, playerIDs, newState.Rand)
 	}
 
-	s.gameStates[roomID] = newState
-	log.Printf("Game started in room %s", roomID)
-	s.nextQuestion(roomID)
+	g := newGameLoop(roomID, newState)
+	s.gamesMu.Lock()
+	oldGame, replaced := s.games[roomID]
+	s.games[roomID] = g
+	s.gamesMu.Unlock()
+	if replaced {
+		// 既存のゲームがあれば終了（タイマーもその goroutine が止める）
+		oldGame.stop()
+	}
+	go g.run()
+	s.setRoomState(roomID, ROOM_STATE_PLAYING)
+
+	log.Printf("Game started in room %s (seed %d)", roomID, seed)
+	g.send(func() {
+		if settings.CountdownTime > 0 {
+			s.startCountdown(roomID, newState)
+		} else {
+			s.nextQuestion(roomID, newState)
+		}
+	})
 	return nil
 }
 
+// totalQuestionCount はゲームの出題数を返します。ルーム設定の値を、出題候補の数（言語と難易度で絞り込んだ数）で頭打ちにします。
+// 脱落モードは残り1人になるか、出題候補がなくなるまで続けます。
+func totalQuestionCount(settings types.GameSettings, eligible int) int {
+	if settings.Mode == types.GameModeElimination || settings.QuestionCount > eligible {
+		return eligible
+	}
+	return settings.QuestionCount
+}
+
 // ProcessClientMessage はクライアントからのメッセージを処理します。
 func (s *QuizService) ProcessClientMessage(roomID, userID string, message []byte) {
 	var msg types.Message
@@ -68,7 +250,20 @@ func (s *QuizService) ProcessClientMessage(roomID, userID string, message []byte
 	}
 	switch msg.Type {
 	case "answer":
-		s.processAnswer(roomID, userID, msg.Payload)
+		err := s.dispatch(roomID, func(state *types.GameState) { s.processAnswer(roomID, userID, state, msg.Payload) })
+		if errors.Is(err, ErrGameBusy) {
+			s.sendBusyError(roomID, userID)
+		} else if err != nil {
+			log.Printf("error: game state not found for room %s", roomID)
+		}
+	case CONTROL_PAUSE, CONTROL_RESUME, CONTROL_SKIP_QUESTION, CONTROL_ABORT_GAME:
+		if err := s.dispatch(roomID, func(state *types.GameState) { s.processHostControl(roomID, userID, msg.Type, state) }); err != nil {
+			s.sendControlError(roomID, userID, msg.Type, err)
+		}
+	case REMATCH_REQUEST:
+		s.requestRematch(roomID, userID)
+	case REMATCH_ACCEPT:
+		s.acceptRematch(roomID, userID)
 	// 他のメッセージタイプが必要な場合はここに追加
 	default:
 		log.Printf("Unknown message type: %s", msg.Type)
@@ -76,121 +271,474 @@ func (s *QuizService) ProcessClientMessage(roomID, userID string, message []byte
 }
 
 // processAnswer はユーザーからの回答を処理します。
-func (s *QuizService) processAnswer(roomID, userID string, payload interface{}) {
-	s.mu.Lock()
+func (s *QuizService) processAnswer(roomID, userID string, state *types.GameState, payload interface{}) {
+	if !state.IsQuestionActive {
+		return
+	}
 
-	state, ok := s.gameStates[roomID]
-	if !ok {
-		s.mu.Unlock()
-		log.Printf("error: game state not found for room %s", roomID)
+	// 一時停止中は回答を受け付けない
+	if state.Paused {
+		s.sendAnswerError(roomID, userID, state, ErrGamePaused.Error())
 		return
 	}
 
-	if !state.IsQuestionActive {
-		s.mu.Unlock()
+	// 締切後に届いた回答は無視（タイマー発火との競合対策）
+	if time.Now().After(state.QuestionDeadline) {
 		return
 	}
 
 	// ★ 既に回答済みのユーザーは無視
-	if state.AnsweredUsers[userID] {
-		s.mu.Unlock()
+	if _, answered := state.Answers[userID]; answered {
+		return
+	}
+
+	// 再戦に参加しなかったプレイヤーは観戦のみ
+	if !seatedPlayer(state, userID) {
+		s.sendAnswerError(roomID, userID, state, "only players who joined the rematch can answer")
+		return
+	}
+	// 脱落したプレイヤーは観戦のみ
+	if !isActivePlayer(state, userID) {
+		s.sendAnswerError(roomID, userID, state, "eliminated players cannot answer")
+		return
+	}
+	if oneAnswerPerTeam(state) && teammateAnswered(state, userID) {
+		s.sendAnswerError(roomID, userID, state, "your team has already answered")
 		return
 	}
 
 	payloadMap, ok := payload.(map[string]interface{})
 	if !ok {
-		s.mu.Unlock()
+		s.sendAnswerError(roomID, userID, state, "answer payload must be an object")
+		return
+	}
+	// 問題の形式ごとに回答を判定（不正な形式の回答は未回答のまま扱い、再送を受け付ける）
+	result, err := evaluateAnswer(state.CurrentQuestion, payloadMap)
+	if err != nil {
+		log.Printf("warning: invalid answer from user %s in room %s: %v", userID, roomID, err)
+		s.sendAnswerError(roomID, userID, state, err.Error())
 		return
 	}
-	answer, _ := payloadMap["answer"].(string)
 
-	isCorrect := (answer == state.CurrentQuestion.Answer)
+	isCorrect := result.IsCorrect
+	answeredAt := time.Now()
 	if isCorrect {
-		state.Scores[userID] += 10
+		state.Streaks[userID]++
+	} else {
+		state.Streaks[userID] = 0
+	}
+	breakdown := state.Settings.Scoring.Score(types.ScoringInput{
+		IsCorrect:         isCorrect,
+		Credit:            result.Credit,
+		AnsweredAt:        answeredAt,
+		QuestionStartedAt: state.QuestionStartedAt,
+		QuestionDeadline:  state.QuestionDeadline,
+		Streak:            state.Streaks[userID],
+	})
+	state.Scores[userID] += breakdown.Total
+	state.Answers[userID] = types.PlayerAnswer{
+		UserID:     userID,
+		ChoiceID:   result.ChoiceID,
+		ChoiceIDs:  result.ChoiceIDs,
+		Choice:     result.Display,
+		IsCorrect:  isCorrect,
+		Credit:     result.Credit,
+		Breakdown:  &breakdown,
+		AnsweredAt: answeredAt,
 	}
-	state.AnsweredUsers[userID] = true
 
-	// ★ 最初の回答者が来た時点で回答受付終了
-	state.IsQuestionActive = false
+	allAnswered := s.allPlayersAnswered(roomID, state)
+
+	switch state.Settings.AnswerMode {
+	case types.AnswerModeEveryone:
+		// 正誤は締め切るまで公開しない
+		s.broadcast(&types.Message{
+			Type: "player_answered",
+			Payload: map[string]interface{}{
+				"userId":        userID,
+				"answeredCount": len(state.Answers),
+			},
+			RoomID: roomID,
+		})
+		if allAnswered {
+			s.closeQuestion(roomID, state, "all_answered")
+		}
+	default:
+		// first_correct: 不正解の場合は他のプレイヤーの回答受付を継続する
+		s.broadcast(&types.Message{
+			Type: "answer_result",
+			Payload: withTeams(state, map[string]interface{}{
+				"userId":    userID,
+				"choiceId":  result.ChoiceID,
+				"choiceIds": result.ChoiceIDs,
+				"choice":    result.Display,
+				"isCorrect": isCorrect,
+				"credit":    result.Credit,
+				"breakdown": breakdown,
+				"scores":    snapshotScores(state.Scores),
+			}),
+			RoomID: roomID,
+		})
+		if isCorrect {
+			s.closeQuestion(roomID, state, "correct_answer")
+		} else if allAnswered {
+			s.closeQuestion(roomID, state, "all_answered")
+		}
+	}
+}
+
+// sendAnswerError は不正な回答を送信したユーザーにのみ、エラー内容を通知します。
+func (s *QuizService) sendAnswerError(roomID, userID string, state *types.GameState, reason string) {
+	s.broadcast(&types.Message{
+		Type: "answer_error",
+		Payload: map[string]interface{}{
+			"questionNumber": state.QuestionNumber,
+			"message":        reason,
+		},
+		RoomID: roomID,
+		UserID: userID,
+	})
+}
 
-	resultMsg := &types.Message{
-		Type: "answer_result",
+// sendBusyError はゲームの処理が詰まっていて受け付けられなかった回答を、送信したユーザーにのみ通知します。
+// ハブのRunループから呼び出すため、ゲーム状態（問題番号など）は参照しません。
+func (s *QuizService) sendBusyError(roomID, userID string) {
+	s.broadcast(&types.Message{
+		Type: "answer_error",
 		Payload: map[string]interface{}{
-			"userId":        userID,
-			"isCorrect":     isCorrect,
-			"correctAnswer": state.CurrentQuestion.Answer,
-			"scores":        state.Scores,
+			"message": ErrGameBusy.Error(),
 		},
 		RoomID: roomID,
+		UserID: userID,
+	})
+}
+
+// allPlayersAnswered は接続中の全プレイヤーが現在の問題に回答済みかを返します。
+// 脱落モードで脱落したプレイヤー（観戦者）は数えません。
+func (s *QuizService) allPlayersAnswered(roomID string, state *types.GameState) bool {
+	players := 0
+	for _, id := range s.hub.GetClientIDs(roomID) {
+		if !isActivePlayer(state, id) {
+			continue
+		}
+		players++
+		if _, answered := state.Answers[id]; answered {
+			continue
+		}
+		// チームで1人のみ回答する場合は、チームの誰かが回答していれば回答済みとみなす
+		if !(oneAnswerPerTeam(state) && teammateAnswered(state, id)) {
+			return false
+		}
+	}
+	return players > 0
+}
+
+// closeQuestion は現在の問題の回答受付を締め切り、全員分の結果を送信して次の問題へ進めます。
+// reason は締め切った理由（correct_answer / all_answered / timeout）です。
+func (s *QuizService) closeQuestion(roomID string, state *types.GameState, reason string) {
+	state.IsQuestionActive = false
+	stopQuestionTimer(state)
+
+	results := buildQuestionResults(state)
+	reveal := state.CurrentQuestion.Reveal()
+	state.History = append(state.History, types.QuestionRecord{
+		QuestionNumber: state.QuestionNumber,
+		Question:       state.CurrentQuestion.Statement,
+		CorrectAnswer:  state.CurrentQuestion.CorrectAnswerText(),
+		Reveal:         reveal,
+		Results:        results,
+	})
+
+	s.broadcast(&types.Message{
+		Type: "question_result",
+		Payload: withTeams(state, map[string]interface{}{
+			"questionNumber":   state.QuestionNumber,
+			"reason":           reason,
+			"correctAnswer":    state.CurrentQuestion.CorrectAnswerText(),
+			"correctChoiceId":  state.CurrentQuestion.CorrectChoiceID(),
+			"correctChoiceIds": state.CurrentQuestion.CorrectChoiceIDs(),
+			"results":          results,
+			"reveal":           reveal,
+			"scores":           snapshotScores(state.Scores),
+		}),
+		RoomID: roomID,
+	})
+	if reason != CLOSE_REASON_SKIPPED {
+		// スキップした問題では回答の機会がなかったプレイヤーもいるため、誰も脱落させない
+		s.eliminatePlayers(roomID, state)
+	}
+	s.startReveal(roomID, state)
+}
+
+// buildQuestionResults はプレイヤーごとの回答内容と正誤を、UserID順に並べて返します。
+// 回答しなかったプレイヤーも Answered=false として含めます。
+func buildQuestionResults(state *types.GameState) []types.PlayerAnswer {
+	results := make([]types.PlayerAnswer, 0, len(state.Scores))
+	for userID := range state.Scores {
+		if answer, ok := state.Answers[userID]; ok {
+			answer.Answered = true
+			results = append(results, answer)
+		} else {
+			results = append(results, types.PlayerAnswer{UserID: userID})
+		}
 	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].UserID < results[j].UserID
+	})
+	return results
+}
 
-	s.mu.Unlock()
+// snapshotScores はスコアマップのコピーを返します。
+// メッセージは送信キュー経由で非同期にシリアライズされるため、共有中のマップを直接渡さないようにします。
+func snapshotScores(scores map[string]int) map[string]int {
+	snapshot := make(map[string]int, len(scores))
+	for userID, score := range scores {
+		snapshot[userID] = score
+	}
+	return snapshot
+}
 
-	go func() {
-		s.hub.Broadcast <- resultMsg
-	}()
+// handleQuestionTimeout は制限時間切れの問題を締め切り、正解を公開して次の問題へ進めます。
+// questionNumber はタイマー設定時の問題番号で、既に次の問題へ進んでいる場合は何もしません。
+func (s *QuizService) handleQuestionTimeout(roomID string, state *types.GameState, questionNumber int) {
+	if state.QuestionNumber != questionNumber || !state.IsQuestionActive || state.Paused {
+		return
+	}
+	// 一時停止・再開で締切が延びた後に、停止前のタイマーが発火した場合は何もしない
+	if time.Now().Before(state.QuestionDeadline) {
+		return
+	}
+
+	state.QuestionTimer = nil
+	log.Printf("Question %d timed out in room %s", questionNumber, roomID)
+
+	s.broadcast(&types.Message{
+		Type: "question_timeout",
+		Payload: withTeams(state, map[string]interface{}{
+			"questionNumber":   questionNumber,
+			"correctAnswer":    state.CurrentQuestion.CorrectAnswerText(),
+			"correctChoiceId":  state.CurrentQuestion.CorrectChoiceID(),
+			"correctChoiceIds": state.CurrentQuestion.CorrectChoiceIDs(),
+			"scores":           snapshotScores(state.Scores),
+		}),
+		RoomID: roomID,
+	})
+	s.closeQuestion(roomID, state, "timeout")
+}
+
+// startQuestionTimer は現在の問題の制限時間タイマーを開始します。
+func (s *QuizService) startQuestionTimer(roomID string, state *types.GameState, d time.Duration) {
+	questionNumber := state.QuestionNumber
+	stopQuestionTimer(state)
+	state.QuestionTimer = time.AfterFunc(d, func() {
+		s.dispatchTo(roomID, state, func() {
+			s.handleQuestionTimeout(roomID, state, questionNumber)
+		})
+	})
+}
 
-	// 3秒後に次の問題へ進む
-	go func() {
-		time.Sleep(3 * time.Second)
-		s.mu.Lock()
-		defer s.mu.Unlock()
-		s.nextQuestion(roomID)
-	}()
+// stopQuestionTimer は問題の制限時間タイマーを停止します。
+func stopQuestionTimer(state *types.GameState) {
+	if state.QuestionTimer != nil {
+		state.QuestionTimer.Stop()
+		state.QuestionTimer = nil
+	}
 }
 
 // nextQuestion は次の問題を出題するか、ゲームを終了します。
-func (s *QuizService) nextQuestion(roomID string) {
-	state, ok := s.gameStates[roomID]
-	if !ok {
+func (s *QuizService) nextQuestion(roomID string, state *types.GameState) {
+	cancelPhase(state)
+
+	// 脱落モードで勝者が決まったらゲーム終了
+	if survivorDecided(state) {
+		log.Printf("Game ending: survivor decided in room %s", roomID)
+		s.endGame(roomID, state)
 		return
 	}
 
 	// 全問題が終わったらゲーム終了
-	log.Printf("Question check: current=%d, total=%d", state.QuestionNumber, TOTAL_QUESTIONS)
-	if state.QuestionNumber >= TOTAL_QUESTIONS {
-		log.Printf("Game ending: reached maximum questions (%d)", TOTAL_QUESTIONS)
-		s.endGame(roomID)
+	log.Printf("Question check: current=%d, total=%d", state.QuestionNumber, state.TotalQuestions)
+	if state.QuestionNumber >= state.TotalQuestions {
+		log.Printf("Game ending: reached maximum questions (%d)", state.TotalQuestions)
+		s.endGame(roomID, state)
 		return
 	}
 
-	state.QuestionNumber++
-	
 	// 重複を避けて問題を選択
-	nextQuestion := s.getNextUniqueQuestion(state.UsedQuestionIDs)
+	nextQuestion := s.getNextUniqueQuestion(state)
 	if nextQuestion == nil {
-		log.Printf("No more unique questions available, ending game")
-		s.endGame(roomID)
+		// 問題が尽きた場合は、その旨を通知してから終了する
+		log.Printf("No more unique questions available, ending game in room %s", roomID)
+		s.broadcast(&types.Message{
+			Type: "questions_exhausted",
+			Payload: map[string]interface{}{
+				"questionNumber": state.QuestionNumber,
+				"totalQuestions": state.TotalQuestions,
+				"message":        "出題できる問題がなくなったため、ゲームを終了します。",
+			},
+			RoomID: roomID,
+		})
+		s.endGame(roomID, state)
 		return
 	}
-	
+
+	state.QuestionNumber++
 	state.CurrentQuestion = nextQuestion
 	state.UsedQuestionIDs = append(state.UsedQuestionIDs, nextQuestion.ID)
-	state.AnsweredUsers = make(map[string]bool)
+	state.Answers = make(map[string]types.PlayerAnswer)
 	state.IsQuestionActive = true // 回答受付開始
+	state.Phase = types.PhaseQuestion
+
+	// サーバー側で制限時間を管理する
+	now := time.Now()
+	state.QuestionStartedAt = now
+	state.QuestionDeadline = now.Add(state.Settings.TimeLimit)
+	s.startQuestionTimer(roomID, state, state.Settings.TimeLimit)
 
 	log.Printf("Question %d selected: %s (ID: %s)", state.QuestionNumber, nextQuestion.Statement, nextQuestion.ID)
 
-	message := &types.Message{
+	shuffle := state.Settings.Shuffle
+	if state.CurrentQuestion.QuestionKind() == types.KindOrdering && shuffle == types.ShuffleNone {
+		// 並べ替え問題は正解の順番のまま出題できないため、常に並び替える
+		shuffle = types.ShuffleGame
+	}
+
+	switch {
+	case state.CurrentQuestion.QuestionKind() == types.KindFreeText:
+		// 自由記述は選択肢がないため並び替えない
+		s.broadcast(questionStartMessage(roomID, state, nil, now))
+	case shuffle == types.ShufflePlayer:
+		// プレイヤーごとに異なる順番の選択肢を個別に送信
+		for _, userID := range s.hub.GetClientIDs(roomID) {
+			rng := playerRand(state.Seed, state.QuestionNumber, userID)
+			message := questionStartMessage(roomID, state, shuffleChoices(rng, state.CurrentQuestion.ChoiceList()), now)
+			message.UserID = userID
+			s.broadcast(message)
+		}
+	case shuffle == types.ShuffleGame:
+		s.broadcast(questionStartMessage(roomID, state, shuffleChoices(state.Rand, state.CurrentQuestion.ChoiceList()), now))
+	default:
+		s.broadcast(questionStartMessage(roomID, state, state.CurrentQuestion.ChoiceList(), now))
+	}
+}
+
+// questionStartMessage は出題メッセージを組み立てます。
+func questionStartMessage(roomID string, state *types.GameState, choices []types.Choice, now time.Time) *types.Message {
+	return &types.Message{
 		Type: "question_start",
-		Payload: map[string]interface{}{
+		Payload: withTeams(state, map[string]interface{}{
 			"questionNumber": state.QuestionNumber,
+			"totalQuestions": state.TotalQuestions,
+			"kind":           state.CurrentQuestion.QuestionKind(),
+			"difficulty":     state.CurrentQuestion.Difficulty,
+			"rating":         state.CurrentQuestion.DifficultyRating(),
 			"question":       state.CurrentQuestion.Statement,
-			"choices":        state.CurrentQuestion.Choices,
-		},
+			"choices":        choices,
+			"timeLimit":      int(state.Settings.TimeLimit / time.Second),
+			"deadline":       state.QuestionDeadline.UnixMilli(), // クライアントはこの時刻に合わせてカウントダウンする
+			"serverTime":     now.UnixMilli(),                    // クライアントとの時計のずれ補正用
+		}),
 		RoomID: roomID,
 	}
-	s.hub.Broadcast <- message
 }
 
-// endGame はゲームを終了し、最終結果を送信します。
-func (s *QuizService) endGame(roomID string) {
-	state, ok := s.gameStates[roomID]
-	if !ok {
+// shuffleChoices は選択肢の順番を rng で並び替えます。
+func shuffleChoices(rng *rand.Rand, choices []types.Choice) []types.Choice {
+	rng.Shuffle(len(choices), func(i, j int) {
+		choices[i], choices[j] = choices[j], choices[i]
+	})
+	return choices
+}
+
+// endGame はゲームを終了し、最終結果を送信します。ゲームの goroutine はこの処理の後に終了します。
+func (s *QuizService) endGame(roomID string, state *types.GameState) {
+	stopGameTimers(state)
+
+	results, teams := rankPlayers(state)
+
+	// 出題した問題の解説をまとめて振り返り用に送信
+	// シードを記録しておくと、同じ問題バンクで同じ出題順・選択肢の順番を再現できます
+	summary := map[string]interface{}{
+		"seed":      state.Seed,
+		"questions": state.History,
+	}
+	if state.Settings.Mode == types.GameModeElimination {
+		summary["eliminations"] = state.Eliminations
+	}
+	if state.Aborted {
+		summary["aborted"] = true
+	}
+	s.broadcast(&types.Message{
+		Type:    "game_summary",
+		Payload: summary,
+		RoomID:  roomID,
+	})
+
+	if state.Settings.Mode == types.GameModeTeam {
+		s.broadcast(&types.Message{
+			Type:    "team_results",
+			Payload: teams,
+			RoomID:  roomID,
+		})
+	}
+
+	message := &types.Message{
+		Type:    "game_over",
+		Payload: results,
+		RoomID:  roomID,
+	}
+	s.broadcast(message)
+
+	s.notifyGameOver(types.GameResult{RoomID: roomID, Seed: state.Seed, Results: results, History: state.History, Teams: teams, Aborted: state.Aborted})
+
+	// ゲームの途中でルームが削除された場合は、削除されたルームの状態も再戦も記録しない
+	if g, ok := s.game(roomID); !ok || g.state != state {
+		log.Printf("Game ended in room %s after the room was deleted", roomID)
 		return
 	}
 
-	// スコアに基づいてランキングを作成
+	// ルームの状態を更新してから、再戦を受け付ける（再戦でルームを待機状態に戻す書き込みと順番が入れ替わらないようにする）
+	s.setRoomState(roomID, ROOM_STATE_FINISHED)
+
+	// ゲームを削除し、再戦に備えて出題した問題を記録する
+	s.gamesMu.Lock()
+	defer s.gamesMu.Unlock()
+	g, ok := s.games[roomID]
+	if !ok || g.state != state {
+		return
+	}
+	delete(s.games, roomID)
+	g.stop()
+	s.rematches[roomID] = &types.Rematch{HostID: state.Settings.HostID, Owner: state.Settings.Owner, UsedQuestionIDs: state.UsedQuestionIDs}
+	log.Printf("Game ended in room %s", roomID)
+}
+
+// notifyGameOver はゲーム終了を購読している機能に結果を渡します。
+func (s *QuizService) notifyGameOver(result types.GameResult) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, listener := range s.gameOverListeners {
+		go listener(result)
+	}
+}
+
+// rankPlayers はゲームの進め方に合わせて順位を作成します。チームの順位は team モードのみ返します。
+func rankPlayers(state *types.GameState) ([]types.PlayerResult, []types.TeamResult) {
+	switch state.Settings.Mode {
+	case types.GameModeElimination:
+		// 脱落モードは脱落した順番で順位を決める
+		return eliminationRanking(state), nil
+	case types.GameModeTeam:
+		// チーム戦はチームの得点の合計で順位を決める
+		teams, results := teamRanking(state)
+		return results, teams
+	default:
+		return scoreRanking(state), nil
+	}
+}
+
+// scoreRanking はスコアに基づいてランキングを作成します。
+func scoreRanking(state *types.GameState) []types.PlayerResult {
 	results := make([]types.PlayerResult, 0, len(state.Scores))
 	for userID, score := range state.Scores {
 		results = append(results, types.PlayerResult{UserID: userID, Score: score})
@@ -215,64 +763,57 @@ func (s *QuizService) endGame(roomID string) {
 			}
 		}
 	}
-
-	message := &types.Message{
-		Type:    "game_over",
-		Payload: results,
-		RoomID:  roomID,
-	}
-	s.hub.Broadcast <- message
-
-	// ゲーム状態を削除 (またはリセットして待機状態に戻す)
-	delete(s.gameStates, roomID)
-	log.Printf("Game ended in room %s", roomID)
+	return results
 }
 
+// getNextUniqueQuestion は出題候補のうち出題済みでない問題から、ルーム設定の選び方で次の問題を選びます。
+func (s *QuizService) getNextUniqueQuestion(state *types.GameState) *types.Question {
+	availableQuestions := make([]*types.Question, 0, len(state.QuestionPool))
 
-// getNextUniqueQuestion は出題済みでない問題を返します。
-func (s *QuizService) getNextUniqueQuestion(usedIDs []string) *types.Question {
-	availableQuestions := make([]*types.Question, 0)
-	
-	for i := range s.questions {
-		question := &s.questions[i]
+	for _, question := range state.QuestionPool {
 		isUsed := false
-		
+
 		// 出題済みかどうかチェック
-		for _, usedID := range usedIDs {
+		for _, usedID := range state.UsedQuestionIDs {
 			if question.ID == usedID {
 				isUsed = true
 				break
 			}
 		}
-		
 		if !isUsed {
 			availableQuestions = append(availableQuestions, question)
 		}
 	}
-	
+
 	if len(availableQuestions) == 0 {
 		return nil // 出題可能な問題がない
 	}
-	
-	// 出題可能な問題からランダム選択
-	return availableQuestions[rand.Intn(len(availableQuestions))]
-}
 
-func (s *QuizService) getRandomQuestion() *types.Question {
-	if len(s.questions) == 0 {
-		return nil
+	selection := state.Settings.Selection
+	if selection == nil {
+		selection = randomSelection{}
 	}
-	return &s.questions[rand.Intn(len(s.questions))]
+	return selection.Select(state, availableQuestions)
 }
 
-func loadQuestions(filePath string) ([]types.Question, error) {
-	file, err := os.ReadFile(filePath)
+// LoadQuestions は問題バンクのファイルを読み込み、内容を検証します。
+// ファイル形式（JSON, YAML, CSV, Markdown）は拡張子から判定します。
+func LoadQuestions(filePath string) ([]types.Question, error) {
+	c, err := codec.ForFile(filePath)
 	if err != nil {
 		return nil, err
 	}
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
 
-	var questions []types.Question
-	if err := json.Unmarshal(file, &questions); err != nil {
+	questions, err := c.Decode(file)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateQuestions(questions); err != nil {
 		return nil, err
 	}
 
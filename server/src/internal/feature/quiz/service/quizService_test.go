@@ -0,0 +1,605 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"server/src/internal/feature/quiz/types"
+	"server/src/internal/feature/quiz/websocket"
+	"testing"
+	"time"
+)
+
+const TEST_ROOM_ID = "room-test"
+
+// newTestService はハブへ送信せず、送信キューを直接検査できるサービスを作成します。
+func newTestService(questions ...types.Question) *QuizService {
+	return &QuizService{
+		hub:         websocket.NewRoomHub(nil),
+		questions:   questions,
+		base:        questions,
+		games:       make(map[string]*gameLoop),
+		rematches:   make(map[string]*types.Rematch),
+		outboxRooms: make(map[string]int),
+		overflowed:  make(map[string]bool),
+		outboxReady: make(chan struct{}, 1),
+	}
+}
+
+// installGame は state をテスト用ルームで進行中のゲームとして登録します。
+// ゲームの goroutine は起動しないため、テストの goroutine がその代わりにゲーム状態を直接扱えます。
+func installGame(s *QuizService, state *types.GameState) *gameLoop {
+	g := newGameLoop(TEST_ROOM_ID, state)
+	s.gamesMu.Lock()
+	s.games[TEST_ROOM_ID] = g
+	s.gamesMu.Unlock()
+	return g
+}
+
+// runGame は state のゲームを登録して goroutine を起動します。goroutine はテストの終了時に停止します。
+// タイマーで進行するゲームを検査する場合に使用し、ゲーム状態には inGame を通してのみ触れます。
+func runGame(t *testing.T, s *QuizService, state *types.GameState) *gameLoop {
+	t.Helper()
+	g := installGame(s, state)
+	go g.run()
+	t.Cleanup(g.stop)
+	return g
+}
+
+// inGame は fn をゲームの goroutine で実行し、完了するまで待ちます。
+func inGame(t *testing.T, g *gameLoop, fn func()) {
+	t.Helper()
+	done := make(chan struct{})
+	if !g.send(func() {
+		fn()
+		close(done)
+	}) {
+		t.Fatal("game loop has already stopped")
+	}
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("game loop did not run the event")
+	}
+}
+
+// joinPlayers はハブを起動し、指定したユーザーをテスト用ルームに接続させます。
+func joinPlayers(t *testing.T, s *QuizService, userIDs ...string) {
+	t.Helper()
+	go s.hub.Run()
+	for _, userID := range userIDs {
+		s.hub.Register <- &websocket.Client{Hub: s.hub, Send: make(chan []byte, 64), RoomID: TEST_ROOM_ID, UserID: userID}
+	}
+	deadline := time.Now().Add(time.Second)
+	for len(s.hub.GetClientIDs(TEST_ROOM_ID)) < len(userIDs) {
+		if time.Now().After(deadline) {
+			t.Fatal("players were not registered")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// popMessage は送信キューの先頭のメッセージを取り出します。キューが空の場合は nil を返します。
+func popMessage(s *QuizService) *types.Message {
+	message, _ := s.popOutbox()
+	return message
+}
+
+// nextMessage は送信キューから次のメッセージを取り出します。
+func nextMessage(t *testing.T, s *QuizService) *types.Message {
+	t.Helper()
+	timeout := time.After(time.Second)
+	for {
+		if message := popMessage(s); message != nil {
+			return message
+		}
+		select {
+		case <-s.outboxReady:
+		case <-timeout:
+			t.Fatal("no message was broadcast")
+			return nil
+		}
+	}
+}
+
+// drainMessageTypes は送信キューに積まれているメッセージの種類を順に取り出します。
+func drainMessageTypes(s *QuizService) []string {
+	var messageTypes []string
+	for message := popMessage(s); message != nil; message = popMessage(s) {
+		messageTypes = append(messageTypes, message.Type)
+	}
+	return messageTypes
+}
+
+// assertNoMessage は送信キューが空であることを確認します。
+func assertNoMessage(t *testing.T, s *QuizService) {
+	t.Helper()
+	if messageTypes := drainMessageTypes(s); len(messageTypes) > 0 {
+		t.Fatalf("unexpected messages %v", messageTypes)
+	}
+}
+
+func activeState(questionNumber int, deadline time.Time) *types.GameState {
+	return &types.GameState{
+		Settings:         types.GameSettings{TimeLimit: DEFAULT_TIME_LIMIT, RevealTime: time.Minute, Scoring: newScoringStrategy(nil)},
+		CurrentQuestion:  &types.Question{ID: "q1", Statement: "1+1", Choices: []string{"1", "2"}, Answer: "2"},
+		Scores:           map[string]int{"alice": 0, "bob": 0},
+		Streaks:          make(map[string]int),
+		Answers:          make(map[string]types.PlayerAnswer),
+		QuestionNumber:   questionNumber,
+		IsQuestionActive: true,
+		QuestionDeadline: deadline,
+		Seed:             1,
+		Rand:             rand.New(rand.NewSource(1)),
+	}
+}
+
+func TestHandleQuestionTimeout(t *testing.T) {
+	tests := []struct {
+		name           string
+		active         bool
+		paused         bool
+		questionNumber int
+		deadline       time.Duration // 現在時刻から締切までの時間
+		want           []string
+	}{
+		{name: "出題中の問題は締め切る", active: true, questionNumber: 1, want: []string{"question_timeout", "question_result"}},
+		{name: "既に次の問題へ進んでいれば無視", active: true, questionNumber: 0},
+		{name: "回答で締め切られた問題は無視", active: false, questionNumber: 1},
+		{name: "一時停止中は無視", active: true, paused: true, questionNumber: 1},
+		{name: "再開で締切が延びていれば無視", active: true, questionNumber: 1, deadline: time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService()
+			state := activeState(1, time.Now().Add(tt.deadline))
+			state.IsQuestionActive = tt.active
+			state.Paused = tt.paused
+			installGame(s, state)
+
+			s.handleQuestionTimeout(TEST_ROOM_ID, state, tt.questionNumber)
+
+			if got := drainMessageTypes(s); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Fatalf("messages = %v, want %v", got, tt.want)
+			}
+			if tt.want != nil && state.IsQuestionActive {
+				t.Error("question is still active after timeout")
+			}
+		})
+	}
+}
+
+func TestProcessAnswerDeadline(t *testing.T) {
+	tests := []struct {
+		name       string
+		deadline   time.Duration
+		wantResult bool
+	}{
+		{name: "締切前の回答は採点する", deadline: time.Minute, wantResult: true},
+		{name: "締切後の回答は無視する", deadline: -time.Millisecond, wantResult: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService()
+			state := activeState(1, time.Now().Add(tt.deadline))
+			installGame(s, state)
+
+			s.processAnswer(TEST_ROOM_ID, "alice", state, map[string]interface{}{"answer": "2"})
+
+			if !tt.wantResult {
+				assertNoMessage(t, s)
+				if state.Scores["alice"] != 0 {
+					t.Errorf("score = %d, want 0", state.Scores["alice"])
+				}
+				return
+			}
+			if message := nextMessage(t, s); message.Type != "answer_result" {
+				t.Fatalf("message type = %q, want answer_result", message.Type)
+			}
+			if state.Scores["alice"] != 10 {
+				t.Errorf("score = %d, want 10", state.Scores["alice"])
+			}
+		})
+	}
+}
+
+func TestProcessAnswerMode(t *testing.T) {
+	type answer struct{ userID, choice string }
+	tests := []struct {
+		name       string
+		mode       types.AnswerMode
+		answers    []answer
+		want       []string
+		wantClosed bool
+	}{
+		{
+			name:       "first_correct: 不正解では締め切らず正解で締め切る",
+			mode:       types.AnswerModeFirstCorrect,
+			answers:    []answer{{"alice", "1"}, {"bob", "2"}},
+			want:       []string{"answer_result", "answer_result", "question_result"},
+			wantClosed: true,
+		},
+		{
+			name:       "first_correct: 全員不正解なら締め切る",
+			mode:       types.AnswerModeFirstCorrect,
+			answers:    []answer{{"alice", "1"}, {"bob", "1"}},
+			want:       []string{"answer_result", "answer_result", "question_result"},
+			wantClosed: true,
+		},
+		{
+			name:    "first_correct: 同じユーザーの再回答は無視",
+			mode:    types.AnswerModeFirstCorrect,
+			answers: []answer{{"alice", "1"}, {"alice", "2"}},
+			want:    []string{"answer_result"},
+		},
+		{
+			name:    "everyone: 正解者が出ても全員の回答を待つ",
+			mode:    types.AnswerModeEveryone,
+			answers: []answer{{"alice", "2"}},
+			want:    []string{"player_answered"},
+		},
+		{
+			name:       "everyone: 全員回答で締め切る",
+			mode:       types.AnswerModeEveryone,
+			answers:    []answer{{"alice", "2"}, {"bob", "1"}},
+			want:       []string{"player_answered", "player_answered", "question_result"},
+			wantClosed: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService()
+			joinPlayers(t, s, "alice", "bob")
+			state := activeState(1, time.Now().Add(time.Minute))
+			state.Settings.AnswerMode = tt.mode
+			installGame(s, state)
+
+			for _, a := range tt.answers {
+				s.processAnswer(TEST_ROOM_ID, a.userID, state, map[string]interface{}{"answer": a.choice})
+			}
+
+			if got := drainMessageTypes(s); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Fatalf("messages = %v, want %v", got, tt.want)
+			}
+			if closed := !state.IsQuestionActive; closed != tt.wantClosed {
+				t.Errorf("closed = %v, want %v", closed, tt.wantClosed)
+			}
+		})
+	}
+}
+
+func TestBuildQuestionResults(t *testing.T) {
+	state := activeState(1, time.Now())
+	state.Scores = map[string]int{"carol": 0, "alice": 10, "bob": 0}
+	state.Answers = map[string]types.PlayerAnswer{
+		"alice": {UserID: "alice", Choice: "2", IsCorrect: true},
+		"carol": {UserID: "carol", Choice: "1"},
+	}
+
+	results := buildQuestionResults(state)
+
+	want := []types.PlayerAnswer{
+		{UserID: "alice", Answered: true, Choice: "2", IsCorrect: true},
+		{UserID: "bob"},
+		{UserID: "carol", Answered: true, Choice: "1"},
+	}
+	if fmt.Sprint(results) != fmt.Sprint(want) {
+		t.Errorf("results = %+v, want %+v", results, want)
+	}
+}
+
+func TestProcessAnswerStreak(t *testing.T) {
+	s := newTestService()
+	state := activeState(1, time.Now().Add(time.Minute))
+	state.Settings.Scoring = newScoringStrategy([]string{"streak"})
+	installGame(s, state)
+
+	steps := []struct {
+		choice     string
+		wantStreak int
+		wantScore  int
+	}{
+		{choice: "2", wantStreak: 1, wantScore: 10},
+		{choice: "2", wantStreak: 2, wantScore: 25},
+		{choice: "1", wantStreak: 0, wantScore: 25},
+		{choice: "2", wantStreak: 1, wantScore: 35},
+	}
+	for i, step := range steps {
+		state.Answers = make(map[string]types.PlayerAnswer)
+		state.IsQuestionActive = true
+		s.processAnswer(TEST_ROOM_ID, "alice", state, map[string]interface{}{"answer": step.choice})
+		if state.Streaks["alice"] != step.wantStreak {
+			t.Errorf("step %d: streak = %d, want %d", i, state.Streaks["alice"], step.wantStreak)
+		}
+		if state.Scores["alice"] != step.wantScore {
+			t.Errorf("step %d: score = %d, want %d", i, state.Scores["alice"], step.wantScore)
+		}
+	}
+}
+
+// testQuestions は q1, q2, ... の ID を持つ問題を n 問作成します。
+func testQuestions(n int) []types.Question {
+	questions := make([]types.Question, n)
+	for i := range questions {
+		questions[i] = types.Question{
+			ID:        fmt.Sprintf("q%d", i+1),
+			Statement: fmt.Sprintf("question %d", i+1),
+			Choices:   []string{"a", "b"},
+			Answer:    "a",
+		}
+	}
+	return questions
+}
+
+func TestStartGameQuestionCount(t *testing.T) {
+	tests := []struct {
+		name      string
+		bankSize  int
+		wantTotal int
+		wantErr   bool
+	}{
+		{name: "問題がなければ開始できない", bankSize: 0, wantErr: true},
+		{name: "問題数が設定値より少なければ問題数で頭打ち", bankSize: 3, wantTotal: 3},
+		{name: "問題が十分あれば設定値", bankSize: 8, wantTotal: DEFAULT_QUESTION_COUNT},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(testQuestions(tt.bankSize)...)
+
+			err := s.StartGame(TEST_ROOM_ID)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("StartGame() succeeded, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("StartGame() error = %v", err)
+			}
+			g, ok := s.game(TEST_ROOM_ID)
+			if !ok {
+				t.Fatal("game loop was not started")
+			}
+			defer g.stop()
+			var total int
+			inGame(t, g, func() { total = g.state.TotalQuestions })
+			if total != tt.wantTotal {
+				t.Errorf("TotalQuestions = %d, want %d", total, tt.wantTotal)
+			}
+			message := nextMessage(t, s)
+			if message.Type != "question_start" {
+				t.Fatalf("message type = %q, want question_start", message.Type)
+			}
+			if total := message.Payload.(map[string]interface{})["totalQuestions"]; total != tt.wantTotal {
+				t.Errorf("totalQuestions = %v, want %d", total, tt.wantTotal)
+			}
+		})
+	}
+}
+
+func TestNextQuestionEndsGame(t *testing.T) {
+	tests := []struct {
+		name           string
+		questionNumber int
+		totalQuestions int
+		used           []string
+		want           []string
+	}{
+		{name: "出題数に達したら終了", questionNumber: 2, totalQuestions: 2, used: []string{"q1", "q2"}, want: []string{"game_summary", "game_over"}},
+		{name: "問題が尽きたら通知して終了", questionNumber: 3, totalQuestions: 5, used: []string{"q1", "q2", "q3"}, want: []string{"questions_exhausted", "game_summary", "game_over"}},
+		{name: "未出題の問題があれば出題", questionNumber: 1, totalQuestions: 2, used: []string{"q1"}, want: []string{"question_start"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(testQuestions(3)...)
+			state := activeState(tt.questionNumber, time.Now())
+			state.IsQuestionActive = false
+			state.TotalQuestions = tt.totalQuestions
+			state.UsedQuestionIDs = tt.used
+			state.QuestionPool = buildQuestionPool(s.questions, state.Settings)
+			installGame(s, state)
+
+			s.nextQuestion(TEST_ROOM_ID, state)
+			stopGameTimers(state)
+
+			if got := drainMessageTypes(s); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Fatalf("messages = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProcessAnswerChoice(t *testing.T) {
+	tests := []struct {
+		name        string
+		payload     map[string]interface{}
+		wantResult  bool
+		wantCorrect bool
+	}{
+		{name: "選択肢IDで正解", payload: map[string]interface{}{"choiceId": types.ChoiceID("q1", "2")}, wantResult: true, wantCorrect: true},
+		{name: "選択肢IDで不正解", payload: map[string]interface{}{"choiceId": types.ChoiceID("q1", "1")}, wantResult: true},
+		{name: "旧クライアントの文字列回答", payload: map[string]interface{}{"answer": "2"}, wantResult: true, wantCorrect: true},
+		{name: "他の問題の選択肢IDは無視", payload: map[string]interface{}{"choiceId": types.ChoiceID("q2", "2")}},
+		{name: "選択肢にない文字列は無視", payload: map[string]interface{}{"answer": "3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService()
+			state := activeState(1, time.Now().Add(time.Minute))
+			installGame(s, state)
+
+			s.processAnswer(TEST_ROOM_ID, "alice", state, tt.payload)
+
+			answer, answered := state.Answers["alice"]
+			if answered != tt.wantResult {
+				t.Fatalf("answered = %v, want %v", answered, tt.wantResult)
+			}
+			if !tt.wantResult {
+				// 不正な回答は本人にのみ通知し、再回答を受け付ける
+				message := nextMessage(t, s)
+				if message.Type != "answer_error" || message.UserID != "alice" {
+					t.Fatalf("message = %+v, want answer_error to alice", message)
+				}
+				assertNoMessage(t, s)
+				if !state.IsQuestionActive {
+					t.Error("question was closed by an invalid answer")
+				}
+				return
+			}
+			if answer.IsCorrect != tt.wantCorrect {
+				t.Errorf("IsCorrect = %v, want %v", answer.IsCorrect, tt.wantCorrect)
+			}
+			if answer.ChoiceID != types.ChoiceID("q1", answer.Choice) {
+				t.Errorf("ChoiceID %q does not match choice %q", answer.ChoiceID, answer.Choice)
+			}
+		})
+	}
+}
+
+func TestNextQuestionShuffle(t *testing.T) {
+	tests := []struct {
+		name         string
+		shuffle      types.ShuffleMode
+		wantMessages int
+		wantPerUser  bool
+	}{
+		{name: "none は問題バンクの順番", shuffle: types.ShuffleNone, wantMessages: 1},
+		{name: "game は全員共通で1通", shuffle: types.ShuffleGame, wantMessages: 1},
+		{name: "player はプレイヤーごとに個別送信", shuffle: types.ShufflePlayer, wantMessages: 2, wantPerUser: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			question := types.Question{ID: "q1", Statement: "pick", Choices: []string{"a", "b", "c", "d", "e", "f"}, Answer: "a"}
+			s := newTestService(question)
+			joinPlayers(t, s, "alice", "bob")
+			state := activeState(0, time.Now())
+			state.Settings.Shuffle = tt.shuffle
+			state.TotalQuestions = 1
+			state.QuestionPool = buildQuestionPool(s.questions, state.Settings)
+			installGame(s, state)
+
+			s.nextQuestion(TEST_ROOM_ID, state)
+			stopGameTimers(state)
+
+			recipients := make(map[string]bool)
+			for i := 0; i < tt.wantMessages; i++ {
+				message := nextMessage(t, s)
+				if message.Type != "question_start" {
+					t.Fatalf("message type = %q, want question_start", message.Type)
+				}
+				recipients[message.UserID] = true
+				choices := message.Payload.(map[string]interface{})["choices"].([]types.Choice)
+				if len(choices) != len(question.Choices) {
+					t.Fatalf("got %d choices, want %d", len(choices), len(question.Choices))
+				}
+				for j, choice := range choices {
+					if choice.ID != types.ChoiceID(question.ID, choice.Text) {
+						t.Errorf("choice %q has unstable id %q", choice.Text, choice.ID)
+					}
+					if tt.shuffle == types.ShuffleNone && choice.Text != question.Choices[j] {
+						t.Errorf("choices[%d] = %q, want %q", j, choice.Text, question.Choices[j])
+					}
+				}
+			}
+			assertNoMessage(t, s)
+			if tt.wantPerUser && !(recipients["alice"] && recipients["bob"]) {
+				t.Errorf("recipients = %v, want alice and bob", recipients)
+			}
+			if !tt.wantPerUser && !recipients[""] {
+				t.Errorf("recipients = %v, want the whole room", recipients)
+			}
+		})
+	}
+}
+
+func TestCloseQuestionRecordsReveal(t *testing.T) {
+	s := newTestService()
+	state := activeState(1, time.Now().Add(time.Minute))
+	state.CurrentQuestion.Explanation = "1+1=2"
+	state.CurrentQuestion.ChoiceRationales = map[string]string{"1": "1を足し忘れています"}
+	installGame(s, state)
+
+	s.closeQuestion(TEST_ROOM_ID, state, "timeout")
+
+	if len(state.History) != 1 {
+		t.Fatalf("len(History) = %d, want 1", len(state.History))
+	}
+	record := state.History[0]
+	if record.QuestionNumber != 1 || record.CorrectAnswer != "2" || record.Reveal.Explanation != "1+1=2" {
+		t.Errorf("History[0] = %+v", record)
+	}
+	message := nextMessage(t, s)
+	reveal, ok := message.Payload.(map[string]interface{})["reveal"].(types.QuestionReveal)
+	if message.Type != "question_result" || !ok || reveal.QuestionID != "q1" {
+		t.Fatalf("message = %+v, want question_result with reveal", message)
+	}
+}
+
+func TestLoadQuestions(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		wantIDs []string
+		wantErr bool
+	}{
+		{name: "JSON", file: "bank.json", content: `[{"Id": "q1", "Statement": "s", "Choices": ["a", "b"], "Answer": "a"}]`, wantIDs: []string{"q1"}},
+		{name: "YAML", file: "bank.yaml", content: "- id: q1\n  statement: s\n  choices: [a, b]\n  answer: a\n", wantIDs: []string{"q1"}},
+		{name: "CSV", file: "bank.csv", content: "id,statement,choices,answer\nq1,s,\"a\nb\",a\n", wantIDs: []string{"q1"}},
+		{name: "未対応の拡張子", file: "bank.txt", content: "q1", wantErr: true},
+		{name: "読み込めても不正な問題", file: "bank.json", content: `[{"Id": "q1", "Statement": "s", "Choices": ["a", "b"], "Answer": "c"}]`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			questions, err := LoadQuestions(path)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadQuestions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			ids := make([]string, len(questions))
+			for i, q := range questions {
+				ids[i] = q.ID
+			}
+			if !tt.wantErr && fmt.Sprint(ids) != fmt.Sprint(tt.wantIDs) {
+				t.Errorf("questions = %v, want %v", ids, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestStartOwnedGame(t *testing.T) {
+	tests := []struct {
+		name  string
+		owner string
+		want  error
+	}{
+		{name: "管理者のいないルームは誰でも開始できる", owner: ""},
+		{name: "管理者が異なるルームは開始しない", owner: "daily", want: ErrRoomOwned},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(testQuestions(1)...)
+
+			err := s.StartOwnedGame(TEST_ROOM_ID, tt.owner)
+
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("StartOwnedGame() error = %v, want %v", err, tt.want)
+			}
+			g, started := s.game(TEST_ROOM_ID)
+			if started != (tt.want == nil) {
+				t.Fatalf("game started = %v, want %v", started, tt.want == nil)
+			}
+			if started {
+				g.stop()
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+// server/src/internal/feature/quiz/service/rematch.go
+package service
+
+import (
+	"log"
+	"server/src/internal/feature/quiz/types"
+	"sort"
+)
+
+// ルームの gameState の値です。ルーム作成時は waiting で、ゲームの開始・終了と再戦の受付で切り替えます。
+const (
+	ROOM_STATE_WAITING  = "waiting"  // プレイヤーの参加とチーム分けを受け付ける
+	ROOM_STATE_PLAYING  = "playing"  // ゲームが進行中
+	ROOM_STATE_FINISHED = "finished" // ゲームが終了した（再戦がそろうと waiting に戻る）
+)
+
+// 再戦の WebSocket メッセージの種類です。
+const (
+	REMATCH_REQUEST = "rematch"        // ホストが再戦を呼びかける
+	REMATCH_ACCEPT  = "rematch_accept" // プレイヤーが再戦に参加する
+)
+
+// requestRematch はゲーム終了後にホストが再戦を呼びかけ、rematch_requested を全員に送信します。
+// ホストは自動的に参加したものとして扱います。
+func (s *QuizService) requestRematch(roomID, userID string) {
+	s.gamesMu.Lock()
+	if _, playing := s.games[roomID]; playing {
+		s.gamesMu.Unlock()
+		s.sendControlError(roomID, userID, REMATCH_REQUEST, ErrGameInProgress)
+		return
+	}
+	rematch, ok := s.rematches[roomID]
+	if !ok {
+		s.gamesMu.Unlock()
+		s.sendControlError(roomID, userID, REMATCH_REQUEST, ErrNoFinishedGame)
+		return
+	}
+	if rematch.Owner != "" {
+		s.gamesMu.Unlock()
+		s.sendControlError(roomID, userID, REMATCH_REQUEST, ErrHostControlOff)
+		return
+	}
+	if rematch.HostID == "" || rematch.HostID != userID {
+		s.gamesMu.Unlock()
+		s.sendControlError(roomID, userID, REMATCH_REQUEST, ErrNotHostControl)
+		return
+	}
+
+	rematch.Requested = true
+	rematch.Ready = false
+	rematch.Accepted = map[string]bool{userID: true}
+	status := s.rematchStatus(roomID, rematch, map[string]interface{}{"requestedBy": userID})
+	players, ready := s.rematchReady(roomID, rematch)
+	s.gamesMu.Unlock()
+
+	log.Printf("Rematch requested in room %s", roomID)
+	s.broadcast(&types.Message{Type: "rematch_requested", Payload: status, RoomID: roomID})
+	if ready {
+		go s.resetRoomForRematch(roomID, players)
+	}
+}
+
+// acceptRematch はプレイヤーの再戦への参加を記録し、rematch_status を全員に送信します。
+// 接続中の全員が参加すると、ルームを待機状態に戻します。
+func (s *QuizService) acceptRematch(roomID, userID string) {
+	s.gamesMu.Lock()
+	rematch, ok := s.rematches[roomID]
+	if !ok || !rematch.Requested {
+		s.gamesMu.Unlock()
+		s.sendControlError(roomID, userID, REMATCH_ACCEPT, ErrNoRematch)
+		return
+	}
+	if rematch.Accepted[userID] {
+		s.gamesMu.Unlock()
+		return
+	}
+
+	rematch.Accepted[userID] = true
+	status := s.rematchStatus(roomID, rematch, map[string]interface{}{"acceptedBy": userID})
+	players, ready := s.rematchReady(roomID, rematch)
+	s.gamesMu.Unlock()
+
+	s.broadcast(&types.Message{Type: "rematch_status", Payload: status, RoomID: roomID})
+	if ready {
+		go s.resetRoomForRematch(roomID, players)
+	}
+}
+
+// rematchStatus は参加したプレイヤーと、まだ参加していない接続中のプレイヤーを payload に加えます。
+func (s *QuizService) rematchStatus(roomID string, rematch *types.Rematch, payload map[string]interface{}) map[string]interface{} {
+	pending := make([]string, 0)
+	for _, userID := range s.hub.GetClientIDs(roomID) {
+		if !rematch.Accepted[userID] {
+			pending = append(pending, userID)
+		}
+	}
+	sort.Strings(pending)
+	payload["accepted"] = acceptedPlayers(rematch)
+	payload["pending"] = pending
+	return payload
+}
+
+// acceptedPlayers は再戦に参加したプレイヤーを UserID 順に返します。
+func acceptedPlayers(rematch *types.Rematch) []string {
+	players := make([]string, 0, len(rematch.Accepted))
+	for userID := range rematch.Accepted {
+		players = append(players, userID)
+	}
+	sort.Strings(players)
+	return players
+}
+
+// rematchReady は接続中の全員が再戦に参加したかを返します。参加がそろった場合は Ready にし、参加者を返します。
+func (s *QuizService) rematchReady(roomID string, rematch *types.Rematch) ([]string, bool) {
+	if rematch.Ready {
+		return nil, false
+	}
+	for _, userID := range s.hub.GetClientIDs(roomID) {
+		if !rematch.Accepted[userID] {
+			return nil, false
+		}
+	}
+	rematch.Ready = true
+	rematch.Players = acceptedPlayers(rematch)
+	return rematch.Players, true
+}
+
+// setRoomState はルームの gameState を更新します。DynamoDB への書き込みを含むため、ハブのRunループからは呼び出しません。
+func (s *QuizService) setRoomState(roomID, gameState string) {
+	if s.hub.DBHandler == nil {
+		return
+	}
+	if err := s.hub.DBHandler.UpdateRoomGameState(roomID, gameState); err != nil {
+		log.Printf("warning: cannot set room %s to %s: %v", roomID, gameState, err)
+	}
+}
+
+// resetRoomForRematch はルームを待機状態に戻し、プレイヤーの顔ぶれと設定はそのままスコアを0に戻します。
+// 再戦に参加したプレイヤーは準備完了にします。ホストがゲームを開始すると、再戦が始まります。
+// DynamoDB への書き込みを含むため、ハブのRunループを止めないよう別の goroutine で呼び出します。
+func (s *QuizService) resetRoomForRematch(roomID string, players []string) {
+	accepted := make(map[string]bool, len(players))
+	for _, userID := range players {
+		accepted[userID] = true
+	}
+	if s.hub.DBHandler != nil {
+		room, err := s.hub.DBHandler.ReadDB(roomID)
+		if err != nil {
+			log.Printf("warning: cannot load room %s for rematch: %v", roomID, err)
+		} else {
+			room.GameState = ROOM_STATE_WAITING
+			for userID, player := range room.Players {
+				player.Score = 0
+				player.IsReady = userID == room.HostID || accepted[userID]
+				room.Players[userID] = player
+			}
+			if err := s.hub.DBHandler.WriteDB(room); err != nil {
+				log.Printf("warning: cannot reset room %s for rematch: %v", roomID, err)
+			}
+		}
+	}
+
+	log.Printf("Room %s is ready for a rematch with %d players", roomID, len(players))
+	s.broadcast(&types.Message{
+		Type: "rematch_ready",
+		Payload: map[string]interface{}{
+			"players": players,
+		},
+		RoomID: roomID,
+	})
+}
+
+// takeRematch はゲームの開始時に再戦の受付状態を削除し、参加がそろった再戦であれば返します。
+// 呼びかけただけで参加がそろっていない場合は通常のゲームとして開始するため、nil を返します。
+func (s *QuizService) takeRematch(roomID string) *types.Rematch {
+	s.gamesMu.Lock()
+	rematch, ok := s.rematches[roomID]
+	delete(s.rematches, roomID)
+	s.gamesMu.Unlock()
+	if !ok || !rematch.Ready {
+		return nil
+	}
+	return rematch
+}
+
+// rematchRoster はゲームに参加するプレイヤーを返します。
+// 再戦では参加したプレイヤーのうち接続中のプレイヤーのみとし、それ以外の接続中のプレイヤーは観戦になります。
+func (s *QuizService) rematchRoster(roomID string, rematch *types.Rematch) []string {
+	connected := s.hub.GetClientIDs(roomID)
+	if rematch == nil {
+		return connected
+	}
+	joined := make(map[string]bool, len(rematch.Players))
+	for _, userID := range rematch.Players {
+		joined[userID] = true
+	}
+	players := make([]string, 0, len(rematch.Players))
+	for _, userID := range connected {
+		if joined[userID] {
+			players = append(players, userID)
+		}
+	}
+	return players
+}
+
+// seatedPlayer はプレイヤーがこのゲームに参加しているかを返します。再戦では参加したプレイヤーのみ回答できます。
+func seatedPlayer(state *types.GameState, userID string) bool {
+	if !state.ClosedRoster {
+		return true
+	}
+	_, ok := state.PlayerStatuses[userID]
+	return ok
+}
+
+// excludeRematchQuestions は参加がそろった再戦の場合に、前のゲームで出題した問題を出題候補から除外します。
+// ルーム設定で重複を許可している場合や、除外すると出題できる問題がなくなる場合は除外しません。
+func excludeRematchQuestions(roomID string, settings types.GameSettings, rematch *types.Rematch, pool []*types.Question) []*types.Question {
+	if rematch == nil || settings.RepeatQuestions {
+		return pool
+	}
+	used := make(map[string]bool, len(rematch.UsedQuestionIDs))
+	for _, id := range rematch.UsedQuestionIDs {
+		used[id] = true
+	}
+	filtered := make([]*types.Question, 0, len(pool))
+	for _, q := range pool {
+		if !used[q.ID] {
+			filtered = append(filtered, q)
+		}
+	}
+	if len(filtered) == 0 {
+		log.Printf("Room %s has no unused questions for the rematch; repeating questions", roomID)
+		return pool
+	}
+	return filtered
+}
@@ -0,0 +1,238 @@
+package service
+
+import (
+	"fmt"
+	"server/src/internal/feature/quiz/types"
+	"sort"
+	"testing"
+	"time"
+)
+
+// finishedGame は alice がホストのゲームを終了させ、再戦を受け付けられる状態にします。
+func finishedGame(t *testing.T, s *QuizService) {
+	t.Helper()
+	state := hostState(time.Minute)
+	state.UsedQuestionIDs = []string{"q1", "q2"}
+	installGame(s, state)
+	s.endGame(TEST_ROOM_ID, state)
+	drainMessageTypes(s)
+}
+
+func TestRequestRematchRejects(t *testing.T) {
+	tests := []struct {
+		name     string
+		playing  bool
+		finished bool
+		owner    string
+		userID   string
+		want     error
+	}{
+		{name: "他の機能が管理するルームでは再戦できない", finished: true, owner: "daily", userID: "alice", want: ErrHostControlOff},
+		{name: "ゲーム中は再戦できない", playing: true, finished: true, userID: "alice", want: ErrGameInProgress},
+		{name: "終了したゲームがない", userID: "alice", want: ErrNoFinishedGame},
+		{name: "ホスト以外は呼びかけられない", finished: true, userID: "bob", want: ErrNotHostControl},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService()
+			if tt.finished {
+				finishedGame(t, s)
+				s.rematches[TEST_ROOM_ID].Owner = tt.owner
+			}
+			if tt.playing {
+				installGame(s, hostState(time.Minute))
+			}
+
+			s.requestRematch(TEST_ROOM_ID, tt.userID)
+
+			message := nextMessage(t, s)
+			payload := message.Payload.(map[string]interface{})
+			if message.Type != "control_error" || message.UserID != tt.userID || payload["message"] != tt.want.Error() {
+				t.Fatalf("message = %+v, want control_error %q to %s", message, tt.want, tt.userID)
+			}
+			assertNoMessage(t, s)
+		})
+	}
+}
+
+func TestRematchFlow(t *testing.T) {
+	s := newTestService()
+	joinPlayers(t, s, "alice", "bob", "carol")
+	finishedGame(t, s)
+
+	// 呼びかける前の参加は受け付けない
+	s.acceptRematch(TEST_ROOM_ID, "bob")
+	if message := nextMessage(t, s); message.Type != "control_error" || message.UserID != "bob" {
+		t.Fatalf("message = %+v, want control_error to bob", message)
+	}
+
+	s.requestRematch(TEST_ROOM_ID, "alice")
+	message := nextMessage(t, s)
+	payload := message.Payload.(map[string]interface{})
+	if message.Type != "rematch_requested" || fmt.Sprint(payload["accepted"]) != "[alice]" || fmt.Sprint(payload["pending"]) != "[bob carol]" {
+		t.Fatalf("message = %+v, want rematch_requested accepted [alice] pending [bob carol]", message)
+	}
+
+	s.acceptRematch(TEST_ROOM_ID, "bob")
+	message = nextMessage(t, s)
+	payload = message.Payload.(map[string]interface{})
+	if message.Type != "rematch_status" || fmt.Sprint(payload["pending"]) != "[carol]" {
+		t.Fatalf("message = %+v, want rematch_status pending [carol]", message)
+	}
+
+	// 同じプレイヤーの2回目の参加は無視する
+	s.acceptRematch(TEST_ROOM_ID, "bob")
+	assertNoMessage(t, s)
+
+	s.acceptRematch(TEST_ROOM_ID, "carol")
+	// ルームのリセットはハブのRunループを止めないよう別の goroutine で行う
+	for _, want := range []string{"rematch_status", "rematch_ready"} {
+		if message := nextMessage(t, s); message.Type != want {
+			t.Fatalf("message type = %q, want %q", message.Type, want)
+		}
+	}
+	s.gamesMu.Lock()
+	rematch := s.rematches[TEST_ROOM_ID]
+	s.gamesMu.Unlock()
+	if !rematch.Ready || fmt.Sprint(rematch.Players) != "[alice bob carol]" {
+		t.Errorf("rematch = %+v, want ready with players [alice bob carol]", rematch)
+	}
+}
+
+func TestTakeRematch(t *testing.T) {
+	tests := []struct {
+		name    string
+		rematch *types.Rematch
+		want    bool
+	}{
+		{name: "再戦でなければ通常のゲーム"},
+		{name: "呼びかけただけなら通常のゲーム", rematch: &types.Rematch{Requested: true}},
+		{name: "参加がそろえば再戦", rematch: &types.Rematch{Requested: true, Ready: true}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService()
+			if tt.rematch != nil {
+				s.rematches[TEST_ROOM_ID] = tt.rematch
+			}
+
+			got := s.takeRematch(TEST_ROOM_ID)
+
+			if (got != nil) != tt.want {
+				t.Errorf("takeRematch() = %+v, want rematch %v", got, tt.want)
+			}
+			// 再戦の受付状態はゲームの開始とともに削除する
+			if _, ok := s.rematches[TEST_ROOM_ID]; ok {
+				t.Error("rematch was not removed")
+			}
+		})
+	}
+}
+
+func TestRematchRoster(t *testing.T) {
+	tests := []struct {
+		name    string
+		rematch *types.Rematch
+		want    string
+	}{
+		{name: "通常のゲームは接続中の全員", want: "[alice bob carol]"},
+		{name: "再戦は参加した接続中のプレイヤーのみ", rematch: &types.Rematch{Players: []string{"alice", "carol", "dave"}}, want: "[alice carol]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService()
+			joinPlayers(t, s, "alice", "bob", "carol")
+
+			players := s.rematchRoster(TEST_ROOM_ID, tt.rematch)
+			sort.Strings(players)
+
+			if got := fmt.Sprint(players); got != tt.want {
+				t.Errorf("rematchRoster() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProcessAnswerClosedRoster(t *testing.T) {
+	tests := []struct {
+		name       string
+		closed     bool
+		userID     string
+		wantResult bool
+	}{
+		{name: "再戦に参加したプレイヤーは回答できる", closed: true, userID: "alice", wantResult: true},
+		{name: "再戦に参加しなかったプレイヤーは観戦のみ", closed: true, userID: "carol"},
+		{name: "通常のゲームは途中参加でも回答できる", userID: "carol", wantResult: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService()
+			state := activeState(1, time.Now().Add(time.Minute))
+			state.PlayerStatuses = map[string]types.PlayerStatus{"alice": types.PlayerActive, "bob": types.PlayerActive}
+			state.ClosedRoster = tt.closed
+			installGame(s, state)
+
+			s.processAnswer(TEST_ROOM_ID, tt.userID, state, map[string]interface{}{"answer": "2"})
+
+			message := nextMessage(t, s)
+			if tt.wantResult != (message.Type == "answer_result") {
+				t.Fatalf("message = %+v, want answer_result %v", message, tt.wantResult)
+			}
+			if !tt.wantResult && (message.Type != "answer_error" || message.UserID != tt.userID) {
+				t.Errorf("message = %+v, want answer_error to %s", message, tt.userID)
+			}
+		})
+	}
+}
+
+func TestExcludeRematchQuestions(t *testing.T) {
+	tests := []struct {
+		name     string
+		rematch  *types.Rematch
+		settings types.GameSettings
+		wantPool string
+	}{
+		{name: "再戦でなければそのまま", wantPool: "[q1 q2 q3]"},
+		{name: "前のゲームの問題を除外", rematch: &types.Rematch{Ready: true, UsedQuestionIDs: []string{"q1", "q3"}}, wantPool: "[q2]"},
+		{name: "重複を許可していれば除外しない", rematch: &types.Rematch{Ready: true, UsedQuestionIDs: []string{"q1"}}, settings: types.GameSettings{RepeatQuestions: true}, wantPool: "[q1 q2 q3]"},
+		{name: "すべて出題済みなら除外しない", rematch: &types.Rematch{Ready: true, UsedQuestionIDs: []string{"q1", "q2", "q3"}}, wantPool: "[q1 q2 q3]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			questions := testQuestions(3)
+			pool := excludeRematchQuestions(TEST_ROOM_ID, tt.settings, tt.rematch, buildQuestionPool(questions, tt.settings))
+			if got := fmt.Sprint(poolIDs(pool)); got != tt.wantPool {
+				t.Errorf("pool = %s, want %s", got, tt.wantPool)
+			}
+		})
+	}
+}
+
+func TestEndGameRecordsRematch(t *testing.T) {
+	s := newTestService()
+	finishedGame(t, s)
+
+	rematch, ok := s.rematches[TEST_ROOM_ID]
+	if !ok {
+		t.Fatal("no rematch recorded")
+	}
+	if rematch.HostID != "alice" || fmt.Sprint(rematch.UsedQuestionIDs) != "[q1 q2]" || rematch.Requested {
+		t.Errorf("rematch = %+v, want host alice with used [q1 q2]", rematch)
+	}
+}
+
+func TestEndGameAfterRoomClosed(t *testing.T) {
+	s := newTestService()
+	state := hostState(time.Minute)
+	// ゲームの最後の処理と同時にルームが削除された
+	installGame(s, state)
+	s.gamesMu.Lock()
+	delete(s.games, TEST_ROOM_ID)
+	s.gamesMu.Unlock()
+
+	s.endGame(TEST_ROOM_ID, state)
+
+	if _, ok := s.rematches[TEST_ROOM_ID]; ok {
+		t.Error("rematch was recorded for the deleted room")
+	}
+}
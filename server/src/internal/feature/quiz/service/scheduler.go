@@ -0,0 +1,148 @@
+// server/src/internal/feature/quiz/service/scheduler.go
+package service
+
+import (
+	"log"
+	"server/src/internal/feature/quiz/types"
+	"time"
+)
+
+// ゲームの進行はルームごとに次の段階を繰り返します。出題中の制限時間は QuestionTimer、
+// それ以外の段階（カウントダウン・正解発表・休憩）の時間は PhaseTimer で管理します。
+//
+//	countdown → question → reveal → (intermission) → question → … → reveal → game_over
+//
+// タイマーはゲームの goroutine の inbox に処理を積み、ゲームの終了・中断・ルームの削除で取り消します。
+// 取り消した後に発火したタイマーは PhaseSeq で判別して無視します。
+
+// schedulePhase は段階を phase に切り替え、d 後に次の段階へ進めるタイマーを設定します。以前のタイマーは取り消します。
+func (s *QuizService) schedulePhase(roomID string, state *types.GameState, phase types.GamePhase, d time.Duration) {
+	cancelPhase(state)
+	state.Phase = phase
+	state.PhaseEndsAt = time.Now().Add(d)
+	s.startPhaseTimer(roomID, state, d)
+}
+
+// startPhaseTimer は現在の段階を d 後に終えるタイマーを設定します。一時停止からの再開でも使用します。
+func (s *QuizService) startPhaseTimer(roomID string, state *types.GameState, d time.Duration) {
+	state.PhaseSeq++
+	seq := state.PhaseSeq
+	state.PhaseTimer = time.AfterFunc(d, func() {
+		s.dispatchTo(roomID, state, func() {
+			s.handlePhaseEnd(roomID, state, seq)
+		})
+	})
+}
+
+// cancelPhase は段階のタイマーを取り消します。
+func cancelPhase(state *types.GameState) {
+	state.PhaseSeq++
+	if state.PhaseTimer != nil {
+		state.PhaseTimer.Stop()
+		state.PhaseTimer = nil
+	}
+}
+
+// stopGameTimers は問題の制限時間と段階のタイマーをすべて取り消します。
+func stopGameTimers(state *types.GameState) {
+	stopQuestionTimer(state)
+	cancelPhase(state)
+}
+
+// handlePhaseEnd は段階のタイマーが発火したときに次の段階へ進めます。
+// タイマーが取り消された場合や、一時停止中の場合は何もしません。
+func (s *QuizService) handlePhaseEnd(roomID string, state *types.GameState, seq int) {
+	if state.PhaseSeq != seq || state.Paused {
+		return
+	}
+	state.PhaseTimer = nil
+	s.advancePhase(roomID, state)
+}
+
+// advancePhase は現在の段階を終えて次の段階へ進めます。
+// 正解発表の後は、休憩時間が設定されていて次の問題がある場合のみ休憩に入ります。
+func (s *QuizService) advancePhase(roomID string, state *types.GameState) {
+	cancelPhase(state)
+	if state.Phase == types.PhaseReveal && state.Settings.IntermissionTime > 0 && hasNextQuestion(state) {
+		s.startIntermission(roomID, state)
+		return
+	}
+	s.nextQuestion(roomID, state)
+}
+
+// hasNextQuestion はまだ出題する問題が残っているかを返します。
+func hasNextQuestion(state *types.GameState) bool {
+	return state.QuestionNumber < state.TotalQuestions && !survivorDecided(state)
+}
+
+// startCountdown は最初の問題の前のカウントダウンを開始し、game_countdown を送信します。
+func (s *QuizService) startCountdown(roomID string, state *types.GameState) {
+	s.schedulePhase(roomID, state, types.PhaseCountdown, state.Settings.CountdownTime)
+	s.broadcast(&types.Message{
+		Type: "game_countdown",
+		Payload: map[string]interface{}{
+			"seconds":        int(state.Settings.CountdownTime / time.Second),
+			"totalQuestions": state.TotalQuestions,
+			"startsAt":       state.PhaseEndsAt.UnixMilli(),
+			"serverTime":     time.Now().UnixMilli(),
+		},
+		RoomID: roomID,
+	})
+}
+
+// startReveal は正解発表の表示時間を開始します。
+func (s *QuizService) startReveal(roomID string, state *types.GameState) {
+	s.schedulePhase(roomID, state, types.PhaseReveal, state.Settings.RevealTime)
+}
+
+// startIntermission は次の問題までの休憩を開始し、途中経過の順位を leaderboard_interim で送信します。
+func (s *QuizService) startIntermission(roomID string, state *types.GameState) {
+	s.schedulePhase(roomID, state, types.PhaseIntermission, state.Settings.IntermissionTime)
+
+	results, teams := rankPlayers(state)
+	payload := map[string]interface{}{
+		"questionNumber": state.QuestionNumber,
+		"totalQuestions": state.TotalQuestions,
+		"ranking":        results,
+		"nextQuestionAt": state.PhaseEndsAt.UnixMilli(),
+		"serverTime":     time.Now().UnixMilli(),
+	}
+	if state.Settings.Mode == types.GameModeTeam {
+		payload["teams"] = teams
+	}
+	s.broadcast(&types.Message{
+		Type:    "leaderboard_interim",
+		Payload: payload,
+		RoomID:  roomID,
+	})
+}
+
+// CloseRoom は削除されたルームのゲームを終了します。タイマーはゲームの goroutine が終了時に取り消します。
+// ルームには誰も残っていないため結果は送信しませんが、デイリーチャレンジなどの挑戦が進行中のまま残らないよう、
+// 削除時点の順位を中断した結果としてゲーム終了の購読者に渡します。
+func (s *QuizService) CloseRoom(roomID string) {
+	if s.closeGame(roomID) {
+		log.Printf("Game in room %s stopped because the room was deleted", roomID)
+	}
+}
+
+// closeGame はルームのゲームと再戦の受付を削除し、ゲームの goroutine が終了するまで待ってから、
+// 終了時点の順位を中断した結果としてゲーム終了の購読者に渡します。ゲームがなかった場合は false を返します。
+func (s *QuizService) closeGame(roomID string) bool {
+	s.gamesMu.Lock()
+	g, ok := s.games[roomID]
+	delete(s.games, roomID)
+	delete(s.rematches, roomID)
+	s.gamesMu.Unlock()
+	if !ok {
+		return false
+	}
+
+	// 処理中のイベントが終わり、ゲームの goroutine が終了してからゲーム状態を読む
+	g.stop()
+	<-g.exited
+	state := g.state
+	results, teams := rankPlayers(state)
+	s.notifyGameOver(types.GameResult{RoomID: roomID, Seed: state.Seed, Results: results, History: state.History, Teams: teams, Aborted: true, Closed: true})
+	return true
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"fmt"
+	"server/src/internal/feature/quiz/types"
+	"testing"
+	"time"
+)
+
+// revealState は questionNumber 問目の正解発表中の状態を返します。
+func revealState(s *QuizService, questionNumber, totalQuestions int, intermission time.Duration) *types.GameState {
+	state := hostState(0)
+	state.IsQuestionActive = false
+	state.Phase = types.PhaseReveal
+	state.QuestionNumber = questionNumber
+	state.TotalQuestions = totalQuestions
+	state.Settings.IntermissionTime = intermission
+	for i := 1; i <= questionNumber; i++ {
+		state.UsedQuestionIDs = append(state.UsedQuestionIDs, fmt.Sprintf("q%d", i))
+	}
+	state.QuestionPool = buildQuestionPool(s.questions, state.Settings)
+	return state
+}
+
+func TestAdvancePhase(t *testing.T) {
+	tests := []struct {
+		name           string
+		phase          types.GamePhase
+		questionNumber int
+		intermission   time.Duration
+		want           []string
+		wantPhase      types.GamePhase
+	}{
+		{name: "カウントダウンの後は出題", phase: types.PhaseCountdown, questionNumber: 0, want: []string{"question_start"}, wantPhase: types.PhaseQuestion},
+		{name: "休憩なしなら正解発表の後すぐに出題", phase: types.PhaseReveal, questionNumber: 1, want: []string{"question_start"}, wantPhase: types.PhaseQuestion},
+		{name: "休憩ありなら途中経過を表示", phase: types.PhaseReveal, questionNumber: 1, intermission: time.Minute, want: []string{"leaderboard_interim"}, wantPhase: types.PhaseIntermission},
+		{name: "最後の問題の後は休憩せずに終了", phase: types.PhaseReveal, questionNumber: 2, intermission: time.Minute, want: []string{"game_summary", "game_over"}},
+		{name: "休憩の後は出題", phase: types.PhaseIntermission, questionNumber: 1, intermission: time.Minute, want: []string{"question_start"}, wantPhase: types.PhaseQuestion},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(testQuestions(2)...)
+			state := revealState(s, tt.questionNumber, 2, tt.intermission)
+			state.Phase = tt.phase
+			installGame(s, state)
+
+			s.advancePhase(TEST_ROOM_ID, state)
+			stopGameTimers(state)
+
+			if got := drainMessageTypes(s); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Fatalf("messages = %v, want %v", got, tt.want)
+			}
+			if tt.wantPhase != "" && state.Phase != tt.wantPhase {
+				t.Errorf("Phase = %q, want %q", state.Phase, tt.wantPhase)
+			}
+		})
+	}
+}
+
+func TestHandlePhaseEnd(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(s *QuizService, state *types.GameState) int // 発火したタイマーの番号を返す
+		want   []string
+	}{
+		{name: "現在の段階を終える", modify: func(s *QuizService, state *types.GameState) int { return state.PhaseSeq }, want: []string{"question_start"}},
+		{name: "取り消したタイマーは無視", modify: func(s *QuizService, state *types.GameState) int { return state.PhaseSeq - 1 }},
+		{name: "一時停止中は無視", modify: func(s *QuizService, state *types.GameState) int {
+			state.Paused = true
+			return state.PhaseSeq
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(testQuestions(2)...)
+			state := revealState(s, 1, 2, 0)
+			state.PhaseSeq = 5
+			installGame(s, state)
+
+			s.handlePhaseEnd(TEST_ROOM_ID, state, tt.modify(s, state))
+			stopGameTimers(state)
+
+			if got := drainMessageTypes(s); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Fatalf("messages = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchedulePhase(t *testing.T) {
+	s := newTestService(testQuestions(2)...)
+	state := revealState(s, 1, 2, 0)
+	g := runGame(t, s, state)
+
+	var first *time.Timer
+	inGame(t, g, func() {
+		s.schedulePhase(TEST_ROOM_ID, state, types.PhaseReveal, time.Hour)
+		first = state.PhaseTimer
+		// 段階を切り替えると以前のタイマーは取り消す
+		s.schedulePhase(TEST_ROOM_ID, state, types.PhaseReveal, 10*time.Millisecond)
+	})
+	if first.Stop() {
+		t.Error("previous phase timer was not stopped")
+	}
+
+	if message := nextMessage(t, s); message.Type != "question_start" {
+		t.Fatalf("message type = %q, want question_start", message.Type)
+	}
+	assertNoMessage(t, s)
+}
+
+func TestStartCountdown(t *testing.T) {
+	s := newTestService(testQuestions(1)...)
+	state := revealState(s, 0, 1, 0)
+	state.Settings.CountdownTime = 3 * time.Second
+	installGame(s, state)
+
+	s.startCountdown(TEST_ROOM_ID, state)
+	defer stopGameTimers(state)
+
+	message := nextMessage(t, s)
+	payload := message.Payload.(map[string]interface{})
+	if message.Type != "game_countdown" || payload["seconds"] != 3 || payload["startsAt"] != state.PhaseEndsAt.UnixMilli() {
+		t.Fatalf("message = %+v, want game_countdown for 3 seconds", message)
+	}
+	if state.Phase != types.PhaseCountdown || state.PhaseTimer == nil {
+		t.Errorf("Phase = %q, timer running = %v, want countdown with timer", state.Phase, state.PhaseTimer != nil)
+	}
+}
+
+func TestCloseRoom(t *testing.T) {
+	s := newTestService(testQuestions(2)...)
+	state := revealState(s, 1, 2, 0)
+	g := runGame(t, s, state)
+	s.rematches[TEST_ROOM_ID] = &types.Rematch{}
+	inGame(t, g, func() {
+		s.startQuestionTimer(TEST_ROOM_ID, state, 10*time.Millisecond)
+		s.schedulePhase(TEST_ROOM_ID, state, types.PhaseReveal, 10*time.Millisecond)
+	})
+
+	s.CloseRoom(TEST_ROOM_ID)
+
+	time.Sleep(50 * time.Millisecond)
+	// 結果を送信せず、取り消したタイマーも進行させない
+	assertNoMessage(t, s)
+	if _, ok := s.game(TEST_ROOM_ID); ok {
+		t.Error("game was not removed")
+	}
+	if g.send(func() {}) {
+		t.Error("game loop is still running")
+	}
+	if _, ok := s.rematches[TEST_ROOM_ID]; ok {
+		t.Error("rematch was not removed")
+	}
+}
+
+func TestCloseRoomReportsScores(t *testing.T) {
+	s := newTestService(testQuestions(2)...)
+	results := make(chan types.GameResult, 1)
+	s.OnGameOver(func(result types.GameResult) { results <- result })
+	// 1人で挑戦中のプレイヤーが出題中に切断し、ルームが削除された
+	state := hostState(time.Minute)
+	state.Settings.Owner = "daily"
+	state.Scores = map[string]int{"alice": 30}
+	state.History = []types.QuestionRecord{{QuestionNumber: 1}}
+	runGame(t, s, state)
+
+	s.CloseRoom(TEST_ROOM_ID)
+
+	select {
+	case result := <-results:
+		if !result.Aborted || !result.Closed || len(result.Results) != 1 || result.Results[0].Score != 30 || len(result.History) != 1 {
+			t.Errorf("result = %+v, want the closed game with alice's score so far", result)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("game over listener was not called")
+	}
+	assertNoMessage(t, s)
+}
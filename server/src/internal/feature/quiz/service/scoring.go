@@ -0,0 +1,127 @@
+// server/src/internal/feature/quiz/service/scoring.go
+package service
+
+import (
+	"log"
+	"math"
+	"server/src/internal/feature/quiz/types"
+	"strings"
+)
+
+const (
+	FLAT_POINTS          = 10 // 正解時の基本点
+	MAX_SPEED_BONUS      = 10 // 即答した場合のスピードボーナス上限
+	WRONG_ANSWER_PENALTY = 5  // 不正解時の減点
+	MAX_STREAK_STEPS     = 4  // 連続正解ボーナスの上限段階（最大 x3.0）
+)
+
+// 得点ルール名（ルーム設定の scoring で指定）
+const (
+	SCORING_FLAT       = "flat"
+	SCORING_TIME_DECAY = "time_decay"
+	SCORING_NEGATIVE   = "negative"
+	SCORING_STREAK     = "streak"
+)
+
+// flatScoring は正解に一律の点数を与えます。部分点の場合は割合に応じて減らします。
+type flatScoring struct {
+	points int
+}
+
+func (f flatScoring) Score(input types.ScoringInput) types.ScoreBreakdown {
+	base := creditPoints(f.points, input)
+	return types.ScoreBreakdown{Base: base, Total: base}
+}
+
+// timeDecayScoring は基本点に加え、締切までの残り時間に比例したボーナスを与えます。
+// 残り時間はサーバー側のタイマーを基準に計算します。
+type timeDecayScoring struct {
+	base     int
+	maxBonus int
+}
+
+func (t timeDecayScoring) Score(input types.ScoringInput) types.ScoreBreakdown {
+	base := creditPoints(t.base, input)
+	if base == 0 {
+		return types.ScoreBreakdown{}
+	}
+	bonus := 0
+	limit := input.QuestionDeadline.Sub(input.QuestionStartedAt)
+	remaining := input.QuestionDeadline.Sub(input.AnsweredAt)
+	if limit > 0 && remaining > 0 {
+		bonus = creditPoints(int(int64(t.maxBonus)*int64(remaining)/int64(limit)), input)
+	}
+	return types.ScoreBreakdown{Base: base, SpeedBonus: bonus, Total: base + bonus}
+}
+
+// creditPoints は部分点の割合に応じて点数を計算します。完全正解の場合は points をそのまま返します。
+func creditPoints(points int, input types.ScoringInput) int {
+	if input.IsCorrect {
+		return points
+	}
+	return int(math.Round(float64(points) * input.Credit))
+}
+
+// negativeMarking は不正解の回答を減点します。正解・部分点の計算は inner に委譲します。
+type negativeMarking struct {
+	inner   types.ScoringStrategy
+	penalty int
+}
+
+func (n negativeMarking) Score(input types.ScoringInput) types.ScoreBreakdown {
+	// 部分点が付く回答は減点しない
+	if input.IsCorrect || input.Credit > 0 {
+		return n.inner.Score(input)
+	}
+	return types.ScoreBreakdown{Penalty: -n.penalty, Total: -n.penalty}
+}
+
+// streakMultiplier は連続正解数に応じて得点を倍増させます。
+// 2連続で x1.5、以降 0.5 ずつ増え、MAX_STREAK_STEPS 段階で頭打ちになります。
+type streakMultiplier struct {
+	inner types.ScoringStrategy
+}
+
+func (m streakMultiplier) Score(input types.ScoringInput) types.ScoreBreakdown {
+	breakdown := m.inner.Score(input)
+	if !input.IsCorrect || input.Streak < 2 {
+		return breakdown
+	}
+	steps := input.Streak - 1
+	if steps > MAX_STREAK_STEPS {
+		steps = MAX_STREAK_STEPS
+	}
+	breakdown.StreakBonus = (breakdown.Base + breakdown.SpeedBonus) * steps / 2
+	breakdown.Total += breakdown.StreakBonus
+	return breakdown
+}
+
+// newScoringStrategy はルーム設定の得点ルール一覧から得点計算ルールを組み立てます。
+// 基本点は flat または time_decay、そこに negative と streak を重ねて適用できます。
+// 未指定の場合は従来どおり正解で10点です。
+func newScoringStrategy(rules []string) types.ScoringStrategy {
+	var strategy types.ScoringStrategy = flatScoring{points: FLAT_POINTS}
+	useNegative, useStreak := false, false
+
+	for _, rule := range rules {
+		switch strings.ToLower(strings.TrimSpace(rule)) {
+		case SCORING_FLAT, "":
+		case SCORING_TIME_DECAY:
+			strategy = timeDecayScoring{base: FLAT_POINTS, maxBonus: MAX_SPEED_BONUS}
+		case SCORING_NEGATIVE:
+			useNegative = true
+		case SCORING_STREAK:
+			useStreak = true
+		default:
+			log.Printf("warning: unknown scoring rule %q is ignored", rule)
+		}
+	}
+
+	if useStreak {
+		strategy = streakMultiplier{inner: strategy}
+	}
+	if useNegative {
+		strategy = negativeMarking{inner: strategy, penalty: WRONG_ANSWER_PENALTY}
+	}
+	return strategy
+}
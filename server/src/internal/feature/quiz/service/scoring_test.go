@@ -0,0 +1,148 @@
+package service
+
+import (
+	"server/src/internal/feature/quiz/types"
+	"testing"
+	"time"
+)
+
+// answeredAfter は制限時間10秒の問題に elapsed 経過後に回答した入力を作成します。
+func answeredAfter(elapsed time.Duration, isCorrect bool, streak int) types.ScoringInput {
+	startedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	return types.ScoringInput{
+		IsCorrect:         isCorrect,
+		AnsweredAt:        startedAt.Add(elapsed),
+		QuestionStartedAt: startedAt,
+		QuestionDeadline:  startedAt.Add(10 * time.Second),
+		Streak:            streak,
+	}
+}
+
+// partialAfter は部分点の回答を作成します。
+func partialAfter(elapsed time.Duration, credit float64) types.ScoringInput {
+	input := answeredAfter(elapsed, false, 0)
+	input.Credit = credit
+	return input
+}
+
+func TestNewScoringStrategy(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules []string
+		input types.ScoringInput
+		want  types.ScoreBreakdown
+	}{
+		{
+			name:  "未指定は正解で10点",
+			input: answeredAfter(time.Second, true, 1),
+			want:  types.ScoreBreakdown{Base: 10, Total: 10},
+		},
+		{
+			name:  "未指定は不正解で0点",
+			input: answeredAfter(time.Second, false, 0),
+			want:  types.ScoreBreakdown{},
+		},
+		{
+			name:  "time_decay: 残り時間に比例したボーナス",
+			rules: []string{"time_decay"},
+			input: answeredAfter(3*time.Second, true, 1),
+			want:  types.ScoreBreakdown{Base: 10, SpeedBonus: 7, Total: 17},
+		},
+		{
+			name:  "time_decay: 締切後はボーナスなし",
+			rules: []string{"time_decay"},
+			input: answeredAfter(11*time.Second, true, 1),
+			want:  types.ScoreBreakdown{Base: 10, Total: 10},
+		},
+		{
+			name:  "negative: 不正解で減点",
+			rules: []string{"negative"},
+			input: answeredAfter(time.Second, false, 0),
+			want:  types.ScoreBreakdown{Penalty: -5, Total: -5},
+		},
+		{
+			name:  "negative: 正解は基本点",
+			rules: []string{"negative"},
+			input: answeredAfter(time.Second, true, 1),
+			want:  types.ScoreBreakdown{Base: 10, Total: 10},
+		},
+		{
+			name:  "streak: 1問目はボーナスなし",
+			rules: []string{"streak"},
+			input: answeredAfter(time.Second, true, 1),
+			want:  types.ScoreBreakdown{Base: 10, Total: 10},
+		},
+		{
+			name:  "streak: 2連続で x1.5",
+			rules: []string{"streak"},
+			input: answeredAfter(time.Second, true, 2),
+			want:  types.ScoreBreakdown{Base: 10, StreakBonus: 5, Total: 15},
+		},
+		{
+			name:  "streak: 5連続で上限の x3",
+			rules: []string{"streak"},
+			input: answeredAfter(time.Second, true, 5),
+			want:  types.ScoreBreakdown{Base: 10, StreakBonus: 20, Total: 30},
+		},
+		{
+			name:  "streak: 上限を超えても x3 のまま",
+			rules: []string{"streak"},
+			input: answeredAfter(time.Second, true, 12),
+			want:  types.ScoreBreakdown{Base: 10, StreakBonus: 20, Total: 30},
+		},
+		{
+			name:  "streak と time_decay はスピードボーナスにも倍率がかかる",
+			rules: []string{"time_decay", "streak"},
+			input: answeredAfter(5*time.Second, true, 3),
+			want:  types.ScoreBreakdown{Base: 10, SpeedBonus: 5, StreakBonus: 15, Total: 30},
+		},
+		{
+			name:  "全ルールの組み合わせで不正解は減点のみ",
+			rules: []string{"time_decay", "streak", "negative"},
+			input: answeredAfter(time.Second, false, 0),
+			want:  types.ScoreBreakdown{Penalty: -5, Total: -5},
+		},
+		{
+			name:  "部分点: 基本点を割合で減らす",
+			input: partialAfter(time.Second, 0.5),
+			want:  types.ScoreBreakdown{Base: 5, Total: 5},
+		},
+		{
+			name:  "部分点: 端数は四捨五入",
+			input: partialAfter(time.Second, 2.0/3),
+			want:  types.ScoreBreakdown{Base: 7, Total: 7},
+		},
+		{
+			name:  "部分点: time_decay はボーナスも割合で減らす",
+			rules: []string{"time_decay"},
+			input: partialAfter(0, 0.5),
+			want:  types.ScoreBreakdown{Base: 5, SpeedBonus: 5, Total: 10},
+		},
+		{
+			name:  "部分点: negative でも減点しない",
+			rules: []string{"negative"},
+			input: partialAfter(time.Second, 0.25),
+			want:  types.ScoreBreakdown{Base: 3, Total: 3},
+		},
+		{
+			name:  "部分点: streak の倍率はかからない",
+			rules: []string{"streak"},
+			input: partialAfter(time.Second, 0.5),
+			want:  types.ScoreBreakdown{Base: 5, Total: 5},
+		},
+		{
+			name:  "大文字と空白は正規化し、不明なルールは無視する",
+			rules: []string{" Negative ", "bogus", ""},
+			input: answeredAfter(time.Second, false, 0),
+			want:  types.ScoreBreakdown{Penalty: -5, Total: -5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newScoringStrategy(tt.rules).Score(tt.input)
+			if got != tt.want {
+				t.Errorf("Score() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+// server/src/internal/feature/quiz/service/seed.go
+package service
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"strconv"
+)
+
+// MAX_GAME_SEED は自動で決めるシードの上限です。JavaScript の数値で誤差なく扱える範囲（2^53 - 1）にします。
+const MAX_GAME_SEED = 1<<53 - 1
+
+// newGameSeed はゲームのシードを返します。ルーム設定で指定されていない場合はランダムに決めます。
+func newGameSeed(seed int64) int64 {
+	if seed != 0 {
+		return seed
+	}
+	return rand.Int63n(MAX_GAME_SEED) + 1
+}
+
+// playerRand はプレイヤーごとの選択肢の並び替えに使用する乱数を返します。
+// ゲームのシード・問題番号・ユーザーIDから決まるため、接続中のプレイヤーの顔ぶれや順番に影響されずに再現できます。
+func playerRand(seed int64, questionNumber int, userID string) *rand.Rand {
+	h := fnv.New64a()
+	h.Write([]byte(strconv.FormatInt(seed, 10)))
+	h.Write([]byte{0})
+	h.Write([]byte(strconv.Itoa(questionNumber)))
+	h.Write([]byte{0})
+	h.Write([]byte(userID))
+	return rand.New(rand.NewSource(int64(h.Sum64())))
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"fmt"
+	"math/rand"
+	"server/src/internal/feature/quiz/types"
+	"testing"
+)
+
+func TestNewGameSeed(t *testing.T) {
+	tests := []struct {
+		name string
+		seed int64
+		want int64 // 0 の場合はランダムに決まる
+	}{
+		{name: "指定したシードを使う", seed: 42, want: 42},
+		{name: "負のシードもそのまま使う", seed: -7, want: -7},
+		{name: "未指定ならランダム", seed: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newGameSeed(tt.seed)
+			if tt.want != 0 && got != tt.want {
+				t.Errorf("newGameSeed(%d) = %d, want %d", tt.seed, got, tt.want)
+			}
+			if tt.want == 0 && (got < 1 || got > MAX_GAME_SEED) {
+				t.Errorf("newGameSeed(0) = %d, want 1..%d", got, MAX_GAME_SEED)
+			}
+		})
+	}
+}
+
+func TestPlayerRand(t *testing.T) {
+	sample := func(seed int64, questionNumber int, userID string) int64 {
+		return playerRand(seed, questionNumber, userID).Int63()
+	}
+	base := sample(1, 1, "alice")
+	tests := []struct {
+		name           string
+		seed           int64
+		questionNumber int
+		userID         string
+		wantSame       bool
+	}{
+		{name: "同じ入力なら同じ乱数", seed: 1, questionNumber: 1, userID: "alice", wantSame: true},
+		{name: "プレイヤーが違えば異なる", seed: 1, questionNumber: 1, userID: "bob"},
+		{name: "問題番号が違えば異なる", seed: 1, questionNumber: 2, userID: "alice"},
+		{name: "シードが違えば異なる", seed: 2, questionNumber: 1, userID: "alice"},
+		{name: "区切りがあるため連結して同じ文字列でも異なる", seed: 11, questionNumber: 1, userID: "alice"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if same := sample(tt.seed, tt.questionNumber, tt.userID) == base; same != tt.wantSame {
+				t.Errorf("same = %v, want %v", same, tt.wantSame)
+			}
+		})
+	}
+}
+
+// TestSeedReproducesGame は同じシードのゲームで出題順と選択肢の順番が一致することを確認します。
+func TestSeedReproducesGame(t *testing.T) {
+	play := func(seed int64) string {
+		s := newTestService(testQuestions(8)...)
+		state := &types.GameState{
+			QuestionPool: buildQuestionPool(s.questions, types.GameSettings{}),
+			Seed:         seed,
+			Rand:         rand.New(rand.NewSource(seed)),
+		}
+		var log []string
+		for question := s.getNextUniqueQuestion(state); question != nil; question = s.getNextUniqueQuestion(state) {
+			state.UsedQuestionIDs = append(state.UsedQuestionIDs, question.ID)
+			choices := shuffleChoices(state.Rand, []types.Choice{{Text: "a"}, {Text: "b"}, {Text: "c"}, {Text: "d"}})
+			log = append(log, fmt.Sprint(question.ID, choices))
+		}
+		return fmt.Sprint(log)
+	}
+	tests := []struct {
+		name     string
+		a, b     int64
+		wantSame bool
+	}{
+		{name: "同じシード", a: 123, b: 123, wantSame: true},
+		{name: "異なるシード", a: 123, b: 456, wantSame: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if same := play(tt.a) == play(tt.b); same != tt.wantSame {
+				t.Errorf("same = %v, want %v", same, tt.wantSame)
+			}
+		})
+	}
+}
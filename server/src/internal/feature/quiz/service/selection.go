@@ -0,0 +1,208 @@
+// server/src/internal/feature/quiz/service/selection.go
+package service
+
+import (
+	"log"
+	"server/src/internal/feature/quiz/types"
+	"strings"
+)
+
+// LANGUAGE_RANDOM はクライアントの言語選択肢「Random」を表し、全言語から出題します。
+const LANGUAGE_RANDOM = "Random"
+
+// difficultyLevels は難易度を比較可能な段階に変換します。
+// クライアントのモックデータで使われている medium も Normal として扱います。
+var difficultyLevels = map[string]int{
+	"easy":   0,
+	"normal": 1,
+	"medium": 1,
+	"hard":   2,
+}
+
+// buildQuestionPool はルーム設定の言語と難易度に合う問題を出題候補として抽出します。
+//
+// 言語は厳密に絞り込みますが、その言語の問題が1問もない場合のみ全言語を候補にします。
+// 難易度も同様に絞り込み、その難易度の問題が1問もない場合のみ全難易度を候補にします（出題時は設定に近い難易度を優先します）。
+// 出題数は絞り込んだ候補の数で頭打ちにするため、指定した難易度以外の問題で埋め合わせることはありません。
+// adaptive は正答状況に合わせて難易度を上下させるため、難易度では絞り込みません（ルームの難易度は最初の目標にのみ使用します）。
+func buildQuestionPool(questions []types.Question, settings types.GameSettings) []*types.Question {
+	pool := make([]*types.Question, 0, len(questions))
+	for i := range questions {
+		if matchesLanguage(&questions[i], settings.Language) {
+			pool = append(pool, &questions[i])
+		}
+	}
+
+	if len(pool) == 0 && len(questions) > 0 {
+		log.Printf("warning: no questions for language %q, falling back to all languages", settings.Language)
+		for i := range questions {
+			pool = append(pool, &questions[i])
+		}
+	}
+
+	if _, adaptive := settings.Selection.(adaptiveSelection); adaptive {
+		return pool
+	}
+	return filterDifficulty(pool, settings.Difficulty)
+}
+
+// filterDifficulty は出題候補をルームの難易度設定に一致する問題に絞り込みます。
+// 設定が未指定・不明な場合や、一致する問題が1問もない場合は絞り込みません。
+func filterDifficulty(pool []*types.Question, difficulty string) []*types.Question {
+	want, ok := difficultyLevels[strings.ToLower(difficulty)]
+	if !ok {
+		return pool
+	}
+	matched := make([]*types.Question, 0, len(pool))
+	for _, q := range pool {
+		if got, known := difficultyLevels[strings.ToLower(q.Difficulty)]; known && got == want {
+			matched = append(matched, q)
+		}
+	}
+	if len(matched) == 0 && len(pool) > 0 {
+		log.Printf("warning: no questions for difficulty %q, falling back to the closest difficulties", difficulty)
+		return pool
+	}
+	return matched
+}
+
+// matchesLanguage は問題がルームの言語設定に合うかを返します。
+func matchesLanguage(question *types.Question, language string) bool {
+	if language == "" || strings.EqualFold(language, LANGUAGE_RANDOM) {
+		return true
+	}
+	return strings.EqualFold(question.Language, language)
+}
+
+// difficultyDistance はルームの難易度設定と問題の難易度の差を返します。
+// 設定が未指定の場合は常に0、問題側の難易度が不明な場合は1段階差として扱います。
+func difficultyDistance(question *types.Question, difficulty string) int {
+	want, ok := difficultyLevels[strings.ToLower(difficulty)]
+	if !ok {
+		return 0
+	}
+	got, ok := difficultyLevels[strings.ToLower(question.Difficulty)]
+	if !ok {
+		return 1
+	}
+	if got > want {
+		return got - want
+	}
+	return want - got
+}
+
+const (
+	SELECTION_RANDOM   = "random"   // ルームの難易度設定に近い問題からランダムに選ぶ
+	SELECTION_ORDERED  = "ordered"  // 問題バンクの順番どおりに出題する
+	SELECTION_ADAPTIVE = "adaptive" // ルームの正答状況に合わせて難易度を上下させる
+
+	// ADAPTIVE_STEP_UP_STREAK は難易度を1段階上げるまでに必要な、連続で正解された問題の数です。
+	ADAPTIVE_STEP_UP_STREAK = 2
+)
+
+// newSelectionStrategy はルーム設定の値から出題の選び方を決めます。未指定または不明な値は random です。
+func newSelectionStrategy(name string) types.SelectionStrategy {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case SELECTION_RANDOM, "":
+		return randomSelection{}
+	case SELECTION_ORDERED:
+		return orderedSelection{}
+	case SELECTION_ADAPTIVE:
+		return adaptiveSelection{}
+	default:
+		log.Printf("warning: unknown selection strategy %q, using %s", name, SELECTION_RANDOM)
+		return randomSelection{}
+	}
+}
+
+// randomSelection はルームの難易度設定に最も近い問題を優先し、同じ近さの問題の中からゲームの乱数で選びます。
+type randomSelection struct{}
+
+func (randomSelection) Select(state *types.GameState, available []*types.Question) *types.Question {
+	return pickClosest(state, available, func(q *types.Question) int {
+		return difficultyDistance(q, state.Settings.Difficulty)
+	})
+}
+
+// orderedSelection は出題候補の順番（問題バンクの順番）どおりに出題します。
+type orderedSelection struct{}
+
+func (orderedSelection) Select(state *types.GameState, available []*types.Question) *types.Question {
+	return available[0]
+}
+
+// adaptiveSelection はルームの直近の正答状況から目標の難易度評価を決め、それに最も近い問題を選びます。
+// 正解された問題が続くと難易度を上げ、正解されなかった問題があるとすぐに難易度を下げます。
+type adaptiveSelection struct{}
+
+func (adaptiveSelection) Select(state *types.GameState, available []*types.Question) *types.Question {
+	target := adaptiveTargetRating(state)
+	return pickClosest(state, available, func(q *types.Question) int {
+		distance := q.DifficultyRating() - target
+		if distance < 0 {
+			return -distance
+		}
+		return distance
+	})
+}
+
+// adaptiveTargetRating は締め切った問題の記録を順にたどり、次の問題の目標の難易度評価を求めます。
+// 開始時はルームの難易度設定（未指定の場合は Normal）の評価から始めます。
+func adaptiveTargetRating(state *types.GameState) int {
+	target := types.RatingForDifficulty(state.Settings.Difficulty)
+	streak := 0
+	for _, record := range state.History {
+		if len(record.Results) == 0 {
+			continue
+		}
+		if roomSolved(record, state.Settings.AnswerMode) {
+			streak++
+			if streak >= ADAPTIVE_STEP_UP_STREAK {
+				target++
+				streak = 0
+			}
+		} else {
+			target--
+			streak = 0
+		}
+		if target < types.MIN_RATING {
+			target = types.MIN_RATING
+		}
+		if target > types.MAX_RATING {
+			target = types.MAX_RATING
+		}
+	}
+	return target
+}
+
+// roomSolved はルームとしてその問題に正解できたかを返します。
+// first_correct では正解者が出た時点で締め切るため1人でも正解すれば、everyone では半数以上が正解すれば正解とみなします。
+func roomSolved(record types.QuestionRecord, mode types.AnswerMode) bool {
+	correct := 0
+	for _, result := range record.Results {
+		if result.IsCorrect {
+			correct++
+		}
+	}
+	if mode == types.AnswerModeEveryone {
+		return correct*2 >= len(record.Results)
+	}
+	return correct > 0
+}
+
+// pickClosest は distance が最も小さい問題の中から、ゲームの乱数で1問を選びます（同じシードなら同じ順番になる）。
+func pickClosest(state *types.GameState, available []*types.Question, distance func(*types.Question) int) *types.Question {
+	closest := make([]*types.Question, 0, len(available))
+	best := -1
+	for _, q := range available {
+		d := distance(q)
+		switch {
+		case best == -1 || d < best:
+			best = d
+			closest = append(closest[:0], q)
+		case d == best:
+			closest = append(closest, q)
+		}
+	}
+	return closest[state.Rand.Intn(len(closest))]
+}
@@ -0,0 +1,274 @@
+package service
+
+import (
+	"fmt"
+	"math/rand"
+	"server/src/internal/feature/quiz/types"
+	"sort"
+	"testing"
+)
+
+// selectionQuestions は言語と難易度の組み合わせを網羅した問題を返します。
+func selectionQuestions() []types.Question {
+	return []types.Question{
+		{ID: "go-easy", Language: "Go", Difficulty: "Easy"},
+		{ID: "go-hard", Language: "Go", Difficulty: "Hard"},
+		{ID: "py-normal", Language: "Python", Difficulty: "Normal"},
+		{ID: "py-medium", Language: "python", Difficulty: "medium"},
+		{ID: "c-unknown", Language: "C"},
+	}
+}
+
+// poolIDs は出題候補の ID を昇順で返します。
+func poolIDs(pool []*types.Question) []string {
+	ids := make([]string, 0, len(pool))
+	for _, question := range pool {
+		ids = append(ids, question.ID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestBuildQuestionPool(t *testing.T) {
+	tests := []struct {
+		name       string
+		language   string
+		difficulty string
+		selection  types.SelectionStrategy
+		want       []string
+	}{
+		{name: "未指定は全言語", language: "", want: []string{"c-unknown", "go-easy", "go-hard", "py-medium", "py-normal"}},
+		{name: "Random は全言語", language: "random", want: []string{"c-unknown", "go-easy", "go-hard", "py-medium", "py-normal"}},
+		{name: "言語は大文字小文字を区別しない", language: "PYTHON", want: []string{"py-medium", "py-normal"}},
+		{name: "該当言語がなければ全言語にフォールバック", language: "Rust", want: []string{"c-unknown", "go-easy", "go-hard", "py-medium", "py-normal"}},
+		{name: "難易度で絞り込む", difficulty: "hard", want: []string{"go-hard"}},
+		{name: "medium は Normal として絞り込む", difficulty: "Normal", want: []string{"py-medium", "py-normal"}},
+		{name: "言語と難易度の両方で絞り込む", language: "Go", difficulty: "Easy", want: []string{"go-easy"}},
+		{name: "該当難易度がなければ全難易度にフォールバック", language: "Python", difficulty: "Hard", want: []string{"py-medium", "py-normal"}},
+		{name: "不明な難易度は絞り込まない", language: "Go", difficulty: "expert", want: []string{"go-easy", "go-hard"}},
+		{name: "adaptive は難易度で絞り込まない", language: "Go", difficulty: "Easy", selection: adaptiveSelection{}, want: []string{"go-easy", "go-hard"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := types.GameSettings{Language: tt.language, Difficulty: tt.difficulty, Selection: tt.selection}
+			pool := buildQuestionPool(selectionQuestions(), settings)
+			if got := poolIDs(pool); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("pool = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTotalQuestionCount(t *testing.T) {
+	tests := []struct {
+		name       string
+		difficulty string
+		mode       types.GameMode
+		count      int
+		want       int
+	}{
+		{name: "候補が足りていれば設定どおり", count: 3, want: 3},
+		{name: "全体の候補数で頭打ち", count: 10, want: 5},
+		{name: "難易度で絞り込んだ候補数で頭打ち", difficulty: "Hard", count: 10, want: 1},
+		{name: "脱落モードは候補がなくなるまで", difficulty: "Normal", mode: types.GameModeElimination, count: 1, want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := types.GameSettings{Difficulty: tt.difficulty, Mode: tt.mode, QuestionCount: tt.count}
+			pool := buildQuestionPool(selectionQuestions(), settings)
+			if got := totalQuestionCount(settings, len(pool)); got != tt.want {
+				t.Errorf("totalQuestionCount() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDifficultyDistance(t *testing.T) {
+	tests := []struct {
+		question   string
+		difficulty string
+		want       int
+	}{
+		{question: "Easy", difficulty: "", want: 0},
+		{question: "Easy", difficulty: "Easy", want: 0},
+		{question: "Easy", difficulty: "Hard", want: 2},
+		{question: "Hard", difficulty: "Normal", want: 1},
+		{question: "medium", difficulty: "Normal", want: 0},
+		{question: "", difficulty: "Hard", want: 1},
+		{question: "Hard", difficulty: "bogus", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.question+"/"+tt.difficulty, func(t *testing.T) {
+			question := &types.Question{Difficulty: tt.question}
+			if got := difficultyDistance(question, tt.difficulty); got != tt.want {
+				t.Errorf("difficultyDistance() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetNextUniqueQuestionPrefersDifficulty(t *testing.T) {
+	tests := []struct {
+		name       string
+		difficulty string
+		used       []string
+		want       []string
+	}{
+		{name: "設定と同じ難易度を優先", difficulty: "Hard", want: []string{"go-hard"}},
+		{name: "出題済みなら次に近い難易度", difficulty: "Hard", used: []string{"go-hard"}, want: []string{"c-unknown", "py-medium", "py-normal"}},
+		{name: "候補を使い切ったら nil", difficulty: "Easy", used: []string{"go-easy", "go-hard", "py-normal", "py-medium", "c-unknown"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(selectionQuestions()...)
+			state := &types.GameState{
+				Settings:        types.GameSettings{Difficulty: tt.difficulty},
+				UsedQuestionIDs: tt.used,
+				QuestionPool:    buildQuestionPool(s.questions, types.GameSettings{}),
+				Rand:            rand.New(rand.NewSource(1)),
+			}
+			// ランダム選択のため、何度選んでも候補の範囲に収まることを確認する
+			seen := make(map[string]bool)
+			for i := 0; i < 50; i++ {
+				question := s.getNextUniqueQuestion(state)
+				if question == nil {
+					if tt.want != nil {
+						t.Fatal("getNextUniqueQuestion() = nil")
+					}
+					return
+				}
+				seen[question.ID] = true
+			}
+			got := make([]string, 0, len(seen))
+			for id := range seen {
+				got = append(got, id)
+			}
+			sort.Strings(got)
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("selected = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewSelectionStrategy(t *testing.T) {
+	tests := []struct {
+		name string
+		want types.SelectionStrategy
+	}{
+		{name: "", want: randomSelection{}},
+		{name: "random", want: randomSelection{}},
+		{name: " Ordered ", want: orderedSelection{}},
+		{name: "ADAPTIVE", want: adaptiveSelection{}},
+		{name: "unknown", want: randomSelection{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newSelectionStrategy(tt.name); got != tt.want {
+				t.Errorf("newSelectionStrategy(%q) = %T, want %T", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrderedSelection(t *testing.T) {
+	s := newTestService(testQuestions(4)...)
+	state := &types.GameState{
+		Settings:        types.GameSettings{Selection: orderedSelection{}},
+		UsedQuestionIDs: []string{"q1", "q3"},
+		QuestionPool:    buildQuestionPool(s.questions, types.GameSettings{}),
+		Rand:            rand.New(rand.NewSource(1)),
+	}
+	var got []string
+	for question := s.getNextUniqueQuestion(state); question != nil; question = s.getNextUniqueQuestion(state) {
+		got = append(got, question.ID)
+		state.UsedQuestionIDs = append(state.UsedQuestionIDs, question.ID)
+	}
+	if want := []string{"q2", "q4"}; fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+}
+
+// record は正解者数 correct、回答者数 total の問題の記録を返します。
+func record(correct, total int) types.QuestionRecord {
+	results := make([]types.PlayerAnswer, total)
+	for i := 0; i < correct; i++ {
+		results[i].IsCorrect = true
+	}
+	return types.QuestionRecord{Results: results}
+}
+
+func TestRoomSolved(t *testing.T) {
+	tests := []struct {
+		name   string
+		record types.QuestionRecord
+		mode   types.AnswerMode
+		want   bool
+	}{
+		{name: "first_correct は1人正解で正解", record: record(1, 4), mode: types.AnswerModeFirstCorrect, want: true},
+		{name: "first_correct で正解者なし", record: record(0, 4), mode: types.AnswerModeFirstCorrect, want: false},
+		{name: "everyone は半数の正解で正解", record: record(2, 4), mode: types.AnswerModeEveryone, want: true},
+		{name: "everyone で半数未満", record: record(1, 4), mode: types.AnswerModeEveryone, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := roomSolved(tt.record, tt.mode); got != tt.want {
+				t.Errorf("roomSolved() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdaptiveTargetRating(t *testing.T) {
+	solved, missed := record(1, 1), record(0, 1)
+	tests := []struct {
+		name       string
+		difficulty string
+		history    []types.QuestionRecord
+		want       int
+	}{
+		{name: "開始時はルームの難易度", difficulty: "Hard", want: 8},
+		{name: "難易度未指定は Normal から", want: 5},
+		{name: "1問正解ではまだ上げない", history: []types.QuestionRecord{solved}, want: 5},
+		{name: "2問連続正解で1段階上げる", history: []types.QuestionRecord{solved, solved}, want: 6},
+		{name: "不正解ですぐに下げる", history: []types.QuestionRecord{missed}, want: 4},
+		{name: "不正解で連続正解数をリセット", history: []types.QuestionRecord{solved, missed, solved}, want: 4},
+		{name: "回答者がいない問題は数えない", history: []types.QuestionRecord{solved, {}, solved}, want: 6},
+		{name: "下限で止まる", difficulty: "Easy", history: []types.QuestionRecord{missed, missed, missed, missed}, want: types.MIN_RATING},
+		{name: "上限で止まる", difficulty: "Hard", history: []types.QuestionRecord{solved, solved, solved, solved, solved, solved}, want: types.MAX_RATING},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := &types.GameState{Settings: types.GameSettings{Difficulty: tt.difficulty}, History: tt.history}
+			if got := adaptiveTargetRating(state); got != tt.want {
+				t.Errorf("adaptiveTargetRating() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdaptiveSelection(t *testing.T) {
+	available := []*types.Question{
+		{ID: "r2", Rating: 2},
+		{ID: "easy", Difficulty: "Easy"},
+		{ID: "r6", Rating: 6},
+		{ID: "hard", Difficulty: "Hard"},
+	}
+	tests := []struct {
+		name    string
+		history []types.QuestionRecord
+		want    string
+	}{
+		{name: "目標5に最も近い問題", want: "r6"},
+		{name: "正解が続けば難しい問題", history: []types.QuestionRecord{record(1, 1), record(1, 1), record(1, 1), record(1, 1)}, want: "hard"},
+		{name: "不正解が続けば易しい問題", history: []types.QuestionRecord{record(0, 1), record(0, 1), record(0, 1)}, want: "r2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := &types.GameState{History: tt.history, Rand: rand.New(rand.NewSource(1))}
+			if got := (adaptiveSelection{}).Select(state, available); got.ID != tt.want {
+				t.Errorf("Select() = %s, want %s", got.ID, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+// server/src/internal/feature/quiz/service/settings.go
+package service
+
+import (
+	"log"
+	"server/src/internal/feature/quiz/types"
+	roomtypes "server/src/internal/feature/room/types"
+	"time"
+)
+
+const (
+	DEFAULT_TIME_LIMIT = 30 * time.Second // クライアントの TIMER.DURATION_SECONDS と合わせる
+	MIN_TIME_LIMIT     = 5 * time.Second
+	MAX_TIME_LIMIT     = 120 * time.Second
+
+	DEFAULT_QUESTION_COUNT = 5
+	MAX_QUESTION_COUNT     = 50
+
+	DEFAULT_REVEAL_TIME = 3 * time.Second
+	MAX_REVEAL_TIME     = 30 * time.Second
+	MAX_INTERMISSION    = 60 * time.Second
+	MAX_COUNTDOWN       = 10 * time.Second
+)
+
+// loadGameSettings はルーム設定を読み込み、ゲームの進行ルールに変換します。
+// ルームが取得できない場合はデフォルト設定でゲームを進行します。
+func (s *QuizService) loadGameSettings(roomID string) types.GameSettings {
+	if s.hub.DBHandler == nil {
+		return newGameSettings(roomtypes.Settings{})
+	}
+	room, err := s.hub.DBHandler.ReadDB(roomID)
+	if err != nil {
+		log.Printf("warning: cannot load settings for room %s, using defaults: %v", roomID, err)
+		return newGameSettings(roomtypes.Settings{})
+	}
+	settings := newGameSettings(room.Settings)
+	settings.HostID = room.HostID
+	settings.Owner = room.Owner
+	if settings.Mode == types.GameModeTeam {
+		settings.Teams = teamRoster(room.Teams)
+	}
+	return settings
+}
+
+// newGameSettings はルーム設定の値を検証し、範囲外の値を補正します。
+func newGameSettings(rs roomtypes.Settings) types.GameSettings {
+	timeLimit := DEFAULT_TIME_LIMIT
+	if rs.TimeLimitSeconds > 0 {
+		timeLimit = time.Duration(rs.TimeLimitSeconds) * time.Second
+		if timeLimit < MIN_TIME_LIMIT {
+			timeLimit = MIN_TIME_LIMIT
+		}
+		if timeLimit > MAX_TIME_LIMIT {
+			timeLimit = MAX_TIME_LIMIT
+		}
+	}
+	questionCount := DEFAULT_QUESTION_COUNT
+	if rs.QuestionCount > 0 {
+		questionCount = rs.QuestionCount
+		if questionCount > MAX_QUESTION_COUNT {
+			questionCount = MAX_QUESTION_COUNT
+		}
+	}
+
+	revealTime := DEFAULT_REVEAL_TIME
+	if rs.RevealSeconds > 0 {
+		revealTime = clampSeconds(rs.RevealSeconds, MAX_REVEAL_TIME)
+	}
+
+	mode := parseGameMode(rs.GameMode)
+	answerMode := parseAnswerMode(rs.AnswerMode)
+	if mode == types.GameModeElimination {
+		// 脱落モードでは残っている全員の回答を判定するため、最初の正解者で締め切らない
+		answerMode = types.AnswerModeEveryone
+	}
+
+	return types.GameSettings{
+		TimeLimit:        timeLimit,
+		AnswerMode:       answerMode,
+		Scoring:          newScoringStrategy(rs.Scoring),
+		Selection:        newSelectionStrategy(rs.Selection),
+		Mode:             mode,
+		TeamCount:        rs.TeamCount,
+		OneAnswerPerTeam: rs.OneAnswerPerTeam,
+		RepeatQuestions:  rs.RematchRepeatQuestions,
+		RevealTime:       revealTime,
+		IntermissionTime: clampSeconds(rs.IntermissionSeconds, MAX_INTERMISSION),
+		CountdownTime:    clampSeconds(rs.CountdownSeconds, MAX_COUNTDOWN),
+		QuestionCount:    questionCount,
+		Language:         rs.Language,
+		Difficulty:       rs.Difficulty,
+		Shuffle:          parseShuffleMode(rs.ShuffleChoices),
+		Seed:             rs.Seed,
+	}
+}
+
+// clampSeconds は秒数を0以上 max 以下の時間に変換します。
+func clampSeconds(seconds int, max time.Duration) time.Duration {
+	d := time.Duration(seconds) * time.Second
+	if d < 0 {
+		return 0
+	}
+	if d > max {
+		return max
+	}
+	return d
+}
+
+// parseShuffleMode は未指定または不明な値を game（全員共通で並び替え）として扱います。
+func parseShuffleMode(mode string) types.ShuffleMode {
+	switch types.ShuffleMode(mode) {
+	case types.ShuffleNone, types.ShufflePlayer:
+		return types.ShuffleMode(mode)
+	default:
+		return types.ShuffleGame
+	}
+}
+
+// parseAnswerMode は未指定または不明な値を first_correct として扱います。
+func parseAnswerMode(mode string) types.AnswerMode {
+	switch types.AnswerMode(mode) {
+	case types.AnswerModeEveryone:
+		return types.AnswerModeEveryone
+	default:
+		return types.AnswerModeFirstCorrect
+	}
+}
+
+// parseGameMode は未指定または不明な値を classic として扱います。
+func parseGameMode(mode string) types.GameMode {
+	switch types.GameMode(mode) {
+	case types.GameModeElimination, types.GameModeTeam:
+		return types.GameMode(mode)
+	default:
+		return types.GameModeClassic
+	}
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"server/src/internal/feature/quiz/types"
+	roomtypes "server/src/internal/feature/room/types"
+	"testing"
+	"time"
+)
+
+func TestNewGameSettingsTimeLimit(t *testing.T) {
+	tests := []struct {
+		name    string
+		seconds int
+		want    time.Duration
+	}{
+		{name: "未設定はデフォルト", seconds: 0, want: DEFAULT_TIME_LIMIT},
+		{name: "負の値はデフォルト", seconds: -10, want: DEFAULT_TIME_LIMIT},
+		{name: "下限未満は下限に補正", seconds: 1, want: MIN_TIME_LIMIT},
+		{name: "上限超過は上限に補正", seconds: 600, want: MAX_TIME_LIMIT},
+		{name: "範囲内はそのまま", seconds: 45, want: 45 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newGameSettings(roomtypes.Settings{TimeLimitSeconds: tt.seconds})
+			if got.TimeLimit != tt.want {
+				t.Errorf("TimeLimit = %v, want %v", got.TimeLimit, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAnswerMode(t *testing.T) {
+	tests := []struct {
+		mode string
+		want types.AnswerMode
+	}{
+		{mode: "", want: types.AnswerModeFirstCorrect},
+		{mode: "first_correct", want: types.AnswerModeFirstCorrect},
+		{mode: "everyone", want: types.AnswerModeEveryone},
+		{mode: "EVERYONE", want: types.AnswerModeFirstCorrect},
+		{mode: "unknown", want: types.AnswerModeFirstCorrect},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			if got := parseAnswerMode(tt.mode); got != tt.want {
+				t.Errorf("parseAnswerMode(%q) = %q, want %q", tt.mode, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewGameSettingsQuestionCount(t *testing.T) {
+	tests := []struct {
+		name  string
+		count int
+		want  int
+	}{
+		{name: "未設定はデフォルト", count: 0, want: DEFAULT_QUESTION_COUNT},
+		{name: "負の値はデフォルト", count: -1, want: DEFAULT_QUESTION_COUNT},
+		{name: "範囲内はそのまま", count: 12, want: 12},
+		{name: "上限超過は上限に補正", count: 500, want: MAX_QUESTION_COUNT},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newGameSettings(roomtypes.Settings{QuestionCount: tt.count})
+			if got.QuestionCount != tt.want {
+				t.Errorf("QuestionCount = %d, want %d", got.QuestionCount, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseShuffleMode(t *testing.T) {
+	tests := []struct {
+		mode string
+		want types.ShuffleMode
+	}{
+		{mode: "", want: types.ShuffleGame},
+		{mode: "none", want: types.ShuffleNone},
+		{mode: "game", want: types.ShuffleGame},
+		{mode: "player", want: types.ShufflePlayer},
+		{mode: "unknown", want: types.ShuffleGame},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			if got := parseShuffleMode(tt.mode); got != tt.want {
+				t.Errorf("parseShuffleMode(%q) = %q, want %q", tt.mode, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewGameSettingsGameMode(t *testing.T) {
+	tests := []struct {
+		name           string
+		gameMode       string
+		answerMode     string
+		wantMode       types.GameMode
+		wantAnswerMode types.AnswerMode
+	}{
+		{name: "未設定は classic", wantMode: types.GameModeClassic, wantAnswerMode: types.AnswerModeFirstCorrect},
+		{name: "不明な値は classic", gameMode: "battle_royale", wantMode: types.GameModeClassic, wantAnswerMode: types.AnswerModeFirstCorrect},
+		{name: "classic は回答受付ルールを変えない", gameMode: "classic", answerMode: "everyone", wantMode: types.GameModeClassic, wantAnswerMode: types.AnswerModeEveryone},
+		{name: "elimination は全員の回答を待つ", gameMode: "elimination", answerMode: "first_correct", wantMode: types.GameModeElimination, wantAnswerMode: types.AnswerModeEveryone},
+		{name: "team は回答受付ルールを変えない", gameMode: "team", answerMode: "first_correct", wantMode: types.GameModeTeam, wantAnswerMode: types.AnswerModeFirstCorrect},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newGameSettings(roomtypes.Settings{GameMode: tt.gameMode, AnswerMode: tt.answerMode})
+			if got.Mode != tt.wantMode || got.AnswerMode != tt.wantAnswerMode {
+				t.Errorf("Mode, AnswerMode = %q, %q, want %q, %q", got.Mode, got.AnswerMode, tt.wantMode, tt.wantAnswerMode)
+			}
+		})
+	}
+}
+
+func TestNewGameSettingsPhases(t *testing.T) {
+	tests := []struct {
+		name             string
+		settings         roomtypes.Settings
+		wantReveal       time.Duration
+		wantIntermission time.Duration
+		wantCountdown    time.Duration
+	}{
+		{name: "未設定はデフォルト", wantReveal: DEFAULT_REVEAL_TIME},
+		{name: "範囲内はそのまま", settings: roomtypes.Settings{RevealSeconds: 5, IntermissionSeconds: 10, CountdownSeconds: 3}, wantReveal: 5 * time.Second, wantIntermission: 10 * time.Second, wantCountdown: 3 * time.Second},
+		{name: "上限超過は上限に補正", settings: roomtypes.Settings{RevealSeconds: 600, IntermissionSeconds: 600, CountdownSeconds: 600}, wantReveal: MAX_REVEAL_TIME, wantIntermission: MAX_INTERMISSION, wantCountdown: MAX_COUNTDOWN},
+		{name: "負の値は0（正解発表はデフォルト）", settings: roomtypes.Settings{RevealSeconds: -1, IntermissionSeconds: -1, CountdownSeconds: -1}, wantReveal: DEFAULT_REVEAL_TIME},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newGameSettings(tt.settings)
+			if got.RevealTime != tt.wantReveal || got.IntermissionTime != tt.wantIntermission || got.CountdownTime != tt.wantCountdown {
+				t.Errorf("reveal, intermission, countdown = %v, %v, %v, want %v, %v, %v",
+					got.RevealTime, got.IntermissionTime, got.CountdownTime, tt.wantReveal, tt.wantIntermission, tt.wantCountdown)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+// server/src/internal/feature/quiz/service/team.go
+package service
+
+import (
+	"math/rand"
+	"server/src/internal/feature/quiz/types"
+	roomservice "server/src/internal/feature/room/service"
+	roomtypes "server/src/internal/feature/room/types"
+	"sort"
+)
+
+// teamRoster はルームのチーム分けをゲームのチームに変換します。
+func teamRoster(teams []roomtypes.Team) []types.Team {
+	roster := make([]types.Team, len(teams))
+	for i, t := range teams {
+		roster[i] = types.Team{ID: t.ID, Name: t.Name, Members: append([]string(nil), t.Members...)}
+	}
+	return roster
+}
+
+// buildTeams はゲーム開始時のチーム分けを確定します。
+//
+// ルームで指定されたチーム分けのうち接続中のプレイヤーのみを残し、どのチームにも入っていないプレイヤーは
+// ゲームの乱数で順番を決めて、人数の最も少ないチーム（同じ場合は先のチーム）へ振り分けます。
+// チーム分けが指定されていない場合は、ルーム設定のチーム数（未指定の場合は2）のチームを作成します。
+func buildTeams(settings types.GameSettings, playerIDs []string, rng *rand.Rand) ([]types.Team, map[string]string) {
+	connected := make(map[string]bool, len(playerIDs))
+	for _, id := range playerIDs {
+		connected[id] = true
+	}
+
+	teams := settings.Teams
+	if len(teams) == 0 {
+		count := settings.TeamCount
+		if count < 2 || count > roomservice.MAX_TEAM_COUNT {
+			count = roomservice.DEFAULT_TEAM_COUNT
+		}
+		teams = teamRoster(roomservice.NewTeams(count))
+	}
+
+	teamOf := make(map[string]string, len(playerIDs))
+	finalTeams := make([]types.Team, len(teams))
+	for i, t := range teams {
+		finalTeams[i] = types.Team{ID: t.ID, Name: t.Name, Members: []string{}}
+		for _, userID := range t.Members {
+			if connected[userID] && teamOf[userID] == "" {
+				finalTeams[i].Members = append(finalTeams[i].Members, userID)
+				teamOf[userID] = t.ID
+			}
+		}
+	}
+
+	var unassigned []string
+	for _, id := range playerIDs {
+		if teamOf[id] == "" {
+			unassigned = append(unassigned, id)
+		}
+	}
+	sort.Strings(unassigned) // 接続順に影響されず、同じシードなら同じチーム分けになるようにする
+	rng.Shuffle(len(unassigned), func(i, j int) {
+		unassigned[i], unassigned[j] = unassigned[j], unassigned[i]
+	})
+	for _, userID := range unassigned {
+		smallest := 0
+		for i := range finalTeams {
+			if len(finalTeams[i].Members) < len(finalTeams[smallest].Members) {
+				smallest = i
+			}
+		}
+		finalTeams[smallest].Members = append(finalTeams[smallest].Members, userID)
+		teamOf[userID] = finalTeams[smallest].ID
+	}
+	return finalTeams, teamOf
+}
+
+// teamStandings は現在のチームの得点（メンバーの得点の合計）を、チームの順番で返します。
+func teamStandings(state *types.GameState) []types.TeamResult {
+	standings := make([]types.TeamResult, len(state.Teams))
+	for i, team := range state.Teams {
+		standings[i] = types.TeamResult{
+			TeamID:  team.ID,
+			Name:    team.Name,
+			Members: append([]string(nil), team.Members...),
+		}
+		for _, userID := range team.Members {
+			standings[i].Score += state.Scores[userID]
+		}
+	}
+	return standings
+}
+
+// withTeams は team モードの場合に、得点を含むメッセージへチームの構成と得点を追加します。
+func withTeams(state *types.GameState, payload map[string]interface{}) map[string]interface{} {
+	if state.Settings.Mode == types.GameModeTeam {
+		payload["teams"] = teamStandings(state)
+	}
+	return payload
+}
+
+// teammateAnswered は同じチームの他のメンバーが現在の問題に回答済みかを返します。
+func teammateAnswered(state *types.GameState, userID string) bool {
+	teamID, ok := state.TeamOf[userID]
+	if !ok {
+		return false
+	}
+	for id := range state.Answers {
+		if id != userID && state.TeamOf[id] == teamID {
+			return true
+		}
+	}
+	return false
+}
+
+// oneAnswerPerTeam は1問につきチームで1人の回答のみを受け付けるかを返します。
+func oneAnswerPerTeam(state *types.GameState) bool {
+	return state.Settings.Mode == types.GameModeTeam && state.Settings.OneAnswerPerTeam
+}
+
+// teamRanking は team モードの最終順位を作成します。チームは得点の合計で順位を決め、同点のチームは同じ順位です。
+// プレイヤーの順位は所属チームの順位とし、チームの順位、個人の得点の順に並べます。
+func teamRanking(state *types.GameState) ([]types.TeamResult, []types.PlayerResult) {
+	teams := teamStandings(state)
+	sort.SliceStable(teams, func(i, j int) bool {
+		return teams[i].Score > teams[j].Score
+	})
+	rank := make(map[string]int, len(teams))
+	for i := range teams {
+		if i > 0 && teams[i].Score == teams[i-1].Score {
+			teams[i].Rank = teams[i-1].Rank
+		} else {
+			teams[i].Rank = i + 1
+		}
+		rank[teams[i].TeamID] = teams[i].Rank
+	}
+
+	players := make([]types.PlayerResult, 0, len(state.Scores))
+	for userID, score := range state.Scores {
+		teamID := state.TeamOf[userID]
+		r, ok := rank[teamID]
+		if !ok {
+			r = len(teams) + 1 // 途中から接続したプレイヤーはどのチームにも属さない
+		}
+		players = append(players, types.PlayerResult{UserID: userID, Score: score, Rank: r, TeamID: teamID})
+	}
+	sort.Slice(players, func(i, j int) bool {
+		if players[i].Rank != players[j].Rank {
+			return players[i].Rank < players[j].Rank
+		}
+		if players[i].Score != players[j].Score {
+			return players[i].Score > players[j].Score
+		}
+		return players[i].UserID < players[j].UserID
+	})
+	return teams, players
+}
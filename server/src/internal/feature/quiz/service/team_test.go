@@ -0,0 +1,227 @@
+package service
+
+import (
+	"fmt"
+	"math/rand"
+	"server/src/internal/feature/quiz/types"
+	"testing"
+	"time"
+)
+
+// formatTeams はチーム分けを "チームID=[メンバー]" の形で返します。
+func formatTeams(teams []types.Team) string {
+	var out []string
+	for _, team := range teams {
+		out = append(out, fmt.Sprintf("%s=%v", team.ID, team.Members))
+	}
+	return fmt.Sprint(out)
+}
+
+func TestBuildTeams(t *testing.T) {
+	roster := []types.Team{
+		{ID: "red", Name: "Red", Members: []string{"alice", "ghost"}},
+		{ID: "blue", Name: "Blue", Members: []string{"alice", "bob"}},
+	}
+	tests := []struct {
+		name      string
+		settings  types.GameSettings
+		players   []string
+		wantTeams int
+		wantSizes []int
+		check     func(t *testing.T, teams []types.Team, teamOf map[string]string)
+	}{
+		{name: "指定がなければ2チーム", players: []string{"a", "b", "c", "d", "e"}, wantTeams: 2, wantSizes: []int{3, 2}},
+		{name: "チーム数の指定", settings: types.GameSettings{TeamCount: 3}, players: []string{"a", "b", "c", "d"}, wantTeams: 3, wantSizes: []int{2, 1, 1}},
+		{name: "範囲外のチーム数は既定値", settings: types.GameSettings{TeamCount: 9}, players: []string{"a", "b"}, wantTeams: 2, wantSizes: []int{1, 1}},
+		{
+			name:      "指定したチーム分けのうち接続中のプレイヤーのみ残す",
+			settings:  types.GameSettings{Teams: roster},
+			players:   []string{"alice", "bob"},
+			wantTeams: 2,
+			wantSizes: []int{1, 1},
+			check: func(t *testing.T, teams []types.Team, teamOf map[string]string) {
+				// 複数のチームに指定されたプレイヤーは先のチームに入る
+				if got := formatTeams(teams); got != "[red=[alice] blue=[bob]]" {
+					t.Errorf("teams = %s", got)
+				}
+			},
+		},
+		{
+			name:      "未所属のプレイヤーは人数の少ないチームへ",
+			settings:  types.GameSettings{Teams: []types.Team{{ID: "red", Members: []string{"alice", "bob"}}, {ID: "blue", Members: []string{}}}},
+			players:   []string{"alice", "bob", "carol", "dave", "erin"},
+			wantTeams: 2,
+			wantSizes: []int{3, 2},
+			check: func(t *testing.T, teams []types.Team, teamOf map[string]string) {
+				if teamOf["alice"] != "red" || teamOf["bob"] != "red" {
+					t.Errorf("assigned players moved: %v", teamOf)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			teams, teamOf := buildTeams(tt.settings, tt.players, rand.New(rand.NewSource(1)))
+			if len(teams) != tt.wantTeams {
+				t.Fatalf("got %d teams, want %d", len(teams), tt.wantTeams)
+			}
+			for i, team := range teams {
+				if len(team.Members) != tt.wantSizes[i] {
+					t.Errorf("team %s has %d members, want %d", team.ID, len(team.Members), tt.wantSizes[i])
+				}
+				for _, userID := range team.Members {
+					if teamOf[userID] != team.ID {
+						t.Errorf("teamOf[%s] = %q, want %q", userID, teamOf[userID], team.ID)
+					}
+				}
+			}
+			if len(teamOf) != len(tt.players) {
+				t.Errorf("%d players assigned, want %d", len(teamOf), len(tt.players))
+			}
+			if tt.check != nil {
+				tt.check(t, teams, teamOf)
+			}
+		})
+	}
+
+	t.Run("同じシードと顔ぶれなら接続順に関係なく同じチーム分け", func(t *testing.T) {
+		a, _ := buildTeams(types.GameSettings{}, []string{"a", "b", "c", "d"}, rand.New(rand.NewSource(7)))
+		b, _ := buildTeams(types.GameSettings{}, []string{"d", "c", "b", "a"}, rand.New(rand.NewSource(7)))
+		if formatTeams(a) != formatTeams(b) {
+			t.Errorf("teams differ: %s vs %s", formatTeams(a), formatTeams(b))
+		}
+	})
+}
+
+// teamState は red（alice, bob）と blue（carol）の team モードの問題の状態を返します。
+func teamState(oneAnswerPerTeam bool) *types.GameState {
+	state := activeState(1, time.Now().Add(time.Minute))
+	state.Settings.Mode = types.GameModeTeam
+	state.Settings.AnswerMode = types.AnswerModeEveryone
+	state.Settings.OneAnswerPerTeam = oneAnswerPerTeam
+	state.Scores = map[string]int{"alice": 0, "bob": 0, "carol": 0}
+	state.Teams = []types.Team{
+		{ID: "red", Name: "Red", Members: []string{"alice", "bob"}},
+		{ID: "blue", Name: "Blue", Members: []string{"carol"}},
+	}
+	state.TeamOf = map[string]string{"alice": "red", "bob": "red", "carol": "blue"}
+	return state
+}
+
+func TestTeamRanking(t *testing.T) {
+	tests := []struct {
+		name        string
+		scores      map[string]int
+		wantTeams   []string // "順位:チームID:得点"
+		wantPlayers []string // "順位:UserID"
+	}{
+		{
+			name:        "メンバーの得点の合計で順位を決める",
+			scores:      map[string]int{"alice": 10, "bob": 5, "carol": 20},
+			wantTeams:   []string{"1:blue:20", "2:red:15"},
+			wantPlayers: []string{"1:carol", "2:alice", "2:bob"},
+		},
+		{
+			name:        "同点のチームは同じ順位",
+			scores:      map[string]int{"alice": 10, "bob": 10, "carol": 20},
+			wantTeams:   []string{"1:red:20", "1:blue:20"},
+			wantPlayers: []string{"1:carol", "1:alice", "1:bob"},
+		},
+		{
+			name:        "途中から接続したプレイヤーは最下位",
+			scores:      map[string]int{"alice": 10, "bob": 0, "carol": 0, "late": 50},
+			wantTeams:   []string{"1:red:10", "2:blue:0"},
+			wantPlayers: []string{"1:alice", "1:bob", "2:carol", "3:late"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := teamState(false)
+			state.Scores = tt.scores
+			teams, players := teamRanking(state)
+
+			var gotTeams, gotPlayers []string
+			for _, team := range teams {
+				gotTeams = append(gotTeams, fmt.Sprintf("%d:%s:%d", team.Rank, team.TeamID, team.Score))
+			}
+			for _, player := range players {
+				gotPlayers = append(gotPlayers, fmt.Sprintf("%d:%s", player.Rank, player.UserID))
+				if player.TeamID != state.TeamOf[player.UserID] {
+					t.Errorf("%s TeamID = %q, want %q", player.UserID, player.TeamID, state.TeamOf[player.UserID])
+				}
+			}
+			if fmt.Sprint(gotTeams) != fmt.Sprint(tt.wantTeams) {
+				t.Errorf("teams = %v, want %v", gotTeams, tt.wantTeams)
+			}
+			if fmt.Sprint(gotPlayers) != fmt.Sprint(tt.wantPlayers) {
+				t.Errorf("players = %v, want %v", gotPlayers, tt.wantPlayers)
+			}
+		})
+	}
+}
+
+func TestWithTeams(t *testing.T) {
+	tests := []struct {
+		name      string
+		mode      types.GameMode
+		wantTeams bool
+	}{
+		{name: "team モードはチームの得点を追加", mode: types.GameModeTeam, wantTeams: true},
+		{name: "classic モードは変更しない", mode: types.GameModeClassic},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := teamState(false)
+			state.Settings.Mode = tt.mode
+			state.Scores["alice"] = 10
+			payload := withTeams(state, map[string]interface{}{})
+			teams, ok := payload["teams"].([]types.TeamResult)
+			if ok != tt.wantTeams {
+				t.Fatalf("teams present = %v, want %v", ok, tt.wantTeams)
+			}
+			if ok && teams[0].Score != 10 {
+				t.Errorf("red score = %d, want 10", teams[0].Score)
+			}
+		})
+	}
+}
+
+func TestProcessAnswerOneAnswerPerTeam(t *testing.T) {
+	correct := map[string]interface{}{"choiceId": types.ChoiceID("q1", "2")}
+	tests := []struct {
+		name             string
+		oneAnswerPerTeam bool
+		wantBobAnswer    bool
+	}{
+		{name: "チームで1人のみ回答できる", oneAnswerPerTeam: true, wantBobAnswer: false},
+		{name: "制限がなければ全員回答できる", oneAnswerPerTeam: false, wantBobAnswer: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService()
+			joinPlayers(t, s, "alice", "bob", "carol")
+			state := teamState(tt.oneAnswerPerTeam)
+			installGame(s, state)
+
+			s.processAnswer(TEST_ROOM_ID, "alice", state, correct)
+			drainMessageTypes(s)
+			s.processAnswer(TEST_ROOM_ID, "bob", state, correct)
+			if _, answered := state.Answers["bob"]; answered != tt.wantBobAnswer {
+				t.Fatalf("bob answered = %v, want %v", answered, tt.wantBobAnswer)
+			}
+			if !tt.wantBobAnswer {
+				if message := nextMessage(t, s); message.Type != "answer_error" || message.UserID != "bob" {
+					t.Fatalf("message = %+v, want answer_error to bob", message)
+				}
+			}
+			drainMessageTypes(s)
+
+			// チームの誰かが回答していれば、そのチームは回答済みとして締め切りを判定する
+			s.processAnswer(TEST_ROOM_ID, "carol", state, correct)
+			stopGameTimers(state)
+			if state.IsQuestionActive {
+				t.Error("question is still active after every team answered")
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+// server/src/internal/feature/quiz/service/validation.go
+package service
+
+import (
+	"errors"
+	"fmt"
+	"server/src/internal/feature/quiz/types"
+	"strings"
+)
+
+// validateQuestions は問題バンク全体を検証し、問題ごとのエラーをまとめて返します。
+func validateQuestions(questions []types.Question) error {
+	var errs []error
+	seen := make(map[string]bool, len(questions))
+	for i := range questions {
+		q := &questions[i]
+		if q.ID != "" && seen[q.ID] {
+			errs = append(errs, fmt.Errorf("question %s: duplicate id", q.ID))
+		}
+		seen[q.ID] = true
+		if err := validateQuestion(q); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validateQuestion は1問分の内容を検証します。
+func validateQuestion(q *types.Question) error {
+	if q.ID == "" {
+		return errors.New("question: id is required")
+	}
+	if strings.TrimSpace(q.Statement) == "" {
+		return fmt.Errorf("question %s: statement is empty", q.ID)
+	}
+
+	var err error
+	switch q.QuestionKind() {
+	case types.KindSingleChoice:
+		err = validateSingleChoice(q)
+	case types.KindFreeText:
+		err = validateFreeText(q)
+	case types.KindMultiSelect:
+		err = validateMultiSelect(q)
+	case types.KindOrdering:
+		err = validateOrdering(q)
+	default:
+		err = fmt.Errorf("unknown kind %q", q.Kind)
+	}
+	if err != nil {
+		return fmt.Errorf("question %s: %w", q.ID, err)
+	}
+
+	if q.Rating != 0 && (q.Rating < types.MIN_RATING || q.Rating > types.MAX_RATING) {
+		return fmt.Errorf("question %s: rating must be between %d and %d", q.ID, types.MIN_RATING, types.MAX_RATING)
+	}
+
+	for _, ref := range q.References {
+		if strings.TrimSpace(ref.URL) == "" {
+			return fmt.Errorf("question %s: reference %q has no url", q.ID, ref.Title)
+		}
+	}
+	return nil
+}
+
+// validateSingleChoice は選択式の問題を検証します。
+// 答えが選択肢のいずれかと一致しない問題は、誰も正解できないため不正とします。
+func validateSingleChoice(q *types.Question) error {
+	seen, err := validateChoices(q)
+	if err != nil {
+		return err
+	}
+	if !seen[q.Answer] {
+		return fmt.Errorf("answer %q is not one of the choices", q.Answer)
+	}
+	return nil
+}
+
+// validateMultiSelect は複数選択の問題を検証します。正解はすべて選択肢に含まれている必要があります。
+func validateMultiSelect(q *types.Question) error {
+	seen, err := validateChoices(q)
+	if err != nil {
+		return err
+	}
+	if len(q.Answers) == 0 {
+		return errors.New("at least 1 answer is required")
+	}
+	answers := make(map[string]bool, len(q.Answers))
+	for _, answer := range q.Answers {
+		if !seen[answer] {
+			return fmt.Errorf("answer %q is not one of the choices", answer)
+		}
+		if answers[answer] {
+			return fmt.Errorf("duplicate answer %q", answer)
+		}
+		answers[answer] = true
+	}
+	return nil
+}
+
+// validateOrdering は並べ替えの問題を検証します。Choices の順番がそのまま正解になります。
+func validateOrdering(q *types.Question) error {
+	if _, err := validateChoices(q); err != nil {
+		return err
+	}
+	if q.Answer != "" || len(q.Answers) > 0 {
+		return errors.New("ordering question uses the order of choices as the answer")
+	}
+	return nil
+}
+
+// validateChoices は選択肢が2つ以上かつ重複していないことを検証し、選択肢の集合を返します。
+func validateChoices(q *types.Question) (map[string]bool, error) {
+	if len(q.Choices) < 2 {
+		return nil, errors.New("at least 2 choices are required")
+	}
+
+	seen := make(map[string]bool, len(q.Choices))
+	for _, choice := range q.Choices {
+		if seen[choice] {
+			return nil, fmt.Errorf("duplicate choice %q", choice)
+		}
+		seen[choice] = true
+	}
+	for choice := range q.ChoiceRationales {
+		if !seen[choice] {
+			return nil, fmt.Errorf("rationale for unknown choice %q", choice)
+		}
+	}
+	return seen, nil
+}
+
+// validateFreeText は自由記述の問題を検証します。
+func validateFreeText(q *types.Question) error {
+	if len(q.Choices) > 0 {
+		return errors.New("free_text question must not have choices")
+	}
+	if q.Answer == "" {
+		return errors.New("answer is empty")
+	}
+	switch q.Match {
+	case "", types.MatchExact, types.MatchWhitespace, types.MatchCaseInsensitive:
+	case types.MatchRegex:
+		if _, err := types.CompileAnswerPattern(q.Answer); err != nil {
+			return fmt.Errorf("invalid answer pattern: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown match mode %q", q.Match)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"server/src/internal/feature/quiz/types"
+	"strings"
+	"testing"
+)
+
+func validQuestion() types.Question {
+	return types.Question{ID: "q1", Statement: "1+1", Choices: []string{"1", "2"}, Answer: "2"}
+}
+
+func TestValidateQuestion(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(q *types.Question)
+		wantErr string
+	}{
+		{name: "正しい問題", modify: func(q *types.Question) {}},
+		{name: "ID がない", modify: func(q *types.Question) { q.ID = "" }, wantErr: "id is required"},
+		{name: "問題文が空白のみ", modify: func(q *types.Question) { q.Statement = " \n" }, wantErr: "statement is empty"},
+		{name: "選択肢が1つ", modify: func(q *types.Question) { q.Choices = []string{"2"} }, wantErr: "at least 2 choices"},
+		{name: "選択肢が重複", modify: func(q *types.Question) { q.Choices = []string{"2", "2"} }, wantErr: "duplicate choice"},
+		{name: "答えが選択肢にない", modify: func(q *types.Question) { q.Answer = "3" }, wantErr: "not one of the choices"},
+		{name: "解説付きの問題", modify: func(q *types.Question) {
+			q.Explanation = "1+1=2"
+			q.ChoiceRationales = map[string]string{"1": "1を足し忘れています"}
+			q.References = []types.Reference{{Title: "spec", URL: "https://go.dev/ref/spec"}}
+		}},
+		{name: "存在しない選択肢の補足", modify: func(q *types.Question) { q.ChoiceRationales = map[string]string{"3": "?"} }, wantErr: "rationale for unknown choice"},
+		{name: "自由記述の問題", modify: func(q *types.Question) {
+			q.Kind, q.Choices, q.Answer, q.Match = types.KindFreeText, nil, `\d+`, types.MatchRegex
+		}},
+		{name: "自由記述に選択肢", modify: func(q *types.Question) { q.Kind = types.KindFreeText }, wantErr: "must not have choices"},
+		{name: "自由記述の答えが空", modify: func(q *types.Question) { q.Kind, q.Choices, q.Answer = types.KindFreeText, nil, "" }, wantErr: "answer is empty"},
+		{name: "自由記述の不正な正規表現", modify: func(q *types.Question) {
+			q.Kind, q.Choices, q.Answer, q.Match = types.KindFreeText, nil, "(", types.MatchRegex
+		}, wantErr: "invalid answer pattern"},
+		{name: "不明な照合方法", modify: func(q *types.Question) { q.Kind, q.Choices, q.Match = types.KindFreeText, nil, "fuzzy" }, wantErr: "unknown match mode"},
+		{name: "複数選択の問題", modify: func(q *types.Question) {
+			q.Kind, q.Choices, q.Answer, q.Answers = types.KindMultiSelect, []string{"a", "b", "c"}, "", []string{"a", "c"}
+		}},
+		{name: "複数選択の正解がない", modify: func(q *types.Question) { q.Kind, q.Answer = types.KindMultiSelect, "" }, wantErr: "at least 1 answer"},
+		{name: "複数選択の正解が選択肢にない", modify: func(q *types.Question) { q.Kind, q.Answers = types.KindMultiSelect, []string{"3"} }, wantErr: "not one of the choices"},
+		{name: "複数選択の正解が重複", modify: func(q *types.Question) { q.Kind, q.Answers = types.KindMultiSelect, []string{"1", "1"} }, wantErr: "duplicate answer"},
+		{name: "並べ替えの問題", modify: func(q *types.Question) { q.Kind, q.Answer = types.KindOrdering, "" }},
+		{name: "並べ替えに答えを指定", modify: func(q *types.Question) { q.Kind = types.KindOrdering }, wantErr: "uses the order of choices"},
+		{name: "並べ替えの行が重複", modify: func(q *types.Question) { q.Kind, q.Answer, q.Choices = types.KindOrdering, "", []string{"x", "x"} }, wantErr: "duplicate choice"},
+		{name: "不明な形式", modify: func(q *types.Question) { q.Kind = "essay" }, wantErr: "unknown kind"},
+		{name: "難易度評価の上限", modify: func(q *types.Question) { q.Rating = types.MAX_RATING }},
+		{name: "難易度評価が範囲外", modify: func(q *types.Question) { q.Rating = types.MAX_RATING + 1 }, wantErr: "rating must be between"},
+		{name: "難易度評価が負", modify: func(q *types.Question) { q.Rating = -1 }, wantErr: "rating must be between"},
+		{name: "URL のない参考リンク", modify: func(q *types.Question) { q.References = []types.Reference{{Title: "spec"}} }, wantErr: "has no url"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := validQuestion()
+			tt.modify(&q)
+			err := validateQuestion(&q)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("validateQuestion() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("validateQuestion() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateQuestionsReportsEveryProblem(t *testing.T) {
+	broken := validQuestion()
+	broken.ID = "q2"
+	broken.Answer = "3"
+	err := validateQuestions([]types.Question{validQuestion(), validQuestion(), broken})
+	if err == nil {
+		t.Fatal("validateQuestions() succeeded, want error")
+	}
+	for _, want := range []string{"question q1: duplicate id", "question q2: answer"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+}
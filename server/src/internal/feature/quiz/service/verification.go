@@ -0,0 +1,125 @@
+// server/src/internal/feature/quiz/service/verification.go
+package service
+
+import (
+	"context"
+	"log"
+	"os"
+	"server/src/internal/feature/quiz/types"
+	"server/src/internal/feature/quiz/verifier"
+	"strings"
+)
+
+// 読み込み時の答え検証モード（環境変数 QUESTION_VERIFY で指定）
+const (
+	VERIFY_OFF    = "off"    // 検証しない（デフォルト）
+	VERIFY_WARN   = "warn"   // 不一致をログに出力し、問題はそのまま使用する
+	VERIFY_STRICT = "strict" // 不一致の問題を出題候補から除外する
+)
+
+// SANDBOX_OFF は環境変数 QUESTION_VERIFY_SANDBOX で検証のサンドボックスを無効にする値です（ユーザー名前空間を使えない環境向け）。
+const SANDBOX_OFF = "off"
+
+// verifyQuestionsInBackground は読み込んだ問題のコード片をバックグラウンドで実行し、答えと一致するか確認します。
+// 実行にはローカルのツールチェーン（gcc, go, python3）が必要なため、デフォルトでは無効です。
+//
+// 全問の検証には数分かかることがあるため、起動や再読み込みは待たせず、読み込んだ問題バンクをそのまま使い始めます。
+// 結果はログに出力し、strict モードでは答えが一致しない問題を後から問題バンクから除外します。
+// 検証中に問題バンクが読み込み直された場合は、古い検証を取り消します。
+func (s *QuizService) verifyQuestionsInBackground(questions []types.Question) {
+	mode := questionVerifyMode()
+	if mode == VERIFY_OFF {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.mu.Lock()
+	if s.cancelVerify != nil {
+		s.cancelVerify()
+	}
+	s.cancelVerify = cancel
+	s.mu.Unlock()
+
+	go s.verifyQuestions(ctx, mode, questions)
+}
+
+// verifyQuestions は問題を順番に検証し、結果をログに出力します。
+func (s *QuizService) verifyQuestions(ctx context.Context, mode string, questions []types.Question) {
+	log.Printf("Verifying answers of %d questions in the background", len(questions))
+	mismatched := verifyAnswers(ctx, questions)
+	if ctx.Err() != nil {
+		return // 新しい問題バンクの検証に置き換えられた
+	}
+
+	log.Printf("Question verification finished: %d questions checked", len(questions))
+	if mode == VERIFY_STRICT && len(mismatched) > 0 {
+		rejected := make(map[string]bool, len(mismatched))
+		for _, result := range mismatched {
+			rejected[result.QuestionID] = true
+		}
+		s.excludeRejectedQuestions(questions, rejected)
+	}
+}
+
+// questionVerifyMode は環境変数 QUESTION_VERIFY の答え検証モードを返します（未設定の場合は VERIFY_OFF）。
+func questionVerifyMode() string {
+	mode := strings.ToLower(os.Getenv("QUESTION_VERIFY"))
+	if mode == "" {
+		return VERIFY_OFF
+	}
+	return mode
+}
+
+// verifyAnswers は問題のコード片を実行し、答えが一致しなかった問題の検証結果を返します。
+// 一致しなかった問題と、実行環境のエラーなどで検証できなかった問題はログに出力します。
+func verifyAnswers(ctx context.Context, questions []types.Question) []verifier.Result {
+	var mismatched []verifier.Result
+	for _, result := range verifier.New(verifierOptions()).VerifyAll(ctx, questions) {
+		switch result.Status {
+		case verifier.StatusMismatch:
+			log.Printf("warning: question %s answer mismatch: expected %q, got %q", result.QuestionID, result.Expected, result.Actual)
+			mismatched = append(mismatched, result)
+		case verifier.StatusError:
+			log.Printf("warning: question %s could not be verified: %s", result.QuestionID, result.Detail)
+		}
+	}
+	return mismatched
+}
+
+// verifierOptions は環境変数を反映した検証時の制限を返します。
+func verifierOptions() verifier.Options {
+	opts := verifier.DefaultOptions
+	if strings.ToLower(os.Getenv("QUESTION_VERIFY_SANDBOX")) == SANDBOX_OFF {
+		log.Printf("warning: QUESTION_VERIFY_SANDBOX=off; question snippets run with the server's privileges")
+		opts.Unsandboxed = true
+	}
+	return opts
+}
+
+// excludeRejectedQuestions は答えが一致しなかった問題を問題バンクから除外します。
+// 検証中に問題バンクが差し替えられていた場合は、検証した問題と一致しないため何もしません。
+func (s *QuizService) excludeRejectedQuestions(questions []types.Question, rejected map[string]bool) {
+	s.updateMu.Lock()
+	defer s.updateMu.Unlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.base) != len(questions) || len(questions) == 0 || &s.base[0] != &questions[0] {
+		log.Printf("Question bank changed during verification; ignoring the results")
+		return
+	}
+
+	verified := make([]types.Question, 0, len(questions)-len(rejected))
+	for _, q := range questions {
+		if !rejected[q.ID] {
+			verified = append(verified, q)
+		}
+	}
+	merged, err := mergeQuestionOverlay(verified, s.overlay)
+	if err != nil {
+		log.Printf("warning: keeping questions with mismatched answers: %v", err)
+		return
+	}
+	s.base = verified
+	s.questions = merged
+	log.Printf("Excluded %d questions with mismatched answers", len(rejected))
+}
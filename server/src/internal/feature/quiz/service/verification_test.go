@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"os/exec"
+	"server/src/internal/feature/quiz/types"
+	"testing"
+)
+
+// verificationQuestions は答えが一致する問題・一致しない問題・検証できない問題を返します。
+func verificationQuestions() []types.Question {
+	return []types.Question{
+		{ID: "ok", Language: "Python", Statement: "print(2)", Choices: []string{"1", "2"}, Answer: "2"},
+		{ID: "wrong", Language: "Python", Statement: "print(1)", Choices: []string{"1", "2"}, Answer: "2"},
+		{ID: "unsupported", Language: "Rust", Statement: "fn main() {}", Choices: []string{"1", "2"}, Answer: "2"},
+	}
+}
+
+// requirePython は python3 がない環境では答えの検証を伴うテストをスキップします。
+func requirePython(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("python3"); err != nil {
+		t.Skip("python3 is not installed")
+	}
+}
+
+func TestVerifyQuestions(t *testing.T) {
+	requirePython(t)
+	tests := []struct {
+		name     string
+		mode     string
+		canceled bool
+		want     string
+	}{
+		{name: "warn は問題バンクを変えない", mode: VERIFY_WARN, want: "[ok wrong unsupported]"},
+		{name: "strict は答えが一致しない問題を除外", mode: VERIFY_STRICT, want: "[ok unsupported]"},
+		{name: "取り消された検証は結果を反映しない", mode: VERIFY_STRICT, canceled: true, want: "[ok wrong unsupported]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(verificationQuestions()...)
+			ctx, cancel := context.WithCancel(context.Background())
+			if tt.canceled {
+				cancel()
+			}
+			defer cancel()
+
+			s.verifyQuestions(ctx, tt.mode, s.base)
+
+			if got := bankIDs(s); got != tt.want {
+				t.Errorf("questions = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifyQuestionsInBackgroundDisabled(t *testing.T) {
+	for _, mode := range []string{"", VERIFY_OFF} {
+		t.Run(mode, func(t *testing.T) {
+			t.Setenv("QUESTION_VERIFY", mode)
+			s := newTestService(verificationQuestions()...)
+
+			s.verifyQuestionsInBackground(s.base)
+
+			if s.cancelVerify != nil {
+				t.Error("verification was started while disabled")
+			}
+		})
+	}
+}
+
+func TestVerifierOptions(t *testing.T) {
+	tests := []struct {
+		name            string
+		sandbox         string
+		wantUnsandboxed bool
+	}{
+		{name: "既定ではサンドボックスで実行", sandbox: "", wantUnsandboxed: false},
+		{name: "off でサンドボックスを無効化", sandbox: "OFF", wantUnsandboxed: true},
+		{name: "未知の値はサンドボックスのまま", sandbox: "no", wantUnsandboxed: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("QUESTION_VERIFY_SANDBOX", tt.sandbox)
+
+			if got := verifierOptions().Unsandboxed; got != tt.wantUnsandboxed {
+				t.Errorf("Unsandboxed = %v, want %v", got, tt.wantUnsandboxed)
+			}
+		})
+	}
+}
+
+func TestExcludeRejectedQuestions(t *testing.T) {
+	tests := []struct {
+		name     string
+		replaced bool
+		want     string
+	}{
+		{name: "検証した問題バンクから除外", want: "[ok unsupported]"},
+		{name: "検証中に読み込み直された問題バンクは変えない", replaced: true, want: "[ok wrong unsupported]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(verificationQuestions()...)
+			verified := s.base
+			if tt.replaced {
+				verified = verificationQuestions()
+			}
+
+			s.excludeRejectedQuestions(verified, map[string]bool{"wrong": true})
+
+			if got := bankIDs(s); got != tt.want {
+				t.Errorf("questions = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+// server/src/internal/feature/quiz/source/dirSource.go
+package source
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"server/src/internal/feature/quiz/codec"
+	"server/src/internal/feature/quiz/types"
+	"strings"
+)
+
+// DirSource はディレクトリ以下（サブディレクトリを含む）の問題ファイルです。
+// 対応している拡張子のファイルをパス順に読み込み、ドットで始まるファイルやディレクトリは無視します。
+type DirSource struct {
+	Dir string
+}
+
+func NewDirSource(dir string) *DirSource {
+	return &DirSource{Dir: dir}
+}
+
+func (s *DirSource) Name() string {
+	return "directory " + s.Dir
+}
+
+// Load は読み込めたファイルの問題を返します。読み込めないファイルがあった場合は、そのエラーもまとめて返します。
+func (s *DirSource) Load() ([]types.Question, error) {
+	sources, err := s.Sources()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		questions []types.Question
+		errs      []error
+	)
+	for _, src := range sources {
+		qs, err := src.Load()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", src.Name(), err))
+			continue
+		}
+		questions = append(questions, qs...)
+	}
+	return questions, errors.Join(errs...)
+}
+
+// Sources はディレクトリ以下の問題ファイルをそれぞれ FileSource として返します。
+func (s *DirSource) Sources() ([]QuestionSource, error) {
+	files, err := s.Files()
+	if err != nil {
+		return nil, err
+	}
+	sources := make([]QuestionSource, len(files))
+	for i, path := range files {
+		sources[i] = NewFileSource(path)
+	}
+	return sources, nil
+}
+
+// Files はディレクトリ以下の問題ファイルのパスをパス順に返します。
+func (s *DirSource) Files() ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(s.Dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != s.Dir && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if _, err := codec.ForFile(path); err == nil {
+			files = append(files, path)
+		}
+		return nil
+	})
+	return files, err
+}
@@ -0,0 +1,34 @@
+// server/src/internal/feature/quiz/source/embeddedSource.go
+package source
+
+import (
+	"bytes"
+	_ "embed"
+	"server/src/internal/feature/quiz/codec"
+	"server/src/internal/feature/quiz/types"
+)
+
+// embeddedQuestions はバイナリに組み込む既定の問題バンクです（server/mock/mock.json と同じ内容）。
+//
+//go:embed embedded/questions.json
+var embeddedQuestions []byte
+
+// EmbeddedSource はバイナリに組み込まれた既定の問題バンクです。
+// 問題ファイルが見つからない環境でもゲームを開始できるよう、他のソースから問題を読み込めなかった場合に使用します。
+type EmbeddedSource struct{}
+
+func NewEmbeddedSource() *EmbeddedSource {
+	return &EmbeddedSource{}
+}
+
+func (s *EmbeddedSource) Name() string {
+	return "embedded default bank"
+}
+
+func (s *EmbeddedSource) Load() ([]types.Question, error) {
+	c, err := codec.Lookup("json")
+	if err != nil {
+		return nil, err
+	}
+	return c.Decode(bytes.NewReader(embeddedQuestions))
+}
@@ -0,0 +1,42 @@
+// server/src/internal/feature/quiz/source/fileSource.go
+package source
+
+import (
+	"os"
+	"server/src/internal/feature/quiz/codec"
+	"server/src/internal/feature/quiz/types"
+)
+
+// FileSource は1つの問題ファイルです。形式は拡張子から判定します。
+type FileSource struct {
+	Path string
+}
+
+func NewFileSource(path string) *FileSource {
+	return &FileSource{Path: path}
+}
+
+func (s *FileSource) Name() string {
+	return "file " + s.Path
+}
+
+func (s *FileSource) Load() ([]types.Question, error) {
+	return decodeFile(s.Path)
+}
+
+func (s *FileSource) Files() ([]string, error) {
+	return []string{s.Path}, nil
+}
+
+func decodeFile(path string) ([]types.Question, error) {
+	c, err := codec.ForFile(path)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return c.Decode(file)
+}
@@ -0,0 +1,36 @@
+// server/src/internal/feature/quiz/source/source.go
+// 問題バンクの読み込み元（問題ファイル、ディレクトリ、ストレージ、組み込みの問題）を提供します。
+// 複数のソースの合成と内容の検証は service パッケージで行います。
+package source
+
+import (
+	"os"
+	"server/src/internal/feature/quiz/types"
+)
+
+// QuestionSource は問題の読み込み元です。
+type QuestionSource interface {
+	// Name はログに表示するソースの名前です。
+	Name() string
+	// Load は問題を読み込みます。一部の読み込みに失敗した場合は、読み込めた問題とエラーの両方を返します。
+	Load() ([]types.Question, error)
+}
+
+// FileBacked はファイルから読み込むソースです。問題ファイルの変更の監視に使用します。
+type FileBacked interface {
+	// Files は読み込み対象のファイルのパスを返します。
+	Files() ([]string, error)
+}
+
+// Composite は複数のソースをまとめたソースです。合成時は個別のソースに展開し、エラーや ID の衝突をソースごとに報告します。
+type Composite interface {
+	Sources() ([]QuestionSource, error)
+}
+
+// ForPath はパスがディレクトリなら DirSource、それ以外なら FileSource を返します。
+func ForPath(path string) QuestionSource {
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		return NewDirSource(path)
+	}
+	return NewFileSource(path)
+}
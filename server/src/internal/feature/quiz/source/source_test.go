@@ -0,0 +1,164 @@
+package source
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	TEST_JSON = `[{"ID":"j1","Statement":"s","Choices":["a","b"],"Answer":"a"}]`
+	TEST_CSV  = "id,statement,choices,answer\nc1,s,\"a\nb\",a\n"
+)
+
+// writeFiles は dir 以下に files（Key: 相対パス, Value: 内容）を作成します。
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestForPath(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"bank.json": TEST_JSON})
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{name: "ディレクトリ", path: dir, want: "directory " + dir},
+		{name: "ファイル", path: filepath.Join(dir, "bank.json"), want: "file " + filepath.Join(dir, "bank.json")},
+		{name: "存在しないパスはファイル", path: filepath.Join(dir, "missing.json"), want: "file " + filepath.Join(dir, "missing.json")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ForPath(tt.path).Name(); got != tt.want {
+				t.Errorf("ForPath().Name() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileSourceLoad(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"bank.json": TEST_JSON, "bank.csv": TEST_CSV, "bank.txt": TEST_JSON, "broken.json": "{"})
+	tests := []struct {
+		name    string
+		file    string
+		wantIDs string
+		wantErr bool
+	}{
+		{name: "JSON", file: "bank.json", wantIDs: "j1"},
+		{name: "CSV", file: "bank.csv", wantIDs: "c1"},
+		{name: "未対応の拡張子", file: "bank.txt", wantErr: true},
+		{name: "壊れたファイル", file: "broken.json", wantErr: true},
+		{name: "存在しないファイル", file: "missing.json", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			questions, err := NewFileSource(filepath.Join(dir, tt.file)).Load()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var ids []string
+			for _, q := range questions {
+				ids = append(ids, q.ID)
+			}
+			if got := strings.Join(ids, ","); got != tt.wantIDs {
+				t.Errorf("IDs = %q, want %q", got, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestDirSourceLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		wantIDs string
+		wantErr bool
+	}{
+		{
+			name:    "サブディレクトリを含めてパス順に読み込む",
+			files:   map[string]string{"b.json": TEST_JSON, "a/bank.csv": TEST_CSV},
+			wantIDs: "c1,j1",
+		},
+		{
+			name:    "対応していないファイルとドットで始まるパスは無視する",
+			files:   map[string]string{"bank.json": TEST_JSON, "README.txt": "x", ".hidden.json": "{", ".git/bank.json": "{"},
+			wantIDs: "j1",
+		},
+		{
+			name:    "壊れたファイルがあっても他のファイルを読み込む",
+			files:   map[string]string{"bank.json": TEST_JSON, "broken.json": "{"},
+			wantIDs: "j1",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, tt.files)
+			questions, err := NewDirSource(dir).Load()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var ids []string
+			for _, q := range questions {
+				ids = append(ids, q.ID)
+			}
+			if got := strings.Join(ids, ","); got != tt.wantIDs {
+				t.Errorf("IDs = %q, want %q", got, tt.wantIDs)
+			}
+		})
+	}
+
+	t.Run("存在しないディレクトリ", func(t *testing.T) {
+		if _, err := NewDirSource(filepath.Join(t.TempDir(), "missing")).Load(); err == nil {
+			t.Error("Load() error = nil, want error")
+		}
+	})
+}
+
+func TestEmbeddedSourceLoad(t *testing.T) {
+	questions, err := NewEmbeddedSource().Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(questions) == 0 {
+		t.Fatal("Load() returned no questions")
+	}
+	seen := make(map[string]bool)
+	for _, q := range questions {
+		if seen[q.ID] {
+			t.Errorf("duplicate question ID %q", q.ID)
+		}
+		seen[q.ID] = true
+	}
+}
+
+func TestDirSourceSources(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"b.json": TEST_JSON, "a/bank.csv": TEST_CSV, "notes.txt": "x"})
+
+	sources, err := NewDirSource(dir).Sources()
+	if err != nil {
+		t.Fatalf("Sources() error = %v", err)
+	}
+	var names []string
+	for _, src := range sources {
+		names = append(names, src.Name())
+	}
+	want := []string{"file " + filepath.Join(dir, "a", "bank.csv"), "file " + filepath.Join(dir, "b.json")}
+	if strings.Join(names, "|") != strings.Join(want, "|") {
+		t.Errorf("Sources() = %v, want %v", names, want)
+	}
+}
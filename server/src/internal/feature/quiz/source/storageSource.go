@@ -0,0 +1,24 @@
+// server/src/internal/feature/quiz/source/storageSource.go
+package source
+
+import (
+	"server/src/internal/feature/quiz/repository"
+	"server/src/internal/feature/quiz/types"
+)
+
+// StorageSource はストレージ（DynamoDB）に保存された問題です。管理APIで追加・編集した問題が保存されます。
+type StorageSource struct {
+	repo *repository.QuestionRepository
+}
+
+func NewStorageSource(repo *repository.QuestionRepository) *StorageSource {
+	return &StorageSource{repo: repo}
+}
+
+func (s *StorageSource) Name() string {
+	return "storage"
+}
+
+func (s *StorageSource) Load() ([]types.Question, error) {
+	return s.repo.FindAll()
+}
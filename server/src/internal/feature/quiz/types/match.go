@@ -0,0 +1,46 @@
+// server/src/internal/feature/quiz/types/match.go
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Match は照合方法に従って回答と答えを比較します。
+// テキスト入力では末尾の改行を入力しづらいため、どの方法でも末尾の改行は無視します。
+func (m MatchMode) Match(answer, input string) (bool, error) {
+	answer = trimTrailingNewlines(answer)
+	input = trimTrailingNewlines(input)
+
+	switch m {
+	case MatchExact, "":
+		return input == answer, nil
+	case MatchWhitespace:
+		return NormalizeWhitespace(input) == NormalizeWhitespace(answer), nil
+	case MatchCaseInsensitive:
+		return strings.EqualFold(input, answer), nil
+	case MatchRegex:
+		re, err := CompileAnswerPattern(answer)
+		if err != nil {
+			return false, err
+		}
+		return re.MatchString(input), nil
+	default:
+		return false, fmt.Errorf("unknown match mode %q", m)
+	}
+}
+
+// CompileAnswerPattern は答えの正規表現を、回答全体と一致するように前後を固定してコンパイルします。
+func CompileAnswerPattern(pattern string) (*regexp.Regexp, error) {
+	return regexp.Compile(`^(?:` + pattern + `)$`)
+}
+
+// NormalizeWhitespace は前後の空白を除き、連続する空白・改行を1つの空白にまとめます。
+func NormalizeWhitespace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func trimTrailingNewlines(s string) string {
+	return strings.TrimRight(s, "\r\n")
+}
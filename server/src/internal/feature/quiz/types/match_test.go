@@ -0,0 +1,39 @@
+package types
+
+import "testing"
+
+func TestMatchModeMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    MatchMode
+		answer  string
+		input   string
+		want    bool
+		wantErr bool
+	}{
+		{name: "exact: 一致", mode: MatchExact, answer: "hello", input: "hello", want: true},
+		{name: "exact: 末尾の改行は無視", mode: MatchExact, answer: "1\n2\n", input: "1\n2", want: true},
+		{name: "exact: CRLF の末尾も無視", mode: MatchExact, answer: "ok", input: "ok\r\n", want: true},
+		{name: "exact: 途中の空白は区別", mode: MatchExact, answer: "a b", input: "a  b", want: false},
+		{name: "未指定は exact", answer: "Hello", input: "hello", want: false},
+		{name: "whitespace: 連続する空白と改行をまとめる", mode: MatchWhitespace, answer: "1 2\n3", input: "  1\t2 3 ", want: true},
+		{name: "whitespace: 文字の違いは不一致", mode: MatchWhitespace, answer: "1 2", input: "1 3", want: false},
+		{name: "case_insensitive: 大文字小文字を区別しない", mode: MatchCaseInsensitive, answer: "True", input: "TRUE", want: true},
+		{name: "regex: 回答全体と照合", mode: MatchRegex, answer: `0x[0-9a-f]+`, input: "0xc000012345", want: true},
+		{name: "regex: 部分一致は不一致", mode: MatchRegex, answer: `\d+`, input: "n=42", want: false},
+		{name: "regex: 選択肢の | も全体に固定", mode: MatchRegex, answer: `yes|no`, input: "nope", want: false},
+		{name: "regex: 不正なパターン", mode: MatchRegex, answer: `(`, input: "(", wantErr: true},
+		{name: "不明な照合方法", mode: "fuzzy", answer: "a", input: "a", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.mode.Match(tt.answer, tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Match() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,30 +1,432 @@
 // server/src/internal/feature/quiz/types/types.go
 package types
 
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"math/rand"
+	"strings"
+	"time"
+)
+
 // Message はクライアントとサーバー間でやり取りされるJSONメッセージの共通構造体です。
 type Message struct {
 	Type    string      `json:"type"`
 	Payload interface{} `json:"payload,omitempty"`
 	// RoomID はJSONには含めず、ハブ内部でのルーティングに使用します。
 	RoomID  string      `json:"-"`
+	// UserID が指定されている場合、ルーム内のそのユーザーにのみ送信します。
+	UserID  string      `json:"-"`
 }
 
+// QuestionKind は問題の形式です。Question は Kind を判別子とするタグ付きユニオンで、
+// 形式ごとに使用するフィールドが異なります。
+type QuestionKind string
+
+const (
+	// KindSingleChoice は選択肢から1つを選ぶ形式です（Choices, Answer を使用）。
+	KindSingleChoice QuestionKind = "single_choice"
+	// KindFreeText はプログラムの出力をそのまま入力する形式です（Answer, Match を使用）。
+	KindFreeText QuestionKind = "free_text"
+	// KindMultiSelect は当てはまる選択肢をすべて選ぶ形式です（Choices, Answers を使用）。
+	KindMultiSelect QuestionKind = "multi_select"
+	// KindOrdering はコードの行を実行順に並べ替える形式です（Choices を正しい順番で記述）。
+	KindOrdering QuestionKind = "ordering"
+)
+
+// MatchMode は自由記述の回答と答えの照合方法です。
+type MatchMode string
+
+const (
+	MatchExact           MatchMode = "exact"            // 末尾の改行を除いて完全一致
+	MatchWhitespace      MatchMode = "whitespace"       // 連続する空白・改行を1つの空白とみなして比較
+	MatchCaseInsensitive MatchMode = "case_insensitive" // 大文字・小文字を区別せずに比較
+	MatchRegex           MatchMode = "regex"            // Answer を正規表現として回答全体と照合
+)
+
 // Question は1つのクイズ問題を表す構造体です。
 type Question struct {
-	ID        string   `json:"Id"`
-	Statement string   `json:"Statement"` // 問題文
-	Choices   []string `json:"Choices"`   // 選択肢
-	Answer    string   `json:"Answer"`    // 答え
+	ID            string       `json:"Id" yaml:"id"`
+	Kind          QuestionKind `json:"Kind,omitempty" yaml:"kind,omitempty"`                   // 問題の形式（未指定の場合は single_choice）
+	Statement     string       `json:"Statement" yaml:"statement"`                             // 問題文
+	Choices       []string     `json:"Choices,omitempty" yaml:"choices,omitempty"`             // 選択肢
+	Answer        string       `json:"Answer,omitempty" yaml:"answer,omitempty"`               // 答え
+	Answers       []string     `json:"Answers,omitempty" yaml:"answers,omitempty"`             // 複数選択の正解の選択肢
+	Match         MatchMode    `json:"Match,omitempty" yaml:"match,omitempty"`                 // 自由記述の照合方法（未指定の場合は exact）
+	DisplayAnswer string       `json:"DisplayAnswer,omitempty" yaml:"displayAnswer,omitempty"` // 正解として表示する文字列（正規表現の答えなどに使用）
+
+	Language   string   `json:"Language,omitempty" yaml:"language,omitempty"`     // プログラミング言語（C, Go, Python など）
+	Difficulty string   `json:"Difficulty,omitempty" yaml:"difficulty,omitempty"` // 難易度（Easy, Normal, Hard）
+	Rating     int      `json:"Rating,omitempty" yaml:"rating,omitempty"`         // 難易度の細かい評価（1〜10。未指定の場合は Difficulty から決める）
+	Tags       []string `json:"Tags,omitempty" yaml:"tags,omitempty"`             // 出題分野のタグ
+
+	// 以下は回答締切後に公開する解説情報（任意）
+	Explanation      string            `json:"Explanation,omitempty" yaml:"explanation,omitempty"`           // 解説
+	ChoiceRationales map[string]string `json:"ChoiceRationales,omitempty" yaml:"choiceRationales,omitempty"` // 選択肢ごとの補足（Key: 選択肢の文字列）
+	References       []Reference       `json:"References,omitempty" yaml:"references,omitempty"`             // 参考リンク
+
+	// Replaces は管理APIで保存する問題が、問題ファイルの同じIDの問題を意図して置き換えることを示します。
+	// 指定しない場合、問題ファイルの問題とIDが重なるとエラーになります。
+	Replaces bool `json:"Replaces,omitempty" yaml:"replaces,omitempty"`
+}
+
+// Reference は解説の参考資料へのリンクです。
+type Reference struct {
+	Title string `json:"title" yaml:"title"`
+	URL   string `json:"url" yaml:"url"`
+}
+
+// ChoiceRationale は選択肢ごとの補足説明です。
+type ChoiceRationale struct {
+	ChoiceID  string `json:"choiceId"`
+	Choice    string `json:"choice"`
+	Rationale string `json:"rationale"`
+}
+
+// QuestionReveal は回答締切後にクライアントへ公開する解説情報です。
+type QuestionReveal struct {
+	QuestionID       string            `json:"questionId"`
+	Explanation      string            `json:"explanation,omitempty"`
+	ChoiceRationales []ChoiceRationale `json:"choiceRationales,omitempty"`
+	References       []Reference       `json:"references,omitempty"`
+}
+
+// QuestionRecord は出題済みの1問分の記録で、ゲーム終了時の振り返りに使用します。
+type QuestionRecord struct {
+	QuestionNumber int            `json:"questionNumber"`
+	Question       string         `json:"question"`
+	CorrectAnswer  string         `json:"correctAnswer"`
+	Reveal         QuestionReveal `json:"reveal"`
+	Results        []PlayerAnswer `json:"results"`
+}
+
+// AnswerMode は1問ごとの回答受付ルールです。
+type AnswerMode string
+
+const (
+	// AnswerModeFirstCorrect は最初の正解者が出た時点で締め切ります。不正解の回答では締め切りません。
+	AnswerModeFirstCorrect AnswerMode = "first_correct"
+	// AnswerModeEveryone は全員が回答するか、制限時間切れで締め切ります。
+	AnswerModeEveryone AnswerMode = "everyone"
+)
+
+// GameMode はゲームの進め方です。
+type GameMode string
+
+const (
+	// GameModeClassic は全員が最後まで回答し、得点で順位を決めます。
+	GameModeClassic GameMode = "classic"
+	// GameModeElimination は不正解・未回答のプレイヤーが脱落し、最後まで残ったプレイヤーが勝ちます。
+	GameModeElimination GameMode = "elimination"
+	// GameModeTeam はチームに分かれ、メンバーの得点の合計で競います。
+	GameModeTeam GameMode = "team"
+)
+
+// Team はゲーム中のチームです。
+type Team struct {
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	Members []string `json:"members"` // メンバーのユーザーID
+}
+
+// TeamResult はチームの得点です。Rank はゲーム終了時のみ設定します。
+type TeamResult struct {
+	TeamID  string   `json:"teamId"`
+	Name    string   `json:"name"`
+	Members []string `json:"members"`
+	Score   int      `json:"score"` // メンバーの得点の合計
+	Rank    int      `json:"rank,omitempty"`
+}
+
+// PlayerStatus はゲーム中のプレイヤーの状態です。
+type PlayerStatus string
+
+const (
+	PlayerActive     PlayerStatus = "active"     // 回答できる
+	PlayerEliminated PlayerStatus = "eliminated" // 脱落し、観戦のみ
+)
+
+// GamePhase はゲームの進行中の段階です。出題中以外の段階はルームごとのスケジューラーが時間を管理します。
+type GamePhase string
+
+const (
+	PhaseCountdown    GamePhase = "countdown"    // 最初の問題の前のカウントダウン
+	PhaseQuestion     GamePhase = "question"     // 出題中（回答受付中）
+	PhaseReveal       GamePhase = "reveal"       // 正解発表
+	PhaseIntermission GamePhase = "intermission" // 次の問題までの休憩（途中経過の順位を表示）
+)
+
+// Elimination は脱落の記録です。
+type Elimination struct {
+	UserID         string `json:"userId"`
+	QuestionNumber int    `json:"questionNumber"` // 脱落した問題の番号
+	Reason         string `json:"reason"`         // wrong_answer / no_answer
+}
+
+// ScoringInput は1回答分の得点計算に必要な情報です。
+type ScoringInput struct {
+	IsCorrect         bool
+	Credit            float64 // 部分点の割合（0〜1）。完全正解で1
+	AnsweredAt        time.Time
+	QuestionStartedAt time.Time
+	QuestionDeadline  time.Time
+	Streak            int // この回答を含めた連続正解数（不正解の場合は0）
+}
+
+// ScoreBreakdown は1回答で加算された得点の内訳です。
+type ScoreBreakdown struct {
+	Base        int `json:"base"`        // 正解による基本点
+	SpeedBonus  int `json:"speedBonus"`  // 残り時間に応じたボーナス
+	StreakBonus int `json:"streakBonus"` // 連続正解ボーナス
+	Penalty     int `json:"penalty"`     // 不正解による減点（0以下）
+	Total       int `json:"total"`       // 合計
+}
+
+// ScoringStrategy は回答の得点計算ルールです。ルームごとに選択されます。
+type ScoringStrategy interface {
+	Score(input ScoringInput) ScoreBreakdown
+}
+
+// SelectionStrategy は次に出題する問題の選び方です。ルームごとに選択されます。
+type SelectionStrategy interface {
+	// Select は出題済みでない問題 available（出題候補の順番に並ぶ）から次の問題を選びます。
+	Select(state *GameState, available []*Question) *Question
+}
+
+// Choice はクライアントに送信する選択肢です。ID は選択肢の並び順に依存しません。
+type Choice struct {
+	ID   string `json:"id"`
+	Text string `json:"text"`
+}
+
+// ChoiceID は問題IDと選択肢の文字列から安定した選択肢IDを生成します。
+// 問題バンク内で選択肢の順番を入れ替えても同じIDになります。
+func ChoiceID(questionID, text string) string {
+	sum := sha1.Sum([]byte(questionID + "\x00" + text))
+	return "c_" + hex.EncodeToString(sum[:4])
+}
+
+// QuestionKind は問題の形式を返します。未指定の場合は single_choice です。
+func (q *Question) QuestionKind() QuestionKind {
+	if q.Kind == "" {
+		return KindSingleChoice
+	}
+	return q.Kind
+}
+
+// MIN_RATING と MAX_RATING は問題の難易度評価（Rating）の範囲です。
+const (
+	MIN_RATING = 1
+	MAX_RATING = 10
+)
+
+// difficultyRatings は Rating が未指定の問題に使用する、難易度ごとの評価です。
+var difficultyRatings = map[string]int{
+	"easy":   3,
+	"normal": 5,
+	"medium": 5,
+	"hard":   8,
+}
+
+// DifficultyRating は問題の難易度評価（1〜10）を返します。
+// Rating が未指定の場合は Difficulty から決め、Difficulty も不明な場合は Normal と同じ評価とします。
+func (q *Question) DifficultyRating() int {
+	if q.Rating != 0 {
+		return q.Rating
+	}
+	return RatingForDifficulty(q.Difficulty)
+}
+
+// RatingForDifficulty は難易度（Easy, Normal, Hard）に対応する評価を返します。不明な場合は Normal の評価です。
+func RatingForDifficulty(difficulty string) int {
+	if rating, ok := difficultyRatings[strings.ToLower(difficulty)]; ok {
+		return rating
+	}
+	return difficultyRatings["normal"]
+}
+
+// CorrectAnswerText は正解としてクライアントに表示する文字列を返します。
+func (q *Question) CorrectAnswerText() string {
+	if q.DisplayAnswer != "" {
+		return q.DisplayAnswer
+	}
+	switch q.QuestionKind() {
+	case KindMultiSelect:
+		return strings.Join(q.Answers, ", ")
+	case KindOrdering:
+		return strings.Join(q.Choices, "\n")
+	default:
+		return q.Answer
+	}
+}
+
+// CorrectChoiceIDs は正解の選択肢IDを返します。並べ替え問題では正しい順番に並びます。
+// 自由記述では nil です。
+func (q *Question) CorrectChoiceIDs() []string {
+	switch q.QuestionKind() {
+	case KindSingleChoice:
+		return []string{ChoiceID(q.ID, q.Answer)}
+	case KindMultiSelect:
+		ids := make([]string, len(q.Answers))
+		for i, text := range q.Answers {
+			ids[i] = ChoiceID(q.ID, text)
+		}
+		return ids
+	case KindOrdering:
+		ids := make([]string, len(q.Choices))
+		for i, text := range q.Choices {
+			ids[i] = ChoiceID(q.ID, text)
+		}
+		return ids
+	default:
+		return nil
+	}
+}
+
+// CorrectChoiceID は選択式の問題の正解の選択肢IDを返します。選択式以外では空文字です。
+func (q *Question) CorrectChoiceID() string {
+	if q.QuestionKind() != KindSingleChoice {
+		return ""
+	}
+	return ChoiceID(q.ID, q.Answer)
+}
+
+// ChoiceList は問題バンク上の順番で選択肢の一覧を返します。
+func (q *Question) ChoiceList() []Choice {
+	choices := make([]Choice, len(q.Choices))
+	for i, text := range q.Choices {
+		choices[i] = Choice{ID: ChoiceID(q.ID, text), Text: text}
+	}
+	return choices
+}
+
+// FindChoice は選択肢IDに対応する選択肢を返します。
+func (q *Question) FindChoice(id string) (Choice, bool) {
+	for _, text := range q.Choices {
+		if ChoiceID(q.ID, text) == id {
+			return Choice{ID: id, Text: text}, true
+		}
+	}
+	return Choice{}, false
+}
+
+// Reveal は問題の解説情報を、選択肢の並び順（問題バンク上の順番）に沿って返します。
+func (q *Question) Reveal() QuestionReveal {
+	reveal := QuestionReveal{
+		QuestionID:  q.ID,
+		Explanation: q.Explanation,
+		References:  q.References,
+	}
+	for _, choice := range q.ChoiceList() {
+		if rationale, ok := q.ChoiceRationales[choice.Text]; ok {
+			reveal.ChoiceRationales = append(reveal.ChoiceRationales, ChoiceRationale{
+				ChoiceID:  choice.ID,
+				Choice:    choice.Text,
+				Rationale: rationale,
+			})
+		}
+	}
+	return reveal
+}
+
+// ShuffleMode は選択肢の並び替え方法です。
+type ShuffleMode string
+
+const (
+	ShuffleNone   ShuffleMode = "none"   // 問題バンクの順番のまま
+	ShuffleGame   ShuffleMode = "game"   // 1問ごとに全員共通の順番で並び替える
+	ShufflePlayer ShuffleMode = "player" // プレイヤーごとに異なる順番で並び替える
+)
+
+// GameSettings はルーム設定から組み立てられる、1ゲーム分の進行ルールです。
+type GameSettings struct {
+	TimeLimit        time.Duration     // 1問あたりの制限時間
+	AnswerMode       AnswerMode        // 回答受付ルール
+	Scoring          ScoringStrategy   // 得点計算ルール
+	Selection        SelectionStrategy // 出題する問題の選び方
+	Mode             GameMode          // ゲームの進め方
+	QuestionCount    int               // 1ゲームの出題数（出題可能な問題数で頭打ち）
+	Language         string            // 出題言語（空または Random の場合は全言語）
+	Difficulty       string            // 出題難易度（空の場合は全難易度）
+	Shuffle          ShuffleMode       // 選択肢の並び替え方法
+	Seed             int64             // 乱数のシード（0の場合はゲーム開始時にランダムに決める）
+	Teams            []Team            // ルームで指定されたチーム分け（team モードのみ）
+	TeamCount        int               // チームを自動で分ける場合のチーム数（team モードのみ）
+	OneAnswerPerTeam bool              // 1問につきチームで最初の1人の回答のみを受け付ける（team モードのみ）
+	HostID           string            // ゲームの進行を操作できるルームのホスト
+	Owner            string            // ルームを管理する機能（設定されている場合、ホストによる操作と再戦を受け付けない）
+	RepeatQuestions  bool              // 再戦でも前のゲームで出題した問題を除外しない
+	RevealTime       time.Duration     // 正解発表の表示時間
+	IntermissionTime time.Duration     // 正解発表の後、次の問題までの休憩時間（0の場合は休憩なし）
+	CountdownTime    time.Duration     // 最初の問題の前のカウントダウン（0の場合はすぐに出題）
+}
+
+// PlayerAnswer は1問に対するプレイヤーの回答内容です。
+type PlayerAnswer struct {
+	UserID     string          `json:"userId"`
+	Answered   bool            `json:"answered"`
+	ChoiceID   string          `json:"choiceId,omitempty"`
+	ChoiceIDs  []string        `json:"choiceIds,omitempty"`
+	Choice     string          `json:"choice,omitempty"`
+	IsCorrect  bool            `json:"isCorrect"`
+	Credit     float64         `json:"credit"`
+	Breakdown  *ScoreBreakdown `json:"breakdown,omitempty"`
+	AnsweredAt time.Time       `json:"-"`
 }
 
 // GameState は一つのルームにおける現在のゲーム状態を保持します。
 type GameState struct {
-	CurrentQuestion  *Question
-	Scores           map[string]int  // Key: UserID, Value: Score
-	AnsweredUsers    map[string]bool // この問題に回答済みのユーザー
-	QuestionNumber   int             // 現在が何問目か
-	IsQuestionActive bool            // 現在の問題が回答可能か
-	UsedQuestionIDs  []string        // 出題済み問題ID
+	Settings          GameSettings
+	CurrentQuestion   *Question
+	Scores            map[string]int          // Key: UserID, Value: Score
+	Streaks           map[string]int          // Key: UserID, Value: 連続正解数
+	Answers           map[string]PlayerAnswer // この問題の回答（Key: UserID）
+	QuestionNumber    int                     // 現在が何問目か
+	TotalQuestions    int                     // このゲームの出題数
+	IsQuestionActive  bool                    // 現在の問題が回答可能か
+	UsedQuestionIDs   []string                // 出題済み問題ID
+	QuestionPool      []*Question             // ルーム設定に合わせて抽出した出題候補
+	History           []QuestionRecord        // 締め切った問題の記録
+	QuestionStartedAt time.Time               // 現在の問題の出題時刻
+	QuestionDeadline  time.Time               // サーバー側の回答締切時刻
+	QuestionTimer     *time.Timer             // 制限時間を監視するタイマー
+	Seed              int64                   // このゲームの乱数のシード
+	Rand              *rand.Rand              // 出題と選択肢の並び替えに使用するゲーム専用の乱数（Seed から生成）
+	PlayerStatuses    map[string]PlayerStatus // ゲーム開始時のプレイヤーごとの状態（Key: UserID）
+	Eliminations      []Elimination           // 脱落した順の記録
+	Teams             []Team                  // このゲームのチーム分け（team モードのみ）
+	TeamOf            map[string]string       // プレイヤーの所属チーム（Key: UserID, Value: チームID）
+	Paused            bool                    // ホストが一時停止中か
+	PausedAt          time.Time               // 一時停止した時刻
+	RemainingTime     time.Duration           // 一時停止した時点の現在の問題または段階の残り時間
+	Phase             GamePhase               // 現在の段階
+	PhaseEndsAt       time.Time               // 現在の段階が終わる時刻（出題中を除く）
+	PhaseTimer        *time.Timer             // 現在の段階の終わりに次へ進めるタイマー（出題中を除く）
+	PhaseSeq          int                     // 段階のタイマーを設定するたびに増やす番号（取り消したタイマーの発火を無視するために使用）
+	Aborted           bool                    // ホストがゲームを中断した
+	ClosedRoster      bool                    // 開始時のプレイヤー（再戦に参加したプレイヤー）のみ回答できる
+}
+
+// Rematch は終了したゲームの再戦の受付状態です。ゲーム終了時に作成し、次のゲームの開始時に削除します。
+type Rematch struct {
+	HostID          string
+	Owner           string          // ルームを管理する機能（設定されている場合は再戦を受け付けない）
+	UsedQuestionIDs []string        // 前のゲームで出題した問題ID
+	Requested       bool            // ホストが再戦を呼びかけた
+	Accepted        map[string]bool // 再戦に参加するプレイヤー（Key: UserID）
+	Ready           bool            // 接続中の全員が参加し、ルームを待機状態に戻した
+	Players         []string        // Ready になった時点で参加していたプレイヤー（次のゲームの参加者）
+}
+
+// GameResult はゲーム終了時の結果です。ゲーム終了を購読する他の機能（デイリーチャレンジなど）に渡します。
+type GameResult struct {
+	RoomID  string
+	Seed    int64
+	Results []PlayerResult
+	History []QuestionRecord
+	Teams   []TeamResult // team モードのチームの順位
+	Aborted bool         // ホストが途中で中断した、またはルームが削除された（Results は終了時点の順位）
+	Closed  bool         // ゲームの途中でルームが削除された、または送信が追いつかずにゲームを止めた
 }
 
 // PlayerResult は最終結果のランキング表示に使用する構造体です。
@@ -33,4 +435,9 @@ type PlayerResult struct {
 	// Name     string `json:"name"` // 必要であればユーザー名も追加
 	Score    int    `json:"score"`
 	Rank     int    `json:"rank"`
+	// Status と EliminatedAt は脱落モードでのみ設定します。
+	Status       PlayerStatus `json:"status,omitempty"`
+	EliminatedAt int          `json:"eliminatedAt,omitempty"` // 脱落した問題の番号
+	// TeamID は team モードでのみ設定します。Rank はチームの順位です。
+	TeamID string `json:"teamId,omitempty"`
 }
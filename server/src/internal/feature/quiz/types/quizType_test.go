@@ -0,0 +1,68 @@
+package types
+
+import "testing"
+
+func TestChoiceID(t *testing.T) {
+	q := Question{ID: "q1", Choices: []string{"a", "b"}}
+	reordered := Question{ID: "q1", Choices: []string{"b", "a"}}
+
+	for _, choice := range q.ChoiceList() {
+		found, ok := reordered.FindChoice(choice.ID)
+		if !ok || found.Text != choice.Text {
+			t.Errorf("FindChoice(%q) = %+v, %v after reordering, want %q", choice.ID, found, ok, choice.Text)
+		}
+	}
+	if ChoiceID("q1", "a") == ChoiceID("q2", "a") {
+		t.Error("choice ids of different questions must differ")
+	}
+	if _, ok := q.FindChoice("c_unknown"); ok {
+		t.Error("FindChoice() found an unknown id")
+	}
+}
+
+func TestQuestionReveal(t *testing.T) {
+	q := Question{
+		ID:               "q1",
+		Choices:          []string{"a", "b", "c"},
+		Explanation:      "because",
+		ChoiceRationales: map[string]string{"c": "why c", "a": "why a"},
+	}
+
+	reveal := q.Reveal()
+
+	if reveal.QuestionID != "q1" || reveal.Explanation != "because" {
+		t.Errorf("Reveal() = %+v", reveal)
+	}
+	// 補足は選択肢の並び順で、補足のない選択肢は含めない
+	want := []string{"a", "c"}
+	if len(reveal.ChoiceRationales) != len(want) {
+		t.Fatalf("got %d rationales, want %d", len(reveal.ChoiceRationales), len(want))
+	}
+	for i, rationale := range reveal.ChoiceRationales {
+		if rationale.Choice != want[i] || rationale.ChoiceID != ChoiceID("q1", want[i]) {
+			t.Errorf("ChoiceRationales[%d] = %+v, want choice %q", i, rationale, want[i])
+		}
+	}
+}
+
+func TestDifficultyRating(t *testing.T) {
+	tests := []struct {
+		name     string
+		question Question
+		want     int
+	}{
+		{name: "Rating を優先", question: Question{Difficulty: "Easy", Rating: 9}, want: 9},
+		{name: "Easy", question: Question{Difficulty: "Easy"}, want: 3},
+		{name: "大文字小文字を区別しない", question: Question{Difficulty: "HARD"}, want: 8},
+		{name: "Medium は Normal と同じ", question: Question{Difficulty: "Medium"}, want: 5},
+		{name: "不明な難易度は Normal", question: Question{Difficulty: "Expert"}, want: 5},
+		{name: "未指定は Normal", question: Question{}, want: 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.question.DifficultyRating(); got != tt.want {
+				t.Errorf("DifficultyRating() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+// server/src/internal/feature/quiz/verifier/process_other.go
+//go:build !unix
+
+package verifier
+
+import "os/exec"
+
+// isolateProcessGroup はプロセスグループに対応しない環境では何もしません（制限時間を超えた場合はコマンドのプロセスのみ終了します）。
+func isolateProcessGroup(cmd *exec.Cmd) {}
+
+// killProcessGroup はプロセスグループに対応しない環境では何もしません。
+func killProcessGroup(cmd *exec.Cmd) error {
+	return nil
+}
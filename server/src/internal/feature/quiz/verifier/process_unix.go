@@ -0,0 +1,29 @@
+// server/src/internal/feature/quiz/verifier/process_unix.go
+//go:build unix
+
+package verifier
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// isolateProcessGroup はコマンドを独立したプロセスグループで起動し、制限時間を超えた場合はグループごと強制終了するよう設定します。
+// コード片が fork した子プロセスが標準出力を開いたまま残っても、実行を打ち切れるようにします。
+func isolateProcessGroup(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setpgid = true
+	cmd.Cancel = func() error {
+		return killProcessGroup(cmd)
+	}
+}
+
+// killProcessGroup はコマンドのプロセスグループ全体を SIGKILL で終了します。
+func killProcessGroup(cmd *exec.Cmd) error {
+	if cmd.Process == nil {
+		return nil
+	}
+	return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+}
@@ -0,0 +1,41 @@
+//go:build unix
+
+package verifier
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunCommandKillsProcessGroup(t *testing.T) {
+	tests := []struct {
+		name         string
+		script       string
+		timeout      time.Duration
+		wantTimedOut bool
+		wantStdout   string
+	}{
+		{name: "子プロセスが出力を開いたまま残っても打ち切る", script: "sleep 30 & echo ok", timeout: 10 * time.Second, wantStdout: "ok"},
+		{name: "制限時間を超えたら子プロセスごと終了する", script: "sleep 30 & sleep 30", timeout: 500 * time.Millisecond, wantTimedOut: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := New(Options{Timeout: tt.timeout})
+			started := time.Now()
+
+			result, err := v.runCommand(context.Background(), t.TempDir(), command{args: []string{"sh", "-c", tt.script}})
+
+			if err != nil {
+				t.Fatalf("runCommand() error = %v", err)
+			}
+			if elapsed := time.Since(started); elapsed > waitDelay+2*time.Second {
+				t.Errorf("runCommand() took %v, want the process group to be killed", elapsed)
+			}
+			if result.TimedOut != tt.wantTimedOut || strings.TrimSpace(result.Stdout) != tt.wantStdout {
+				t.Errorf("result = %+v, want timed out %v with stdout %q", result, tt.wantTimedOut, tt.wantStdout)
+			}
+		})
+	}
+}
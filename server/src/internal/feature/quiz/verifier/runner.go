@@ -0,0 +1,340 @@
+// server/src/internal/feature/quiz/verifier/runner.go
+package verifier
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// maxOutputBytes は標準出力・標準エラーの取り込み上限です。無限ループで出力し続けるコードへの対策です。
+const maxOutputBytes = 64 * 1024
+
+// waitDelay はプロセスの終了後、子プロセスが開いたままの標準出力・標準エラーを待つ時間です。
+// 超えた場合はパイプを閉じて実行を打ち切ります。
+const waitDelay = 2 * time.Second
+
+// execution はコード片を実行した結果です。
+type execution struct {
+	CompileFailed bool   // コンパイル（構文チェック）に失敗した
+	CompileOutput string // コンパイラの出力
+	TimedOut      bool   // 制限時間を超えた
+	Signal        string // シグナルで異常終了した場合のシグナル名
+	ExitCode      int
+	Stdout        string
+	Stderr        string
+}
+
+// Crashed はシグナルまたは0以外の終了コードで終了したかを返します。
+func (e *execution) Crashed() bool {
+	return e.Signal != "" || e.ExitCode != 0
+}
+
+// runner は1言語分のコンパイル・実行手順です。
+type runner struct {
+	source  string                          // ソースファイル名
+	tool    string                          // 必要なツールチェーンのコマンド名
+	compile func(tool, dir string) []string // コンパイルコマンド（不要な場合は nil を返す）。tool はツールの実行ファイル
+	run     func(tool, dir string) []string // 実行コマンド
+	// locate はツールチェーンの実体を調べます（nil の場合は PATH 上の実行ファイルとそのプレフィックスを使う）。
+	locate func(ctx context.Context, path string) (toolchain, error)
+	// compileFailed は実行時の出力からコンパイル（構文）エラーを判定します（インタプリタ言語用）。
+	compileFailed func(stderr string) bool
+	// reservedMB はランタイムが起動時に予約する仮想メモリで、ulimit -v の上限に上乗せします。
+	reservedMB int
+}
+
+// runners は言語名（小文字）ごとの実行手順です。
+var runners = map[string]runner{
+	"c": {
+		source: "main.c",
+		tool:   "gcc",
+		compile: func(tool, dir string) []string {
+			return []string{tool, "-std=c11", "-O0", "-w", "-o", filepath.Join(dir, "prog"), filepath.Join(dir, "main.c")}
+		},
+		run: func(_, dir string) []string { return []string{filepath.Join(dir, "prog")} },
+	},
+	"go": {
+		source: "main.go",
+		tool:   "go",
+		locate: locateGo,
+		compile: func(tool, dir string) []string {
+			return []string{tool, "build", "-o", filepath.Join(dir, "prog"), filepath.Join(dir, "main.go")}
+		},
+		run: func(_, dir string) []string { return []string{filepath.Join(dir, "prog")} },
+		// Go のランタイムは実際の使用量に関わらず約700MBの仮想アドレス空間を予約する
+		reservedMB: 1024,
+	},
+	"python": {
+		source: "main.py",
+		tool:   "python3",
+		locate: locatePython,
+		run:    func(tool, dir string) []string { return []string{tool, "-I", filepath.Join(dir, "main.py")} },
+		compileFailed: func(stderr string) bool {
+			return strings.Contains(stderr, "SyntaxError") || strings.Contains(stderr, "IndentationError")
+		},
+	},
+}
+
+// toolchain は PATH 上のコマンドから調べたツールチェーンの実体です。
+// サンドボックスではホームディレクトリなどが見えないため、シム（pyenv など）を経由せず実行ファイルを直接起動します。
+type toolchain struct {
+	path     string   // ツールの実行ファイル
+	roots    []string // サンドボックスに読み取り専用で見せるディレクトリ（インストール先）
+	env      []string // コンパイル・実行時に追加する環境変数
+	cacheEnv string   // ビルドキャッシュを指定する環境変数（キャッシュを使わない場合は空）
+	cacheDir string   // ビルドキャッシュのディレクトリ
+}
+
+var (
+	toolchainsMu sync.Mutex
+	toolchains   = make(map[string]toolchain) // キー: コマンド名
+)
+
+// toolchain はツールチェーンの実体を調べます。結果はプロセス内でキャッシュします。
+func (r runner) toolchain(ctx context.Context) (toolchain, error) {
+	toolchainsMu.Lock()
+	defer toolchainsMu.Unlock()
+	if tc, ok := toolchains[r.tool]; ok {
+		return tc, nil
+	}
+
+	path, err := exec.LookPath(r.tool)
+	if err != nil {
+		return toolchain{}, fmt.Errorf("toolchain %q not found: %w", r.tool, err)
+	}
+	locate := r.locate
+	if locate == nil {
+		locate = locateBinary
+	}
+	tc, err := locate(ctx, path)
+	if err != nil {
+		return toolchain{}, fmt.Errorf("locate toolchain %q: %w", r.tool, err)
+	}
+	toolchains[r.tool] = tc
+	return tc, nil
+}
+
+// locateBinary はシンボリックリンクを解決した実行ファイルと、そのインストール先（bin の親ディレクトリ）を返します。
+func locateBinary(_ context.Context, path string) (toolchain, error) {
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return toolchain{}, err
+	}
+	return toolchain{path: resolved, roots: []string{filepath.Dir(filepath.Dir(resolved))}}, nil
+}
+
+// locateGo は go env で GOROOT とビルドキャッシュを調べます。
+func locateGo(ctx context.Context, path string) (toolchain, error) {
+	out, err := exec.CommandContext(ctx, path, "env", "GOROOT", "GOCACHE").Output()
+	if err != nil {
+		return toolchain{}, err
+	}
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	if len(lines) != 2 || !filepath.IsAbs(lines[0]) {
+		return toolchain{}, fmt.Errorf("unexpected go env output %q", out)
+	}
+	goroot, cache := lines[0], strings.TrimSpace(lines[1])
+	tc := toolchain{
+		path:  filepath.Join(goroot, "bin", "go"),
+		roots: []string{goroot},
+		// go.mod がないためツールチェーンの自動ダウンロードは起きないが、念のためローカルのものに固定する
+		env: []string{"GOROOT=" + goroot, "GOTOOLCHAIN=local"},
+	}
+	if filepath.IsAbs(cache) {
+		tc.cacheEnv, tc.cacheDir = "GOCACHE", cache
+	}
+	return tc, nil
+}
+
+// locatePython はシムを経由せずに起動できるよう、インタプリタ自身に実行ファイルとインストール先を問い合わせます。
+func locatePython(ctx context.Context, path string) (toolchain, error) {
+	out, err := exec.CommandContext(ctx, path, "-I", "-c", "import sys; print(sys.executable); print(sys.base_prefix)").Output()
+	if err != nil {
+		return toolchain{}, err
+	}
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	if len(lines) != 2 || !filepath.IsAbs(lines[0]) || !filepath.IsAbs(lines[1]) {
+		return toolchain{}, fmt.Errorf("unexpected python output %q", out)
+	}
+	return toolchain{path: lines[0], roots: []string{lines[1]}}, nil
+}
+
+// execute はコード片を一時ディレクトリに書き出し、コンパイルして実行します。
+// 実行時は標準入力を空にし、制限時間とメモリ上限（ulimit -v）を適用します。
+func (v *Verifier) execute(ctx context.Context, r runner, code string) (*execution, error) {
+	tc, err := r.toolchain(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := os.MkdirTemp("", "coderush-verify-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	if err := os.WriteFile(filepath.Join(dir, r.source), []byte(code), 0o600); err != nil {
+		return nil, err
+	}
+
+	if r.compile != nil {
+		// コンパイラ自体はメモリを多く使うため、メモリ上限は実行時のみ適用する
+		c := command{args: r.compile(tc.path, dir), env: tc.env, readOnly: tc.roots}
+		if tc.cacheEnv != "" {
+			cache, err := v.cacheDir(tc.cacheDir)
+			if err != nil {
+				return nil, err
+			}
+			c.env = append(c.env, tc.cacheEnv+"="+cache)
+			c.writable = []string{cache}
+		}
+		result, err := v.runCommand(ctx, dir, c)
+		if err != nil {
+			return nil, err
+		}
+		if result.TimedOut {
+			return nil, errors.New("compilation timed out")
+		}
+		if result.Crashed() {
+			return &execution{CompileFailed: true, CompileOutput: result.Stderr + result.Stdout}, nil
+		}
+	}
+
+	memoryLimitMB := v.opts.MemoryLimitMB
+	if memoryLimitMB > 0 {
+		memoryLimitMB += r.reservedMB
+	}
+	result, err := v.runCommand(ctx, dir, command{args: r.run(tc.path, dir), env: tc.env, readOnly: tc.roots, memoryLimitMB: memoryLimitMB})
+	if err != nil {
+		return nil, err
+	}
+	if r.compileFailed != nil && result.Crashed() && r.compileFailed(result.Stderr) {
+		return &execution{CompileFailed: true, CompileOutput: result.Stderr}, nil
+	}
+	return result, nil
+}
+
+// command は作業ディレクトリで実行する1つのコマンドです。
+type command struct {
+	args          []string
+	env           []string // sandboxEnv に追加する環境変数
+	memoryLimitMB int      // 仮想メモリの上限（0 の場合は無制限）
+	readOnly      []string // サンドボックスに読み取り専用で見せるディレクトリ（作業ディレクトリとシステムのディレクトリ以外）
+	writable      []string // サンドボックスに書き込み可能で見せるディレクトリ（作業ディレクトリ以外）
+}
+
+// runCommand はコマンドを制限時間付きで実行します。memoryLimitMB が0より大きい場合は
+// sh の ulimit で仮想メモリの上限を設定してから exec します。
+// Options.Unsandboxed でない場合はサンドボックス（sandbox_linux.go）の中で実行します。
+// コマンドは独立したプロセスグループで起動し、終了後（制限時間を超えた場合を含む）にグループに残ったプロセスもすべて終了します。
+func (v *Verifier) runCommand(ctx context.Context, dir string, c command) (*execution, error) {
+	ctx, cancel := context.WithTimeout(ctx, v.opts.Timeout)
+	defer cancel()
+
+	args := c.args
+	if c.memoryLimitMB > 0 {
+		script := fmt.Sprintf(`ulimit -v %d 2>/dev/null; exec "$@"`, c.memoryLimitMB*1024)
+		args = append([]string{"sh", "-c", script, "sh"}, args...)
+	}
+
+	var cmd *exec.Cmd
+	if v.opts.Unsandboxed {
+		cmd = exec.CommandContext(ctx, args[0], args[1:]...)
+	} else {
+		var cleanup func()
+		var err error
+		cmd, cleanup, err = sandboxCommand(ctx, dir, args, c)
+		if err != nil {
+			return nil, err
+		}
+		defer cleanup()
+	}
+	cmd.Dir = dir
+	cmd.Env = append(sandboxEnv(dir), c.env...)
+	cmd.Stdin = nil
+	stdout := &limitedBuffer{limit: maxOutputBytes}
+	stderr := &limitedBuffer{limit: maxOutputBytes}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	cmd.WaitDelay = waitDelay
+	isolateProcessGroup(cmd)
+
+	err := cmd.Run()
+	killProcessGroup(cmd) // バックグラウンドに残った子プロセスを片付ける
+	result := &execution{Stdout: stdout.String(), Stderr: stderr.String()}
+	if ctx.Err() == context.DeadlineExceeded {
+		result.TimedOut = true
+		return result, nil
+	}
+
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil, errors.Is(err, exec.ErrWaitDelay):
+		// 終了後も子プロセスが出力を開いていた場合は、そこまでの出力で判定する
+	case errors.As(err, &exitErr):
+		result.ExitCode = exitErr.ExitCode()
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+			result.Signal = status.Signal().String()
+		}
+	default:
+		if !v.opts.Unsandboxed {
+			return nil, fmt.Errorf("start sandbox (user namespaces may be unavailable): %w", err)
+		}
+		return nil, err
+	}
+	if !v.opts.Unsandboxed {
+		if err := sandboxFailure(result); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// cacheDir はコンパイル時に書き込み可能にするビルドキャッシュのディレクトリを返します。
+func (v *Verifier) cacheDir(dir string) (string, error) {
+	if v.opts.Unsandboxed {
+		return dir, nil
+	}
+	return sandboxCacheDir(dir)
+}
+
+// sandboxEnv は子プロセスに渡す最小限の環境変数を返します。サーバーの環境変数（秘密鍵など）は引き継ぎません。
+// ツールチェーン固有の環境変数（GOROOT、ビルドキャッシュなど）は toolchain.env で追加します。
+func sandboxEnv(dir string) []string {
+	return []string{
+		"PATH=" + os.Getenv("PATH"),
+		"HOME=" + dir,
+		"TMPDIR=" + dir,
+		"LANG=C",
+	}
+}
+
+// limitedBuffer は上限を超えた書き込みを黙って捨てるバッファです。
+type limitedBuffer struct {
+	buf   bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if remaining := b.limit - b.buf.Len(); remaining > 0 {
+		if len(p) > remaining {
+			b.buf.Write(p[:remaining])
+		} else {
+			b.buf.Write(p)
+		}
+	}
+	return len(p), nil
+}
+
+func (b *limitedBuffer) String() string {
+	return b.buf.String()
+}
@@ -0,0 +1,442 @@
+// server/src/internal/feature/quiz/verifier/sandbox_linux.go
+//go:build linux
+
+package verifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"syscall"
+)
+
+// サンドボックスの初期化プロセスとして起動されたときの argv[0] です。
+const sandboxInitArg = "coderush-verifier-sandbox"
+
+// 初期化に失敗した場合の終了コードと標準エラーの接頭辞です。コード片自身の失敗と区別するために使います。
+const (
+	sandboxFailedExit  = 125
+	sandboxErrorPrefix = "verifier sandbox: "
+)
+
+// sandboxUID, sandboxGID はサンドボックス内でコード片を実行するユーザーです（nobody）。
+const (
+	sandboxUID = 65534
+	sandboxGID = 65534
+)
+
+// 初期化プロセスに渡すケーパビリティ（ユーザー名前空間内でのみ有効）
+const (
+	capSetgid   = 6
+	capSetuid   = 7
+	capSysAdmin = 21
+)
+
+const (
+	prSetNoNewPrivs       = 38
+	prCapAmbient          = 47
+	prCapAmbientClearAll  = 4
+	sandboxTmpfsOptions   = "mode=0755,size=64m"
+	sandboxOldRootDirName = ".oldroot"
+)
+
+// sandboxSystemPaths はサンドボックスに読み取り専用で見せるシステムのパスです。存在しないものは無視します。
+// /etc はサーバーの設定や秘密情報を含むことがあるため、動的リンクに必要なものだけを見せます。
+var sandboxSystemPaths = []string{
+	"/usr", "/bin", "/sbin", "/lib", "/lib32", "/lib64", "/libx32",
+	"/etc/alternatives", "/etc/ld.so.cache", "/etc/ld.so.conf", "/etc/ld.so.conf.d", "/etc/localtime",
+}
+
+// sandboxDevices はサンドボックスの /dev に見せるデバイスです。
+var sandboxDevices = []string{"/dev/null", "/dev/zero", "/dev/full", "/dev/random", "/dev/urandom"}
+
+// 読み取り専用で再マウントするときに引き継ぐ必要があるフラグ（statfs の ST_* と MS_* の対応）。
+// ユーザー名前空間では、元のマウントのこれらのフラグを外すことができません。
+var lockedMountFlags = []struct{ st, ms uintptr }{
+	{0x2, syscall.MS_NOSUID},
+	{0x4, syscall.MS_NODEV},
+	{0x8, syscall.MS_NOEXEC},
+	{0x400, syscall.MS_NOATIME},
+	{0x800, syscall.MS_NODIRATIME},
+	{0x1000, syscall.MS_RELATIME},
+}
+
+// init はサンドボックスの初期化プロセスとして起動された場合に、サンドボックスを組み立ててコマンドを exec します。
+// 実行ファイル自身（/proc/self/exe）を再実行するため、検証を行うプロセス（サーバー、CLI、テスト）のどれでも動作します。
+func init() {
+	if len(os.Args) == 0 || os.Args[0] != sandboxInitArg {
+		return
+	}
+	err := runSandboxInit(os.Args[1:])
+	fmt.Fprintf(os.Stderr, "%s%v\n", sandboxErrorPrefix, err)
+	os.Exit(sandboxFailedExit)
+}
+
+// sandboxCommand はコマンドをサンドボックスの中で実行する exec.Cmd を組み立てます。
+//
+// サンドボックスは新しいユーザー・マウント・ネットワーク・PID・IPC・UTS 名前空間で、次のように隔離します。
+//   - ネットワークはループバックも含めて使えない
+//   - ファイルシステムは、システムのディレクトリ（/usr など）とツールチェーンを読み取り専用で、
+//     作業ディレクトリ（とコンパイル時のビルドキャッシュ）のみを書き込み可能で見せる。ホームディレクトリや
+//     サーバーの設定ファイル、ホストの /proc は見えない
+//   - コード片は nobody（サーバーが root で動いている場合はホストでも nobody）として、
+//     ケーパビリティなし、no_new_privs で実行する
+//
+// seccomp によるシステムコールの制限は行いません。カーネルの脆弱性を突くコードに対しては、コンテナや VM で隔離してください。
+// ユーザー名前空間を使えない環境では起動に失敗します（Options.Unsandboxed で無効にできます）。
+func sandboxCommand(ctx context.Context, dir string, args []string, c command) (*exec.Cmd, func(), error) {
+	uid, gid, switchUser := sandboxIDs()
+	if switchUser {
+		if err := chownTree(dir, sandboxUID, sandboxGID); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// 新しいルートのマウントポイント。中身はサンドボックス内で tmpfs に置き換わる
+	root, err := os.MkdirTemp("", "coderush-sandbox-*")
+	if err != nil {
+		return nil, nil, err
+	}
+	cleanup := func() { os.Remove(root) }
+
+	initArgs := []string{sandboxInitArg, "-root", root, "-dir", dir}
+	for _, p := range c.readOnly {
+		initArgs = append(initArgs, "-ro", p)
+	}
+	for _, p := range c.writable {
+		initArgs = append(initArgs, "-rw", p)
+	}
+	if switchUser {
+		initArgs = append(initArgs, "-setuid")
+	}
+	initArgs = append(append(initArgs, "--"), args...)
+
+	uidMappings := []syscall.SysProcIDMap{{ContainerID: sandboxUID, HostID: uid, Size: 1}}
+	gidMappings := []syscall.SysProcIDMap{{ContainerID: sandboxGID, HostID: gid, Size: 1}}
+	if switchUser {
+		// 初期化プロセス（root）がファイルを作れるよう root も対応付ける。exec の前に nobody に切り替える
+		uidMappings = append(uidMappings, syscall.SysProcIDMap{ContainerID: 0, HostID: 0, Size: 1})
+		gidMappings = append(gidMappings, syscall.SysProcIDMap{ContainerID: 0, HostID: 0, Size: 1})
+	}
+
+	cmd := exec.CommandContext(ctx, "/proc/self/exe")
+	cmd.Args = initArgs
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Cloneflags: syscall.CLONE_NEWUSER | syscall.CLONE_NEWNS | syscall.CLONE_NEWNET |
+			syscall.CLONE_NEWPID | syscall.CLONE_NEWIPC | syscall.CLONE_NEWUTS,
+		UidMappings:                uidMappings,
+		GidMappings:                gidMappings,
+		GidMappingsEnableSetgroups: switchUser,
+		AmbientCaps:                []uintptr{capSysAdmin, capSetuid, capSetgid},
+	}
+	return cmd, cleanup, nil
+}
+
+// sandboxIDs はサンドボックスのユーザーに対応付けるホストのユーザーを返します。
+// 特権のないプロセスは自分自身しか対応付けられないため、root で動いている場合のみホストの nobody に切り替えます。
+func sandboxIDs() (uid, gid int, switchUser bool) {
+	if os.Getuid() == 0 {
+		return sandboxUID, sandboxGID, true
+	}
+	return os.Getuid(), os.Getgid(), false
+}
+
+// sandboxCacheDir はサンドボックスから書き込むビルドキャッシュのディレクトリを用意します。
+// nobody に切り替える場合は、サーバーのキャッシュを書き換えられないよう専用のディレクトリを使います
+// （初回のコンパイルは標準ライブラリのビルドを含むため時間がかかります）。
+func sandboxCacheDir(dir string) (string, error) {
+	if _, _, switchUser := sandboxIDs(); !switchUser {
+		return dir, os.MkdirAll(dir, 0o755)
+	}
+	dedicated := filepath.Join(filepath.Dir(dir), "coderush-verify-"+filepath.Base(dir))
+	if err := os.MkdirAll(dedicated, 0o700); err != nil {
+		return "", err
+	}
+	return dedicated, os.Chown(dedicated, sandboxUID, sandboxGID)
+}
+
+// sandboxFailure はサンドボックスの初期化に失敗していた場合にそのエラーを返します。
+func sandboxFailure(result *execution) error {
+	if result.ExitCode != sandboxFailedExit || !strings.HasPrefix(result.Stderr, sandboxErrorPrefix) {
+		return nil
+	}
+	return errors.New(strings.TrimSpace(result.Stderr))
+}
+
+func chownTree(dir string, uid, gid int) error {
+	return filepath.WalkDir(dir, func(path string, _ fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		return os.Lchown(path, uid, gid)
+	})
+}
+
+// sandboxSpec は初期化プロセスの引数です。
+type sandboxSpec struct {
+	root     string // 新しいルートのマウントポイント
+	dir      string // 作業ディレクトリ
+	readOnly []string
+	writable []string
+	setuid   bool // nobody に切り替える
+	args     []string
+}
+
+func parseSandboxArgs(args []string) (sandboxSpec, error) {
+	var spec sandboxSpec
+	for len(args) > 0 {
+		flag := args[0]
+		args = args[1:]
+		switch flag {
+		case "--":
+			spec.args = args
+			args = nil
+		case "-setuid":
+			spec.setuid = true
+		case "-root", "-dir", "-ro", "-rw":
+			if len(args) == 0 || !filepath.IsAbs(args[0]) {
+				return spec, fmt.Errorf("flag %s needs an absolute path", flag)
+			}
+			path := filepath.Clean(args[0])
+			args = args[1:]
+			switch flag {
+			case "-root":
+				spec.root = path
+			case "-dir":
+				spec.dir = path
+			case "-ro":
+				spec.readOnly = append(spec.readOnly, path)
+			case "-rw":
+				spec.writable = append(spec.writable, path)
+			}
+		default:
+			return spec, fmt.Errorf("unknown flag %q", flag)
+		}
+	}
+	if spec.root == "" || spec.dir == "" || len(spec.args) == 0 {
+		return spec, errors.New("root, dir and command are required")
+	}
+	return spec, nil
+}
+
+// runSandboxInit はサンドボックスのファイルシステムを組み立て、権限を落としてコマンドを exec します。戻るのは失敗した場合のみです。
+func runSandboxInit(args []string) error {
+	// no_new_privs とケーパビリティはスレッドごとの属性のため、exec するスレッドで設定する
+	runtime.LockOSThread()
+
+	spec, err := parseSandboxArgs(args)
+	if err != nil {
+		return err
+	}
+	if err := buildSandboxRoot(spec); err != nil {
+		return err
+	}
+	if err := enterSandboxRoot(spec.root); err != nil {
+		return err
+	}
+
+	if spec.setuid {
+		if err := syscall.Setgroups(nil); err != nil {
+			return fmt.Errorf("setgroups: %w", err)
+		}
+		if err := syscall.Setresgid(sandboxGID, sandboxGID, sandboxGID); err != nil {
+			return fmt.Errorf("setresgid: %w", err)
+		}
+		if err := syscall.Setresuid(sandboxUID, sandboxUID, sandboxUID); err != nil {
+			return fmt.Errorf("setresuid: %w", err)
+		}
+	}
+	if err := prctl(prSetNoNewPrivs, 1, 0); err != nil {
+		return fmt.Errorf("set no_new_privs: %w", err)
+	}
+	if err := prctl(prCapAmbient, prCapAmbientClearAll, 0); err != nil {
+		return fmt.Errorf("clear ambient capabilities: %w", err)
+	}
+	if err := os.Chdir(spec.dir); err != nil {
+		return err
+	}
+
+	path, err := exec.LookPath(spec.args[0])
+	if err != nil {
+		return err
+	}
+	return syscall.Exec(path, spec.args, os.Environ())
+}
+
+// buildSandboxRoot は spec.root に tmpfs を作り、見せるパスだけをバインドマウントします。
+func buildSandboxRoot(spec sandboxSpec) error {
+	// ホストのマウント名前空間に変更が伝播しないようにする
+	if err := syscall.Mount("", "/", "", syscall.MS_REC|syscall.MS_PRIVATE, ""); err != nil {
+		return fmt.Errorf("make mounts private: %w", err)
+	}
+	root := spec.root
+	if err := syscall.Mount("tmpfs", root, "tmpfs", syscall.MS_NOSUID|syscall.MS_NODEV, sandboxTmpfsOptions); err != nil {
+		return fmt.Errorf("mount root: %w", err)
+	}
+	// 作業ディレクトリは通常 /tmp の下にあるため、先に /tmp を作っておく
+	for _, dir := range []string{"tmp", "dev", "proc"} {
+		if err := os.Mkdir(filepath.Join(root, dir), 0o755); err != nil {
+			return err
+		}
+	}
+	if err := syscall.Mount("tmpfs", filepath.Join(root, "tmp"), "tmpfs", syscall.MS_NOSUID|syscall.MS_NODEV, sandboxTmpfsOptions); err != nil {
+		return fmt.Errorf("mount /tmp: %w", err)
+	}
+	if err := os.Chmod(filepath.Join(root, "tmp"), 0o1777); err != nil {
+		return err
+	}
+
+	for _, p := range sandboxSystemPaths {
+		if err := bindSystemPath(root, p); err != nil {
+			return err
+		}
+	}
+
+	type bind struct {
+		path  string
+		flags uintptr
+	}
+	binds := []bind{{spec.dir, syscall.MS_NOSUID | syscall.MS_NODEV}}
+	for _, p := range spec.writable {
+		binds = append(binds, bind{p, syscall.MS_NOSUID | syscall.MS_NODEV})
+	}
+	for _, p := range spec.readOnly {
+		if !coveredBySystemPath(p) {
+			binds = append(binds, bind{p, syscall.MS_RDONLY | syscall.MS_NOSUID | syscall.MS_NODEV})
+		}
+	}
+	// 親ディレクトリを先にマウントする
+	sort.Slice(binds, func(i, j int) bool { return binds[i].path < binds[j].path })
+	for _, b := range binds {
+		if err := bindMount(root, b.path, b.flags); err != nil {
+			return err
+		}
+	}
+
+	for _, dev := range sandboxDevices {
+		if err := bindMount(root, dev, syscall.MS_NOSUID|syscall.MS_NOEXEC); err != nil {
+			return err
+		}
+	}
+	for name, target := range map[string]string{"fd": "/proc/self/fd", "stdin": "/proc/self/fd/0", "stdout": "/proc/self/fd/1", "stderr": "/proc/self/fd/2"} {
+		if err := os.Symlink(target, filepath.Join(root, "dev", name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bindSystemPath はシステムのパスを読み取り専用で見せます。シンボリックリンク（/bin -> usr/bin など）はそのまま作り直します。
+func bindSystemPath(root, path string) error {
+	fi, err := os.Lstat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if fi.Mode()&fs.ModeSymlink == 0 {
+		return bindMount(root, path, syscall.MS_RDONLY|syscall.MS_NOSUID|syscall.MS_NODEV)
+	}
+	link, err := os.Readlink(path)
+	if err != nil {
+		return err
+	}
+	target := filepath.Join(root, path)
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+	return os.Symlink(link, target)
+}
+
+// coveredBySystemPath はパスがシステムのパスの下にあり、すでに見えているかを返します。
+func coveredBySystemPath(path string) bool {
+	for _, p := range sandboxSystemPaths {
+		if path == p || strings.HasPrefix(path, p+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// bindMount はホストの path を新しいルートの同じパスにバインドマウントし、flags を付けて再マウントします。
+func bindMount(root, path string, flags uintptr) error {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	target := filepath.Join(root, path)
+	if fi.IsDir() {
+		if err := os.MkdirAll(target, 0o755); err != nil {
+			return err
+		}
+	} else {
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY, 0o644)
+		if err != nil {
+			return err
+		}
+		f.Close()
+	}
+
+	if err := syscall.Mount(path, target, "", syscall.MS_BIND|syscall.MS_REC, ""); err != nil {
+		return fmt.Errorf("bind %s: %w", path, err)
+	}
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(target, &st); err != nil {
+		return err
+	}
+	for _, f := range lockedMountFlags {
+		if uintptr(st.Flags)&f.st != 0 {
+			flags |= f.ms
+		}
+	}
+	if err := syscall.Mount("", target, "", syscall.MS_BIND|syscall.MS_REMOUNT|flags, ""); err != nil {
+		return fmt.Errorf("remount %s: %w", path, err)
+	}
+	return nil
+}
+
+// enterSandboxRoot は新しいルートに pivot_root し、元のルートを切り離して読み取り専用にします。
+func enterSandboxRoot(root string) error {
+	oldRoot := filepath.Join(root, sandboxOldRootDirName)
+	if err := os.Mkdir(oldRoot, 0o700); err != nil {
+		return err
+	}
+	if err := syscall.PivotRoot(root, oldRoot); err != nil {
+		return fmt.Errorf("pivot_root: %w", err)
+	}
+	if err := os.Chdir("/"); err != nil {
+		return err
+	}
+	// 新しい PID 名前空間の /proc。コンテナ内などで許可されない場合は空のままにし、ホストの /proc は見せない
+	_ = syscall.Mount("proc", "/proc", "proc", syscall.MS_NOSUID|syscall.MS_NODEV|syscall.MS_NOEXEC, "")
+
+	oldRoot = "/" + sandboxOldRootDirName
+	if err := syscall.Unmount(oldRoot, syscall.MNT_DETACH); err != nil {
+		return fmt.Errorf("unmount old root: %w", err)
+	}
+	if err := os.Remove(oldRoot); err != nil {
+		return err
+	}
+	if err := syscall.Mount("", "/", "", syscall.MS_BIND|syscall.MS_REMOUNT|syscall.MS_RDONLY|syscall.MS_NOSUID|syscall.MS_NODEV, ""); err != nil {
+		return fmt.Errorf("remount root read-only: %w", err)
+	}
+	return nil
+}
+
+func prctl(option, arg2, arg3 uintptr) error {
+	if _, _, errno := syscall.RawSyscall6(syscall.SYS_PRCTL, option, arg2, arg3, 0, 0, 0); errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+//go:build linux
+
+package verifier
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"server/src/internal/feature/quiz/types"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// requireSandbox はユーザー名前空間を使えない環境ではサンドボックスのテストをスキップします。
+func requireSandbox(t *testing.T) {
+	t.Helper()
+	v := New(Options{Timeout: 10 * time.Second})
+	if _, err := v.runCommand(context.Background(), t.TempDir(), command{args: []string{"true"}}); err != nil {
+		t.Skipf("sandbox is not available: %v", err)
+	}
+}
+
+func TestSandbox(t *testing.T) {
+	requireSandbox(t)
+	secret := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(secret, []byte("token"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		script     string
+		wantExit0  bool
+		wantStdout string
+	}{
+		{name: "作業ディレクトリには書き込める", script: "echo ok > out && cat out", wantExit0: true, wantStdout: "ok"},
+		{name: "nobody として実行する", script: "id -u", wantExit0: true, wantStdout: strconv.Itoa(sandboxUID)},
+		{name: "システムのディレクトリには書き込めない", script: "echo x > /usr/coderush-sandbox-test"},
+		{name: "ルートには書き込めない", script: "echo x > /coderush-sandbox-test"},
+		{name: "作業ディレクトリ外のファイルは見えない", script: "cat " + secret},
+		{name: "ホームディレクトリは見えない", script: "ls " + home},
+	}
+	v := New(Options{Timeout: 10 * time.Second})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := v.runCommand(context.Background(), t.TempDir(), command{args: []string{"sh", "-c", tt.script}})
+
+			if err != nil {
+				t.Fatalf("runCommand() error = %v", err)
+			}
+			if (result.ExitCode == 0) != tt.wantExit0 || strings.TrimSpace(result.Stdout) != tt.wantStdout {
+				t.Errorf("result = %+v, want exit 0 %v with stdout %q", result, tt.wantExit0, tt.wantStdout)
+			}
+		})
+	}
+}
+
+func TestSandboxNetwork(t *testing.T) {
+	requireSandbox(t)
+	requireTool(t, "python3")
+	v := New(Options{Timeout: 10 * time.Second})
+	code := "import socket\ntry:\n    socket.create_connection(('1.1.1.1', 53), timeout=2)\n    print('connected')\nexcept OSError:\n    print('unreachable')"
+	q := types.Question{ID: "py-net", Kind: types.KindFreeText, Language: "Python", Statement: code, Answer: "unreachable"}
+
+	result := v.Verify(context.Background(), &q)
+
+	if result.Status != StatusOK {
+		t.Errorf("Verify() = %+v, want the network to be unreachable", result)
+	}
+}
@@ -0,0 +1,25 @@
+// server/src/internal/feature/quiz/verifier/sandbox_other.go
+//go:build !linux
+
+package verifier
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+)
+
+// sandboxCommand はサンドボックスに対応しない環境ではエラーを返します（Options.Unsandboxed で無効にできます）。
+func sandboxCommand(ctx context.Context, dir string, args []string, c command) (*exec.Cmd, func(), error) {
+	return nil, nil, errors.New("sandbox is only supported on Linux")
+}
+
+// sandboxCacheDir はサンドボックスに対応しない環境ではキャッシュのディレクトリをそのまま返します。
+func sandboxCacheDir(dir string) (string, error) {
+	return dir, nil
+}
+
+// sandboxFailure はサンドボックスに対応しない環境では何もしません。
+func sandboxFailure(result *execution) error {
+	return nil
+}
@@ -0,0 +1,231 @@
+// server/src/internal/feature/quiz/verifier/verifier.go
+// 問題のコード片を実際にコンパイル・実行し、答えが正しいかを検証します。
+//
+// コード片は制限時間とメモリ上限を適用し、Linux の名前空間によるサンドボックス（sandbox_linux.go）の中で実行します。
+// サンドボックスからはネットワークに接続できず、作業ディレクトリ以外のファイルは読み取り専用のシステムのディレクトリと
+// ツールチェーンしか見えません。
+package verifier
+
+import (
+	"context"
+	"fmt"
+	"server/src/internal/feature/quiz/types"
+	"strings"
+	"time"
+)
+
+// Status は1問分の検証結果の種別です。
+type Status string
+
+const (
+	StatusOK       Status = "ok"       // 実行結果が答えと一致した
+	StatusMismatch Status = "mismatch" // 実行結果が答えと一致しなかった
+	StatusSkipped  Status = "skipped"  // 実行では検証できない（未定義動作、未対応の言語や形式など）
+	StatusError    Status = "error"    // ツールチェーンがない、実行環境のエラーなど
+)
+
+// Result は1問分の検証結果です。
+type Result struct {
+	QuestionID string `json:"questionId"`
+	Status     Status `json:"status"`
+	Expected   string `json:"expected,omitempty"`
+	Actual     string `json:"actual,omitempty"`
+	Detail     string `json:"detail,omitempty"`
+}
+
+// Options は検証時の制限です。
+type Options struct {
+	Timeout       time.Duration // コンパイル・実行それぞれの制限時間
+	MemoryLimitMB int           // 実行時の仮想メモリ上限（0 の場合は無制限）
+	// Unsandboxed はサンドボックスを使わず、サーバーと同じ権限で実行します。
+	// ユーザー名前空間を使えない環境向けで、信頼できる問題バンクの検証にのみ使用してください。
+	Unsandboxed bool
+}
+
+// DefaultOptions は CLI と読み込み時チェックで共通の既定値です。
+var DefaultOptions = Options{
+	Timeout:       10 * time.Second,
+	MemoryLimitMB: 512,
+}
+
+// Verifier は問題の答えを実行結果と照合します。
+type Verifier struct {
+	opts Options
+}
+
+// New は新しい Verifier を生成します。
+func New(opts Options) *Verifier {
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultOptions.Timeout
+	}
+	return &Verifier{opts: opts}
+}
+
+// VerifyAll はすべての問題を順番に検証します。ctx が取り消された場合は、そこまでの結果を返します。
+func (v *Verifier) VerifyAll(ctx context.Context, questions []types.Question) []Result {
+	results := make([]Result, 0, len(questions))
+	for i := range questions {
+		if ctx.Err() != nil {
+			break
+		}
+		results = append(results, v.Verify(ctx, &questions[i]))
+	}
+	return results
+}
+
+// Verify は1問を検証します。
+func (v *Verifier) Verify(ctx context.Context, q *types.Question) Result {
+	result := Result{QuestionID: q.ID}
+
+	r, ok := runners[strings.ToLower(q.Language)]
+	if !ok {
+		result.Status = StatusSkipped
+		result.Detail = fmt.Sprintf("language %q is not supported", q.Language)
+		return result
+	}
+
+	expect, ok := expectationFor(q)
+	if !ok {
+		result.Status = StatusSkipped
+		result.Detail = fmt.Sprintf("answer of %s question cannot be verified by execution", q.QuestionKind())
+		return result
+	}
+	result.Expected = expect.describe()
+
+	exec, err := v.execute(ctx, r, q.Statement)
+	if err != nil {
+		result.Status = StatusError
+		result.Detail = err.Error()
+		return result
+	}
+
+	result.Actual = describeExecution(exec)
+	if expect.matches(exec) {
+		result.Status = StatusOK
+	} else {
+		result.Status = StatusMismatch
+	}
+	return result
+}
+
+// outcome は期待する実行結果の種類です。
+type outcome int
+
+const (
+	outcomeOutput       outcome = iota // 正常終了して標準出力が一致する
+	outcomeCompileError                // コンパイルエラーになる
+	outcomeCrash                       // シグナル（セグメンテーション違反など）で異常終了する
+	outcomeRuntimeError                // 0以外の終了コードで終了する（パニック、例外など）
+)
+
+// expectation は問題の答えから導いた、期待する実行結果です。
+type expectation struct {
+	outcome outcome
+	match   func(stdout string) bool
+	text    string
+}
+
+// crashAnswers は実行結果ではなく終了のしかたを表す答えです。
+var crashAnswers = map[string]outcome{
+	"segmentation fault": outcomeCrash,
+	"segfault":           outcomeCrash,
+	"bus error":          outcomeCrash,
+	"compilation error":  outcomeCompileError,
+	"compile error":      outcomeCompileError,
+	"runtime error":      outcomeRuntimeError,
+	"panic":              outcomeRuntimeError,
+	"exception":          outcomeRuntimeError,
+}
+
+// unverifiableAnswers は実行しても正しさを確認できない答えです。
+var unverifiableAnswers = map[string]bool{
+	"undefined behavior":     true,
+	"undefined behaviour":    true,
+	"implementation-defined": true,
+	"implementation defined": true,
+	"unspecified behavior":   true,
+}
+
+// expectationFor は問題の形式と答えから期待する実行結果を組み立てます。
+// 実行では検証できない場合は false を返します。
+func expectationFor(q *types.Question) (expectation, bool) {
+	switch q.QuestionKind() {
+	case types.KindSingleChoice:
+		key := strings.ToLower(strings.TrimSpace(q.Answer))
+		if unverifiableAnswers[key] {
+			return expectation{}, false
+		}
+		if o, ok := crashAnswers[key]; ok {
+			return expectation{outcome: o, text: q.Answer}, true
+		}
+		return outputExpectation(q.Answer, func(stdout string) bool {
+			return types.NormalizeWhitespace(stdout) == types.NormalizeWhitespace(q.Answer)
+		}), true
+	case types.KindFreeText:
+		return outputExpectation(q.Answer, func(stdout string) bool {
+			ok, err := q.Match.Match(q.Answer, stdout)
+			return err == nil && ok
+		}), true
+	case types.KindOrdering:
+		want := strings.Join(q.Choices, "\n")
+		return outputExpectation(want, func(stdout string) bool {
+			return strings.TrimRight(stdout, "\r\n") == want
+		}), true
+	default:
+		return expectation{}, false
+	}
+}
+
+func outputExpectation(text string, match func(string) bool) expectation {
+	return expectation{outcome: outcomeOutput, text: text, match: match}
+}
+
+func (e expectation) describe() string {
+	switch e.outcome {
+	case outcomeCompileError:
+		return "<compile error>"
+	case outcomeCrash:
+		return "<crash: " + e.text + ">"
+	case outcomeRuntimeError:
+		return "<runtime error>"
+	default:
+		return e.text
+	}
+}
+
+func (e expectation) matches(exec *execution) bool {
+	switch e.outcome {
+	case outcomeCompileError:
+		return exec.CompileFailed
+	case outcomeCrash:
+		return !exec.CompileFailed && exec.Signal != ""
+	case outcomeRuntimeError:
+		return !exec.CompileFailed && !exec.TimedOut && exec.Crashed()
+	default:
+		return !exec.CompileFailed && !exec.TimedOut && !exec.Crashed() && e.match(exec.Stdout)
+	}
+}
+
+// describeExecution は実行結果を人が読める形に要約します。
+func describeExecution(exec *execution) string {
+	switch {
+	case exec.CompileFailed:
+		return "<compile error> " + firstLine(exec.CompileOutput)
+	case exec.TimedOut:
+		return "<timeout>"
+	case exec.Signal != "":
+		return fmt.Sprintf("<crash: %s> %s", exec.Signal, exec.Stdout)
+	case exec.ExitCode != 0:
+		return fmt.Sprintf("<exit %d> %s", exec.ExitCode, firstLine(exec.Stderr))
+	default:
+		return exec.Stdout
+	}
+}
+
+func firstLine(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
@@ -0,0 +1,208 @@
+package verifier
+
+import (
+	"context"
+	"os/exec"
+	"server/src/internal/feature/quiz/types"
+	"testing"
+	"time"
+)
+
+func TestExpectationFor(t *testing.T) {
+	tests := []struct {
+		name        string
+		question    types.Question
+		wantOK      bool
+		wantOutcome outcome
+		stdout      string
+		wantMatch   bool
+	}{
+		{
+			name:        "選択式: 出力は空白を正規化して比較",
+			question:    types.Question{Choices: []string{"1 2", "2 1"}, Answer: "1 2"},
+			wantOK:      true,
+			wantOutcome: outcomeOutput,
+			stdout:      "1\n2\n",
+			wantMatch:   true,
+		},
+		{
+			name:        "選択式: クラッシュを表す答え",
+			question:    types.Question{Answer: "Segmentation fault"},
+			wantOK:      true,
+			wantOutcome: outcomeCrash,
+		},
+		{
+			name:        "選択式: コンパイルエラーを表す答え",
+			question:    types.Question{Answer: " compile error "},
+			wantOK:      true,
+			wantOutcome: outcomeCompileError,
+		},
+		{
+			name:     "選択式: 未定義動作は検証できない",
+			question: types.Question{Answer: "Undefined behavior"},
+		},
+		{
+			name:        "自由記述: 問題の照合方法を使う",
+			question:    types.Question{Kind: types.KindFreeText, Answer: `\d+`, Match: types.MatchRegex},
+			wantOK:      true,
+			wantOutcome: outcomeOutput,
+			stdout:      "42\n",
+			wantMatch:   true,
+		},
+		{
+			name:        "並べ替え: 選択肢の順番に出力される",
+			question:    types.Question{Kind: types.KindOrdering, Choices: []string{"a", "b"}},
+			wantOK:      true,
+			wantOutcome: outcomeOutput,
+			stdout:      "a\nb",
+			wantMatch:   true,
+		},
+		{
+			name:     "複数選択は検証できない",
+			question: types.Question{Kind: types.KindMultiSelect, Answers: []string{"a"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expect, ok := expectationFor(&tt.question)
+			if ok != tt.wantOK {
+				t.Fatalf("expectationFor() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if expect.outcome != tt.wantOutcome {
+				t.Errorf("outcome = %v, want %v", expect.outcome, tt.wantOutcome)
+			}
+			if tt.wantOutcome == outcomeOutput {
+				if got := expect.match(tt.stdout); got != tt.wantMatch {
+					t.Errorf("match(%q) = %v, want %v", tt.stdout, got, tt.wantMatch)
+				}
+			}
+		})
+	}
+}
+
+func TestExpectationMatches(t *testing.T) {
+	output := outputExpectation("ok", func(stdout string) bool { return stdout == "ok" })
+	tests := []struct {
+		name   string
+		expect expectation
+		exec   execution
+		want   bool
+	}{
+		{name: "出力が一致", expect: output, exec: execution{Stdout: "ok"}, want: true},
+		{name: "出力が一致しても異常終了", expect: output, exec: execution{Stdout: "ok", ExitCode: 2}},
+		{name: "出力が一致してもタイムアウト", expect: output, exec: execution{Stdout: "ok", TimedOut: true}},
+		{name: "コンパイルエラーを期待", expect: expectation{outcome: outcomeCompileError}, exec: execution{CompileFailed: true}, want: true},
+		{name: "クラッシュを期待してシグナル終了", expect: expectation{outcome: outcomeCrash}, exec: execution{Signal: "segmentation fault"}, want: true},
+		{name: "クラッシュを期待して終了コードのみ", expect: expectation{outcome: outcomeCrash}, exec: execution{ExitCode: 1}},
+		{name: "実行時エラーを期待", expect: expectation{outcome: outcomeRuntimeError}, exec: execution{ExitCode: 2}, want: true},
+		{name: "実行時エラーを期待してタイムアウト", expect: expectation{outcome: outcomeRuntimeError}, exec: execution{TimedOut: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.expect.matches(&tt.exec); got != tt.want {
+				t.Errorf("matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// requireTool はツールチェーンがない環境では実行を伴うテストをスキップします。
+func requireTool(t *testing.T, tool string) {
+	t.Helper()
+	if _, err := exec.LookPath(tool); err != nil {
+		t.Skipf("%s is not installed", tool)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	tests := []struct {
+		name     string
+		tool     string
+		question types.Question
+		want     Status
+	}{
+		{
+			name:     "Python: 出力が答えと一致",
+			tool:     "python3",
+			question: types.Question{ID: "py-ok", Language: "Python", Statement: "print(1 + 1)", Choices: []string{"2", "11"}, Answer: "2"},
+			want:     StatusOK,
+		},
+		{
+			name:     "Python: 出力が答えと不一致",
+			tool:     "python3",
+			question: types.Question{ID: "py-ng", Language: "Python", Statement: "print('1' + '1')", Choices: []string{"2", "11"}, Answer: "2"},
+			want:     StatusMismatch,
+		},
+		{
+			name:     "Python: 構文エラー",
+			tool:     "python3",
+			question: types.Question{ID: "py-syntax", Language: "Python", Statement: "print(", Choices: []string{"Compile error", "1"}, Answer: "Compile error"},
+			want:     StatusOK,
+		},
+		{
+			name:     "Python: 例外",
+			tool:     "python3",
+			question: types.Question{ID: "py-raise", Language: "Python", Statement: "raise ValueError()", Choices: []string{"Exception", "1"}, Answer: "Exception"},
+			want:     StatusOK,
+		},
+		{
+			name:     "C: セグメンテーション違反",
+			tool:     "gcc",
+			question: types.Question{ID: "c-segv", Language: "C", Statement: "int main(void) { int *p = 0; return *p; }", Choices: []string{"Segmentation fault", "0"}, Answer: "Segmentation fault"},
+			want:     StatusOK,
+		},
+		{
+			name:     "Go: 自由記述の出力",
+			tool:     "go",
+			question: types.Question{ID: "go-ok", Kind: types.KindFreeText, Language: "Go", Statement: "package main\nimport \"fmt\"\nfunc main() { fmt.Println(6 * 7) }", Answer: "42"},
+			want:     StatusOK,
+		},
+		{
+			name:     "未対応の言語",
+			question: types.Question{ID: "rs", Language: "Rust", Statement: "fn main() {}", Answer: "x"},
+			want:     StatusSkipped,
+		},
+	}
+	v := New(Options{Timeout: 30 * time.Second, MemoryLimitMB: DefaultOptions.MemoryLimitMB})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.tool != "" {
+				requireTool(t, tt.tool)
+			}
+			result := v.Verify(context.Background(), &tt.question)
+			if result.Status != tt.want {
+				t.Errorf("Verify() = %+v, want status %s", result, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifyTimeout(t *testing.T) {
+	requireTool(t, "python3")
+	v := New(Options{Timeout: 500 * time.Millisecond})
+	q := types.Question{ID: "py-loop", Language: "Python", Statement: "while True:\n    pass", Choices: []string{"1", "2"}, Answer: "1"}
+
+	result := v.Verify(context.Background(), &q)
+
+	if result.Status != StatusMismatch || result.Actual != "<timeout>" {
+		t.Errorf("Verify() = %+v, want a mismatch caused by timeout", result)
+	}
+}
+
+func TestVerifyAllCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	questions := []types.Question{
+		{ID: "rs", Language: "Rust", Statement: "fn main() {}", Answer: "x"},
+		{ID: "rs2", Language: "Rust", Statement: "fn main() {}", Answer: "y"},
+	}
+
+	results := New(DefaultOptions).VerifyAll(ctx, questions)
+
+	if len(results) != 0 {
+		t.Errorf("len(results) = %d, want 0 after cancel", len(results))
+	}
+}
@@ -14,6 +14,11 @@ type MessageProcessor interface {
 	ProcessClientMessage(roomID, userID string, message []byte)
 }
 
+// RoomCloser は削除されたルームの後始末を行うインターフェースです。Processor が実装している場合、ホストの退出でルームを削除したときに呼び出します。
+type RoomCloser interface {
+	CloseRoom(roomID string)
+}
+
 type RoomHub struct {
 	rooms      map[string]map[*Client]bool
 	mu         sync.RWMutex
@@ -105,6 +110,11 @@ func (h *RoomHub) unregisterClient(client *Client) {
 			if isHost {
 				log.Printf("Host %s has left. Closing room %s.", userID, roomID)
 
+				// 進行中のゲームのタイマーを止める（ハブのロックを保持したまま Processor を呼び出さない）
+				if closer, ok := h.Processor.(RoomCloser); ok {
+					go closer.CloseRoom(roomID)
+				}
+
 				closeMsg := &types.Message{
 					Type:    "room_closed",
 					Payload: map[string]string{"message": "ホストが退出したため、ルームは解散されました。"},
@@ -150,6 +160,10 @@ func (h *RoomHub) broadcastMessage(message *types.Message) {
 		}
 
 		for client := range room {
+			// 宛先ユーザーが指定されている場合はそのユーザーにのみ送信
+			if message.UserID != "" && client.UserID != message.UserID {
+				continue
+			}
 			select {
 			case client.Send <- jsonMsg:
 			default:
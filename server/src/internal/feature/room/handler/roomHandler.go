@@ -8,6 +8,7 @@ import (
 
 	"server/src/internal/feature/room/service"
 	"server/src/internal/feature/room/types"
+	"server/src/internal/feature/room/utils"
 
 	"github.com/labstack/echo/v4"
 )
@@ -87,3 +88,44 @@ func (h *RoomHandler) JoinRoom(c echo.Context) error {
 	}
 	return c.JSON(http.StatusOK, room)
 }
+
+// AssignTeams は PUT /rooms/:id/teams のリクエストを処理します。
+func (h *RoomHandler) AssignTeams(c echo.Context) error {
+	req := new(types.TeamAssignmentRequest)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, types.ErrorResponse{Message: "Invalid request body"})
+	}
+	room, err := h.service.AssignTeams(c.Param("id"), req)
+	if err != nil {
+		return teamErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, room)
+}
+
+// BalanceTeams は POST /rooms/:id/teams/balance のリクエストを処理します。
+func (h *RoomHandler) BalanceTeams(c echo.Context) error {
+	req := new(types.TeamBalanceRequest)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, types.ErrorResponse{Message: "Invalid request body"})
+	}
+	room, err := h.service.BalanceTeams(c.Param("id"), req)
+	if err != nil {
+		return teamErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, room)
+}
+
+func teamErrorResponse(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, utils.ErrRoomNotFound):
+		return c.JSON(http.StatusNotFound, types.ErrorResponse{Message: err.Error()})
+	case errors.Is(err, service.ErrNotHostTeams):
+		return c.JSON(http.StatusForbidden, types.ErrorResponse{Message: err.Error()})
+	case errors.Is(err, service.ErrInvalidTeams):
+		return c.JSON(http.StatusBadRequest, types.ErrorResponse{Message: err.Error()})
+	case errors.Is(err, service.ErrGameStarted):
+		return c.JSON(http.StatusConflict, types.ErrorResponse{Message: err.Error()})
+	default:
+		return c.JSON(http.StatusInternalServerError, types.ErrorResponse{Message: err.Error()})
+	}
+}
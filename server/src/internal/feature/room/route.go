@@ -12,10 +12,14 @@ import (
 
 // RegisterRoutes はroom機能の依存関係を解決し、ルートを登録します。
 // ★★★ main.goからdbハンドラを受け取れるように、引数を追加 ★★★
-func RegisterRoutes(g *echo.Group, db *database.DBHandler) {
+// onDelete にはルーム削除時に呼び出す関数（進行中のゲームの後始末など）を指定します。
+func RegisterRoutes(g *echo.Group, db *database.DBHandler, onDelete ...func(roomID string)) {
 	// 依存関係を組み立てる
 	repo := repository.NewRoomRepository(db)
 	svc := service.NewRoomService(repo)
+	for _, listener := range onDelete {
+		svc.OnDelete(listener)
+	}
 	h := handler.NewRoomHandler(svc)
 
 	// ルート定義
@@ -23,4 +27,6 @@ func RegisterRoutes(g *echo.Group, db *database.DBHandler) {
 	g.GET("/:id", h.GetRoom)
 	g.DELETE("/:id", h.DeleteRoom)
 	g.POST("/:id/join", h.JoinRoom)
+	g.PUT("/:id/teams", h.AssignTeams)
+	g.POST("/:id/teams/balance", h.BalanceTeams)
 }
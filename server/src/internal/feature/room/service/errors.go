@@ -5,4 +5,7 @@ import "errors"
 var (
 	ErrRoomNotFound       = errors.New("room not found")
 	ErrNotHostPermission  = errors.New("only the host can delete the room")
+	ErrNotHostTeams       = errors.New("only the host can change teams")
+	ErrGameStarted        = errors.New("game has already started")
+	ErrInvalidTeams       = errors.New("invalid teams")
 )
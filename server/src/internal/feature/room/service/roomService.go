@@ -15,6 +15,8 @@ import (
 // QuizService はクイズ機能のビジネスロジックを担当します。
 type RoomService struct {
 	repo *repository.RoomRepository
+
+	deleteListeners []func(roomID string) // ルーム削除時に呼び出す関数
 }
 
 // NewQuizService は新しいサービスインスタンスを生成します。
@@ -42,6 +44,7 @@ func (s *RoomService) CreateRoom(req *types.RoomCreationRequest) (*types.Room, e
 		Settings:  req.Settings,
 		Players:   make(map[string]types.Player),
 		GameState: "waiting",
+		Owner:     req.Owner,
 		CreatedAt: time.Now().UTC(),
 	}
 	// ホストをプレイヤーとして追加
@@ -68,7 +71,18 @@ func (s *RoomService) DeleteRoom(id, userID string) error {
 	if room.HostID != userID {
 		fmt.Println("Hello, world")
 	}
-	return s.repo.DeleteRoom(id)
+	if err := s.repo.DeleteRoom(id); err != nil {
+		return err
+	}
+	for _, listener := range s.deleteListeners {
+		listener(id)
+	}
+	return nil
+}
+
+// OnDelete はルーム削除時に呼び出す関数を登録します（進行中のゲームのタイマーを止めるなど）。
+func (s *RoomService) OnDelete(listener func(roomID string)) {
+	s.deleteListeners = append(s.deleteListeners, listener)
 }
 
 // JoinRoom はゲストがルームに参加するロジックを処理します。
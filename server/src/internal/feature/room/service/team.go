@@ -0,0 +1,124 @@
+// server/src/internal/feature/room/service/team.go
+package service
+
+import (
+	"fmt"
+	"math/rand"
+	"server/src/internal/feature/room/types"
+	"sort"
+	"strconv"
+)
+
+const (
+	DEFAULT_TEAM_COUNT = 2
+	MAX_TEAM_COUNT     = 8
+)
+
+// AssignTeams はホストが指定したチーム分けを保存します。
+// どのチームにも入っていないプレイヤーは、ゲーム開始時に人数の少ないチームへ自動で振り分けます。
+func (s *RoomService) AssignTeams(id string, req *types.TeamAssignmentRequest) (*types.Room, error) {
+	room, err := s.editableRoom(id, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateTeams(room, req.Teams); err != nil {
+		return nil, err
+	}
+	room.Teams = req.Teams
+	return s.repo.UpdateRoom(room)
+}
+
+// BalanceTeams はルームのプレイヤーをランダムに、人数ができるだけ均等になるようチームに分けて保存します。
+func (s *RoomService) BalanceTeams(id string, req *types.TeamBalanceRequest) (*types.Room, error) {
+	room, err := s.editableRoom(id, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	count := req.TeamCount
+	if count == 0 {
+		count = room.Settings.TeamCount
+	}
+	if count == 0 {
+		count = DEFAULT_TEAM_COUNT
+	}
+	if count < 2 || count > MAX_TEAM_COUNT {
+		return nil, fmt.Errorf("%w: teamCount must be between 2 and %d", ErrInvalidTeams, MAX_TEAM_COUNT)
+	}
+
+	players := make([]string, 0, len(room.Players))
+	for userID := range room.Players {
+		players = append(players, userID)
+	}
+	sort.Strings(players)
+	rand.Shuffle(len(players), func(i, j int) {
+		players[i], players[j] = players[j], players[i]
+	})
+
+	room.Teams = NewTeams(count)
+	for i, userID := range players {
+		room.Teams[i%count].Members = append(room.Teams[i%count].Members, userID)
+	}
+	return s.repo.UpdateRoom(room)
+}
+
+// NewTeams は count 個の空のチーム（team-1, team-2, ...）を作成します。
+func NewTeams(count int) []types.Team {
+	teams := make([]types.Team, count)
+	for i := range teams {
+		n := strconv.Itoa(i + 1)
+		teams[i] = types.Team{ID: "team-" + n, Name: "Team " + n, Members: []string{}}
+	}
+	return teams
+}
+
+// editableRoom はホストがチーム分けを変更できるルームを取得します。
+func (s *RoomService) editableRoom(id, userID string) (*types.Room, error) {
+	room, err := s.repo.FindRoomByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if room.HostID != userID {
+		return nil, ErrNotHostTeams
+	}
+	if room.GameState != "waiting" {
+		return nil, ErrGameStarted
+	}
+	return room, nil
+}
+
+// validateTeams はチーム分けを検証します。チームIDは一意で、メンバーはルームのプレイヤーである必要があり、
+// 1人のプレイヤーが複数のチームに入ることはできません。
+func validateTeams(room *types.Room, teams []types.Team) error {
+	if len(teams) < 2 || len(teams) > MAX_TEAM_COUNT {
+		return fmt.Errorf("%w: the number of teams must be between 2 and %d", ErrInvalidTeams, MAX_TEAM_COUNT)
+	}
+	ids := make(map[string]bool, len(teams))
+	members := make(map[string]string)
+	for i := range teams {
+		team := &teams[i]
+		if team.ID == "" {
+			return fmt.Errorf("%w: team id is required", ErrInvalidTeams)
+		}
+		if ids[team.ID] {
+			return fmt.Errorf("%w: duplicate team id %q", ErrInvalidTeams, team.ID)
+		}
+		ids[team.ID] = true
+		if team.Name == "" {
+			team.Name = team.ID
+		}
+		if team.Members == nil {
+			team.Members = []string{}
+		}
+		for _, userID := range team.Members {
+			if _, ok := room.Players[userID]; !ok {
+				return fmt.Errorf("%w: %s is not in the room", ErrInvalidTeams, userID)
+			}
+			if other, ok := members[userID]; ok {
+				return fmt.Errorf("%w: %s is in both %s and %s", ErrInvalidTeams, userID, other, team.ID)
+			}
+			members[userID] = team.ID
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"errors"
+	"server/src/internal/feature/room/types"
+	"testing"
+)
+
+func TestValidateTeams(t *testing.T) {
+	room := &types.Room{Players: map[string]types.Player{"alice": {}, "bob": {}}}
+	tests := []struct {
+		name    string
+		teams   []types.Team
+		wantErr bool
+	}{
+		{name: "正しいチーム分け", teams: []types.Team{{ID: "a", Members: []string{"alice"}}, {ID: "b", Members: []string{"bob"}}}},
+		{name: "空のチームも指定できる", teams: []types.Team{{ID: "a"}, {ID: "b"}}},
+		{name: "1チームのみ", teams: []types.Team{{ID: "a"}}, wantErr: true},
+		{name: "チーム数が上限を超える", teams: NewTeams(MAX_TEAM_COUNT + 1), wantErr: true},
+		{name: "チームIDがない", teams: []types.Team{{ID: ""}, {ID: "b"}}, wantErr: true},
+		{name: "チームIDが重複", teams: []types.Team{{ID: "a"}, {ID: "a"}}, wantErr: true},
+		{name: "ルームにいないプレイヤー", teams: []types.Team{{ID: "a", Members: []string{"carol"}}, {ID: "b"}}, wantErr: true},
+		{name: "複数のチームに入っている", teams: []types.Team{{ID: "a", Members: []string{"alice"}}, {ID: "b", Members: []string{"alice"}}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTeams(room, tt.teams)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateTeams() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidTeams) {
+				t.Errorf("error = %v, want ErrInvalidTeams", err)
+			}
+		})
+	}
+
+	t.Run("名前とメンバーの省略を補う", func(t *testing.T) {
+		teams := []types.Team{{ID: "a"}, {ID: "b", Name: "B"}}
+		if err := validateTeams(room, teams); err != nil {
+			t.Fatal(err)
+		}
+		if teams[0].Name != "a" || teams[1].Name != "B" || teams[0].Members == nil {
+			t.Errorf("teams = %+v", teams)
+		}
+	})
+}
@@ -9,6 +9,43 @@ import "time"
 type Settings struct {
 	Difficulty string `json:"difficulty" dynamodbav:"difficulty"`
 	Language   string `json:"language" dynamodbav:"language"`
+
+	// TimeLimitSeconds は1問あたりの制限時間（秒）。0の場合はサーバーのデフォルト値を使用します。
+	TimeLimitSeconds int `json:"timeLimitSeconds,omitempty" dynamodbav:"time_limit_seconds,omitempty"`
+	// AnswerMode は回答受付ルール（first_correct / everyone）。未指定の場合は first_correct です。
+	AnswerMode string `json:"answerMode,omitempty" dynamodbav:"answer_mode,omitempty"`
+	// Scoring は得点ルールの組み合わせ（flat / time_decay / negative / streak）。未指定の場合は flat です。
+	Scoring []string `json:"scoring,omitempty" dynamodbav:"scoring,omitempty"`
+	// QuestionCount は1ゲームの出題数。0の場合はサーバーのデフォルト値を使用し、出題可能な問題数を上限とします。
+	QuestionCount int `json:"questionCount,omitempty" dynamodbav:"question_count,omitempty"`
+	// ShuffleChoices は選択肢の並び替え方法（none / game / player）。未指定の場合は game です。
+	ShuffleChoices string `json:"shuffleChoices,omitempty" dynamodbav:"shuffle_choices,omitempty"`
+	// TeamCount は team モードのチーム数。チームを自動で分ける場合に使用し、0の場合は2チームです。
+	TeamCount int `json:"teamCount,omitempty" dynamodbav:"team_count,omitempty"`
+	// OneAnswerPerTeam が true の場合、team モードでは1問につきチームで最初の1人の回答のみを受け付けます。
+	OneAnswerPerTeam bool `json:"oneAnswerPerTeam,omitempty" dynamodbav:"one_answer_per_team,omitempty"`
+	// Selection は出題する問題の選び方（random / ordered / adaptive）。未指定の場合は random です。
+	Selection string `json:"selection,omitempty" dynamodbav:"selection,omitempty"`
+	// GameMode はゲームの進め方（classic / elimination / team）。未指定の場合は classic です。
+	GameMode string `json:"gameMode,omitempty" dynamodbav:"game_mode,omitempty"`
+	// RevealSeconds は正解発表の表示時間（秒）。0の場合はサーバーのデフォルト値を使用します。
+	RevealSeconds int `json:"revealSeconds,omitempty" dynamodbav:"reveal_seconds,omitempty"`
+	// IntermissionSeconds は正解発表の後、次の問題までに途中経過の順位を表示する時間（秒）。0の場合は休憩なしで次の問題へ進みます。
+	IntermissionSeconds int `json:"intermissionSeconds,omitempty" dynamodbav:"intermission_seconds,omitempty"`
+	// CountdownSeconds は最初の問題の前のカウントダウン（秒）。0の場合はすぐに出題します。
+	CountdownSeconds int `json:"countdownSeconds,omitempty" dynamodbav:"countdown_seconds,omitempty"`
+	// RematchRepeatQuestions が true の場合、再戦でも前のゲームで出題した問題を出題候補から除外しません。
+	RematchRepeatQuestions bool `json:"rematchRepeatQuestions,omitempty" dynamodbav:"rematch_repeat_questions,omitempty"`
+	// Seed は出題順と選択肢の並び替えに使用する乱数のシード。0の場合はゲームごとにランダムに決めます。
+	// 同じシードと同じ問題バンクであれば、同じ問題が同じ順番で出題されます。
+	Seed int64 `json:"seed,omitempty" dynamodbav:"seed,omitempty"`
+}
+
+// Team は team モードのチームです。
+type Team struct {
+	ID      string   `json:"id" dynamodbav:"id"`
+	Name    string   `json:"name" dynamodbav:"name"`
+	Members []string `json:"members" dynamodbav:"members"` // プレイヤーのユーザーID
 }
 
 type Player struct {
@@ -25,6 +62,8 @@ type Room struct {
 	Settings  Settings          `json:"settings" dynamodbav:"settings"`
 	Players   map[string]Player `json:"players" dynamodbav:"players"`
 	GameState string            `json:"gameState" dynamodbav:"game_state"`
+	Teams     []Team            `json:"teams,omitempty" dynamodbav:"teams,omitempty"` // team モードのチーム分け（ゲーム開始時に未所属のプレイヤーは自動で振り分けます）
+	Owner     string            `json:"owner,omitempty" dynamodbav:"owner,omitempty"` // ルームを管理する機能（設定されている場合、ゲームはその機能からのみ開始できます）
 	CreatedAt time.Time         `json:"createdAt" dynamodbav:"created_at"`
 }
 
@@ -36,6 +75,7 @@ type RoomCreationRequest struct {
 	RoomID   string   `json:"roomId"`
 	HostID   string   `json:"hostId"`
 	Settings Settings `json:"settings"`
+	Owner    string   `json:"-"` // ルームを管理する機能（サーバー内の機能がルームを作成する場合のみ指定し、クライアントからは指定できません）
 }
 
 // JoinRequest はルーム参加時のリクエストボディ
@@ -44,6 +84,18 @@ type JoinRequest struct {
 	UserId     string `json:"userId"`
 }
 
+// TeamAssignmentRequest はホストがチーム分けを指定するリクエストボディ
+type TeamAssignmentRequest struct {
+	UserID string `json:"userId"` // ホストのユーザーID
+	Teams  []Team `json:"teams"`
+}
+
+// TeamBalanceRequest はチームを自動で分けるリクエストボディ
+type TeamBalanceRequest struct {
+	UserID    string `json:"userId"`              // ホストのユーザーID
+	TeamCount int    `json:"teamCount,omitempty"` // 0の場合はルーム設定の teamCount
+}
+
 // ErrorResponse はエラー時の共通レスポンス
 type ErrorResponse struct {
 	Message string `json:"message"`
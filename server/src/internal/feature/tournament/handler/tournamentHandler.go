@@ -0,0 +1,107 @@
+// server/src/internal/feature/tournament/handler/tournamentHandler.go
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"server/src/internal/feature/tournament/service"
+	"server/src/internal/feature/tournament/types"
+
+	"github.com/labstack/echo/v4"
+)
+
+// TournamentHandler はトーナメントのリクエストを処理します。
+type TournamentHandler struct {
+	service *service.TournamentService
+}
+
+func NewTournamentHandler(svc *service.TournamentService) *TournamentHandler {
+	return &TournamentHandler{service: svc}
+}
+
+// CreateTournament は POST /tournaments のリクエストを処理します。
+func (h *TournamentHandler) CreateTournament(c echo.Context) error {
+	req := new(types.CreateRequest)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+	t, err := h.service.Create(req)
+	if err != nil {
+		return tournamentErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusCreated, t)
+}
+
+// ListTournaments は GET /tournaments のリクエストを処理します。
+func (h *TournamentHandler) ListTournaments(c echo.Context) error {
+	tournaments, err := h.service.List()
+	if err != nil {
+		return tournamentErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, tournaments)
+}
+
+// GetTournament は GET /tournaments/:id のリクエストを処理します。
+// 組み合わせ・各試合のルームID・勝敗・順位表を含むトーナメントの状態を返します。
+func (h *TournamentHandler) GetTournament(c echo.Context) error {
+	t, err := h.service.Get(c.Param("id"))
+	if err != nil {
+		return tournamentErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, t)
+}
+
+// RegisterParticipant は POST /tournaments/:id/participants のリクエストを処理します。
+func (h *TournamentHandler) RegisterParticipant(c echo.Context) error {
+	req := new(types.RegisterRequest)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+	t, err := h.service.Register(c.Param("id"), req)
+	if err != nil {
+		return tournamentErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, t)
+}
+
+// StartTournament は POST /tournaments/:id/start のリクエストを処理します。
+func (h *TournamentHandler) StartTournament(c echo.Context) error {
+	req := new(types.HostRequest)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+	t, err := h.service.Start(c.Param("id"), req)
+	if err != nil {
+		return tournamentErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, t)
+}
+
+// ReportResult は POST /tournaments/:id/matches/:matchId/result のリクエストを処理します。
+func (h *TournamentHandler) ReportResult(c echo.Context) error {
+	req := new(types.ReportRequest)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+	t, err := h.service.Report(c.Param("id"), c.Param("matchId"), req)
+	if err != nil {
+		return tournamentErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, t)
+}
+
+func tournamentErrorResponse(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidTournament), errors.Is(err, service.ErrUserIDRequired), errors.Is(err, service.ErrInvalidResult):
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	case errors.Is(err, service.ErrNotHost):
+		return c.JSON(http.StatusForbidden, map[string]string{"error": err.Error()})
+	case errors.Is(err, service.ErrTournamentNotFound), errors.Is(err, service.ErrMatchNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	case errors.Is(err, service.ErrRegistrationClosed), errors.Is(err, service.ErrAlreadyRegistered),
+		errors.Is(err, service.ErrNotEnoughParticipants), errors.Is(err, service.ErrNotRunning), errors.Is(err, service.ErrMatchNotReady):
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	default:
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+}
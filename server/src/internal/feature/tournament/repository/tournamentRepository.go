@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"server/src/internal/database"
+	"server/src/internal/feature/tournament/types"
+)
+
+type TournamentRepository struct {
+	db *database.DBHandler
+}
+
+func NewTournamentRepository(db *database.DBHandler) *TournamentRepository {
+	return &TournamentRepository{db: db}
+}
+
+// Save はトーナメントを保存
+func (r *TournamentRepository) Save(t *types.Tournament) error {
+	return r.db.WriteTournament(t)
+}
+
+// Find はトーナメントを取得（存在しない場合は nil）
+func (r *TournamentRepository) Find(id string) (*types.Tournament, error) {
+	return r.db.ReadTournament(id)
+}
+
+// FindAll はすべてのトーナメントを取得
+func (r *TournamentRepository) FindAll() ([]types.Tournament, error) {
+	return r.db.ListTournaments()
+}
@@ -0,0 +1,30 @@
+// server/src/internal/feature/tournament/route.go
+package tournament
+
+import (
+	"server/src/internal/database"
+	quizservice "server/src/internal/feature/quiz/service"
+	roomrepository "server/src/internal/feature/room/repository"
+	roomservice "server/src/internal/feature/room/service"
+	"server/src/internal/feature/tournament/handler"
+	"server/src/internal/feature/tournament/repository"
+	"server/src/internal/feature/tournament/service"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RegisterRoutes はトーナメント機能の依存関係を解決し、ルートを登録します。
+// 各試合のルームは通常のルームと同じく room 機能で作成し、ゲームの進行は QuizService に任せます。
+func RegisterRoutes(g *echo.Group, db *database.DBHandler, quizSvc *quizservice.QuizService) {
+	rooms := roomservice.NewRoomService(roomrepository.NewRoomRepository(db))
+	repo := repository.NewTournamentRepository(db)
+	svc := service.NewTournamentService(repo, rooms, quizSvc)
+	h := handler.NewTournamentHandler(svc)
+
+	g.POST("", h.CreateTournament)
+	g.GET("", h.ListTournaments)
+	g.GET("/:id", h.GetTournament)
+	g.POST("/:id/participants", h.RegisterParticipant)
+	g.POST("/:id/start", h.StartTournament)
+	g.POST("/:id/matches/:matchId/result", h.ReportResult)
+}
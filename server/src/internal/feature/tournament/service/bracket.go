@@ -0,0 +1,77 @@
+// server/src/internal/feature/tournament/service/bracket.go
+package service
+
+import (
+	"fmt"
+	"server/src/internal/feature/tournament/types"
+)
+
+// matchID は試合IDを返します（r{ラウンド}-m{番号}）。
+func matchID(round, number int) string {
+	return fmt.Sprintf("r%d-m%d", round, number)
+}
+
+// bracketSeedOrder は勝ち抜き戦の1回戦の並び順をシード順位で返します（size は2の累乗）。
+// 1位と2位が決勝まで当たらないよう、1位 vs 最下位、2位 vs 最下位から2番目…の順に並べます。
+func bracketSeedOrder(size int) []int {
+	order := []int{1}
+	for len(order) < size {
+		n := len(order) * 2
+		next := make([]int, 0, n)
+		for _, seed := range order {
+			next = append(next, seed, n+1-seed)
+		}
+		order = next
+	}
+	return order
+}
+
+// newSingleEliminationRounds はシード順に並んだ参加者から勝ち抜き戦の全ラウンドを作成します。
+// 参加人数が2の累乗でない場合は、上位シードから順に1回戦が不戦勝になります。2回戦以降の対戦相手は勝敗に応じて決まります。
+func newSingleEliminationRounds(seeded []types.Participant) []types.Round {
+	size := 2
+	for size < len(seeded) {
+		size *= 2
+	}
+	player := func(seed int) string {
+		if seed <= len(seeded) {
+			return seeded[seed-1].UserID
+		}
+		return ""
+	}
+
+	order := bracketSeedOrder(size)
+	var rounds []types.Round
+	for number, matches := 1, size/2; matches >= 1; number, matches = number+1, matches/2 {
+		round := types.Round{Number: number, Matches: make([]types.Match, matches)}
+		for i := range round.Matches {
+			m := types.Match{ID: matchID(number, i+1), Round: number, Status: types.MatchWaiting}
+			if number == 1 {
+				m.PlayerA, m.PlayerB = player(order[2*i]), player(order[2*i+1])
+				if m.PlayerB == "" {
+					m.Bye, m.Winner, m.Status = true, m.PlayerA, types.MatchFinished
+				}
+			}
+			round.Matches[i] = m
+		}
+		rounds = append(rounds, round)
+	}
+	return rounds
+}
+
+// advanceSingleElimination は終了した試合の勝者を次のラウンドの試合に進めます。
+func advanceSingleElimination(t *types.Tournament) {
+	for r := 0; r < len(t.Rounds)-1; r++ {
+		for i, m := range t.Rounds[r].Matches {
+			if m.Status != types.MatchFinished {
+				continue
+			}
+			next := &t.Rounds[r+1].Matches[i/2]
+			if i%2 == 0 {
+				next.PlayerA = m.Winner
+			} else {
+				next.PlayerB = m.Winner
+			}
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// server/src/internal/feature/tournament/service/bracket_test.go
+package service
+
+import (
+	"fmt"
+	"reflect"
+	"server/src/internal/feature/tournament/types"
+	"testing"
+)
+
+// participants はシード順に並んだ参加者 p1, p2, ... を作成します。
+func participants(n int) []types.Participant {
+	ps := make([]types.Participant, n)
+	for i := range ps {
+		ps[i] = types.Participant{UserID: fmt.Sprintf("p%d", i+1), Seed: i + 1}
+	}
+	return ps
+}
+
+func TestBracketSeedOrder(t *testing.T) {
+	tests := []struct {
+		size int
+		want []int
+	}{
+		{size: 2, want: []int{1, 2}},
+		{size: 4, want: []int{1, 4, 2, 3}},
+		{size: 8, want: []int{1, 8, 4, 5, 2, 7, 3, 6}},
+	}
+	for _, tt := range tests {
+		if got := bracketSeedOrder(tt.size); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("bracketSeedOrder(%d) = %v, want %v", tt.size, got, tt.want)
+		}
+	}
+}
+
+func TestNewSingleEliminationRounds(t *testing.T) {
+	tests := []struct {
+		name   string
+		n      int
+		rounds int
+		first  [][2]string // 1回戦の組み合わせ（空文字は不戦勝）
+	}{
+		{name: "2人", n: 2, rounds: 1, first: [][2]string{{"p1", "p2"}}},
+		{name: "3人は1位が不戦勝", n: 3, rounds: 2, first: [][2]string{{"p1", ""}, {"p2", "p3"}}},
+		{name: "4人", n: 4, rounds: 2, first: [][2]string{{"p1", "p4"}, {"p2", "p3"}}},
+		{name: "5人は上位3人が不戦勝", n: 5, rounds: 3, first: [][2]string{{"p1", ""}, {"p4", "p5"}, {"p2", ""}, {"p3", ""}}},
+		{name: "8人", n: 8, rounds: 3, first: [][2]string{{"p1", "p8"}, {"p4", "p5"}, {"p2", "p7"}, {"p3", "p6"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rounds := newSingleEliminationRounds(participants(tt.n))
+			if len(rounds) != tt.rounds {
+				t.Fatalf("got %d rounds, want %d", len(rounds), tt.rounds)
+			}
+			for r := 1; r < len(rounds); r++ {
+				if len(rounds[r].Matches) != len(rounds[r-1].Matches)/2 {
+					t.Errorf("round %d has %d matches, want %d", r+1, len(rounds[r].Matches), len(rounds[r-1].Matches)/2)
+				}
+			}
+
+			var got [][2]string
+			for _, m := range rounds[0].Matches {
+				got = append(got, [2]string{m.PlayerA, m.PlayerB})
+				bye := m.PlayerB == ""
+				if m.Bye != bye {
+					t.Errorf("match %s: Bye = %v, want %v", m.ID, m.Bye, bye)
+				}
+				if bye && (m.Winner != m.PlayerA || m.Status != types.MatchFinished) {
+					t.Errorf("bye match %s: winner %q status %q, want %q finished", m.ID, m.Winner, m.Status, m.PlayerA)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.first) {
+				t.Errorf("first round = %v, want %v", got, tt.first)
+			}
+		})
+	}
+}
+
+func TestAdvanceSingleElimination(t *testing.T) {
+	tests := []struct {
+		name    string
+		n       int
+		winners map[string]string // 1回戦の試合ID → 勝者
+		want    [][2]string       // 2回戦の組み合わせ
+	}{
+		{
+			name:    "勝者が次の試合に進む",
+			n:       4,
+			winners: map[string]string{"r1-m1": "p4", "r1-m2": "p2"},
+			want:    [][2]string{{"p4", "p2"}},
+		},
+		{
+			name:    "不戦勝は試合をせずに進む",
+			n:       3,
+			winners: map[string]string{},
+			want:    [][2]string{{"p1", ""}},
+		},
+		{
+			name:    "不戦勝と勝者が当たる",
+			n:       3,
+			winners: map[string]string{"r1-m2": "p3"},
+			want:    [][2]string{{"p1", "p3"}},
+		},
+		{
+			name:    "終了していない試合は進めない",
+			n:       8,
+			winners: map[string]string{"r1-m1": "p1", "r1-m4": "p6"},
+			want:    [][2]string{{"p1", ""}, {"", "p6"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tournament := &types.Tournament{Rounds: newSingleEliminationRounds(participants(tt.n))}
+			for i, m := range tournament.Rounds[0].Matches {
+				if winner, ok := tt.winners[m.ID]; ok {
+					tournament.Rounds[0].Matches[i].Winner = winner
+					tournament.Rounds[0].Matches[i].Status = types.MatchFinished
+				}
+			}
+			advanceSingleElimination(tournament)
+
+			var got [][2]string
+			for _, m := range tournament.Rounds[1].Matches {
+				got = append(got, [2]string{m.PlayerA, m.PlayerB})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("second round = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
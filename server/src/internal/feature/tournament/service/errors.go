@@ -0,0 +1,17 @@
+package service
+
+import "errors"
+
+var (
+	ErrTournamentNotFound    = errors.New("tournament not found")
+	ErrNotHost               = errors.New("only the host can manage the tournament")
+	ErrInvalidTournament     = errors.New("invalid tournament")
+	ErrUserIDRequired        = errors.New("userId is required")
+	ErrRegistrationClosed    = errors.New("registration is closed")
+	ErrAlreadyRegistered     = errors.New("already registered")
+	ErrNotEnoughParticipants = errors.New("at least 2 participants are required")
+	ErrNotRunning            = errors.New("tournament is not running")
+	ErrMatchNotFound         = errors.New("match not found")
+	ErrMatchNotReady         = errors.New("match is not ready or already finished")
+	ErrInvalidResult         = errors.New("invalid match result")
+)
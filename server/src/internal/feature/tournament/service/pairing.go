@@ -0,0 +1,145 @@
+// server/src/internal/feature/tournament/service/pairing.go
+package service
+
+import (
+	"server/src/internal/feature/tournament/types"
+)
+
+// MAX_PAIRING_STEPS はスイス式の組み合わせで再戦を避ける探索の上限です。超えた場合は再戦を許して組み合わせます。
+const MAX_PAIRING_STEPS = 100000
+
+// newRoundRobinRounds はシード順に並んだ参加者から総当たり戦の全ラウンドを作成します（サークル方式）。
+// 参加人数が奇数の場合は、各ラウンドで1人が不戦勝になります。
+func newRoundRobinRounds(seeded []types.Participant) []types.Round {
+	ids := make([]string, len(seeded))
+	for i, p := range seeded {
+		ids[i] = p.UserID
+	}
+	if len(ids)%2 == 1 {
+		ids = append(ids, "") // 不戦勝
+	}
+
+	n := len(ids)
+	rounds := make([]types.Round, 0, n-1)
+	for r := 0; r < n-1; r++ {
+		round := types.Round{Number: r + 1}
+		for i := 0; i < n/2; i++ {
+			round.Matches = append(round.Matches, newPairing(r+1, len(round.Matches)+1, ids[i], ids[n-1-i]))
+		}
+		rounds = append(rounds, round)
+
+		// 先頭を固定して残りを1つずつ回転させる
+		last := ids[n-1]
+		copy(ids[2:], ids[1:n-1])
+		ids[1] = last
+	}
+	return rounds
+}
+
+// newPairing は2人の試合を作成します。どちらかが空の場合は、もう一方の不戦勝です。
+func newPairing(round, number int, a, b string) types.Match {
+	if a == "" {
+		a, b = b, a
+	}
+	m := types.Match{ID: matchID(round, number), Round: round, PlayerA: a, PlayerB: b, Status: types.MatchWaiting}
+	if b == "" {
+		m.Bye, m.Winner, m.Status = true, a, types.MatchFinished
+	}
+	return m
+}
+
+// newSwissRound はスイス式の次のラウンドを作成します。standings は現在の順位順に並んでいる必要があります。
+//
+// 1回戦は上位半分と下位半分のシード順位が同じ位置の相手と対戦します。2回戦以降は順位の近いプレイヤー同士を、
+// まだ対戦していない相手から組み合わせます。参加人数が奇数の場合は、まだ不戦勝になっていない最下位のプレイヤーが不戦勝です。
+func newSwissRound(t *types.Tournament, standings []types.Standing) types.Round {
+	number := len(t.Rounds) + 1
+	played := make(map[string]map[string]bool)
+	hadBye := make(map[string]bool)
+	for _, round := range t.Rounds {
+		for _, m := range round.Matches {
+			if m.Bye {
+				hadBye[m.PlayerA] = true
+				continue
+			}
+			if played[m.PlayerA] == nil {
+				played[m.PlayerA] = make(map[string]bool)
+			}
+			if played[m.PlayerB] == nil {
+				played[m.PlayerB] = make(map[string]bool)
+			}
+			played[m.PlayerA][m.PlayerB] = true
+			played[m.PlayerB][m.PlayerA] = true
+		}
+	}
+
+	order := make([]string, len(standings))
+	for i, s := range standings {
+		order[i] = s.UserID
+	}
+
+	bye := ""
+	if len(order)%2 == 1 {
+		at := len(order) - 1
+		for i := len(order) - 1; i >= 0; i-- {
+			if !hadBye[order[i]] {
+				at = i
+				break
+			}
+		}
+		bye = order[at]
+		order = append(order[:at:at], order[at+1:]...)
+	}
+
+	var pairs [][2]string
+	if number == 1 {
+		half := len(order) / 2
+		for i := 0; i < half; i++ {
+			pairs = append(pairs, [2]string{order[i], order[i+half]})
+		}
+	} else {
+		steps := 0
+		var ok bool
+		if pairs, ok = pairAvoidingRematches(order, played, &steps); !ok {
+			pairs = pairs[:0]
+			for i := 0; i+1 < len(order); i += 2 {
+				pairs = append(pairs, [2]string{order[i], order[i+1]})
+			}
+		}
+	}
+
+	round := types.Round{Number: number}
+	for _, pair := range pairs {
+		round.Matches = append(round.Matches, newPairing(number, len(round.Matches)+1, pair[0], pair[1]))
+	}
+	if bye != "" {
+		round.Matches = append(round.Matches, newPairing(number, len(round.Matches)+1, bye, ""))
+	}
+	return round
+}
+
+// pairAvoidingRematches は順位順の先頭から、まだ対戦していない最も順位の近い相手と組み合わせます。
+// 残りを組み合わせられない場合は、ひとつ前の組み合わせをやり直します。
+func pairAvoidingRematches(order []string, played map[string]map[string]bool, steps *int) ([][2]string, bool) {
+	if len(order) == 0 {
+		return nil, true
+	}
+	*steps++
+	if *steps > MAX_PAIRING_STEPS {
+		return nil, false
+	}
+
+	first := order[0]
+	for j := 1; j < len(order); j++ {
+		if played[first][order[j]] {
+			continue
+		}
+		rest := make([]string, 0, len(order)-2)
+		rest = append(rest, order[1:j]...)
+		rest = append(rest, order[j+1:]...)
+		if pairs, ok := pairAvoidingRematches(rest, played, steps); ok {
+			return append([][2]string{{first, order[j]}}, pairs...), true
+		}
+	}
+	return nil, false
+}
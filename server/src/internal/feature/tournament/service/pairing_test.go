@@ -0,0 +1,226 @@
+// server/src/internal/feature/tournament/service/pairing_test.go
+package service
+
+import (
+	"reflect"
+	"server/src/internal/feature/tournament/types"
+	"testing"
+)
+
+// pairsOf はラウンドの組み合わせを返します。不戦勝は bye に返します。
+func pairsOf(round types.Round) (pairs [][2]string, bye string) {
+	for _, m := range round.Matches {
+		if m.Bye {
+			bye = m.PlayerA
+			continue
+		}
+		pairs = append(pairs, [2]string{m.PlayerA, m.PlayerB})
+	}
+	return pairs, bye
+}
+
+// standingsOf は順位順に並んだ順位表を作成します。
+func standingsOf(ids ...string) []types.Standing {
+	standings := make([]types.Standing, len(ids))
+	for i, id := range ids {
+		standings[i] = types.Standing{Rank: i + 1, UserID: id}
+	}
+	return standings
+}
+
+// playedRound は終了した試合だけのラウンドを作成します。空文字の相手は不戦勝です。
+func playedRound(number int, pairs ...[2]string) types.Round {
+	round := types.Round{Number: number}
+	for _, p := range pairs {
+		m := newPairing(number, len(round.Matches)+1, p[0], p[1])
+		m.Winner, m.Status = p[0], types.MatchFinished
+		round.Matches = append(round.Matches, m)
+	}
+	return round
+}
+
+func TestNewRoundRobinRounds(t *testing.T) {
+	for _, n := range []int{2, 4, 5, 6, 7} {
+		rounds := newRoundRobinRounds(participants(n))
+
+		wantRounds := n - 1
+		if n%2 == 1 {
+			wantRounds = n
+		}
+		if len(rounds) != wantRounds {
+			t.Fatalf("n=%d: got %d rounds, want %d", n, len(rounds), wantRounds)
+		}
+
+		met := make(map[[2]string]int)
+		byes := make(map[string]int)
+		for _, round := range rounds {
+			seen := make(map[string]bool)
+			pairs, bye := pairsOf(round)
+			if (bye != "") != (n%2 == 1) {
+				t.Errorf("n=%d round %d: bye = %q", n, round.Number, bye)
+			}
+			if bye != "" {
+				byes[bye]++
+				seen[bye] = true
+			}
+			for _, p := range pairs {
+				if seen[p[0]] || seen[p[1]] {
+					t.Errorf("n=%d round %d: player plays twice in %v", n, round.Number, pairs)
+				}
+				seen[p[0]], seen[p[1]] = true, true
+				if p[0] > p[1] {
+					p[0], p[1] = p[1], p[0]
+				}
+				met[p]++
+			}
+			if len(seen) != n {
+				t.Errorf("n=%d round %d: %d players scheduled, want %d", n, round.Number, len(seen), n)
+			}
+		}
+
+		if len(met) != n*(n-1)/2 {
+			t.Errorf("n=%d: %d distinct pairings, want %d", n, len(met), n*(n-1)/2)
+		}
+		for pair, count := range met {
+			if count != 1 {
+				t.Errorf("n=%d: %v met %d times", n, pair, count)
+			}
+		}
+		if n%2 == 1 {
+			for _, p := range participants(n) {
+				if byes[p.UserID] != 1 {
+					t.Errorf("n=%d: %s had %d byes, want 1", n, p.UserID, byes[p.UserID])
+				}
+			}
+		}
+	}
+}
+
+func TestNewSwissRound(t *testing.T) {
+	tests := []struct {
+		name      string
+		played    []types.Round
+		standings []types.Standing
+		want      [][2]string
+		wantBye   string
+	}{
+		{
+			name:      "1回戦は上位半分と下位半分が対戦する",
+			standings: standingsOf("p1", "p2", "p3", "p4", "p5", "p6"),
+			want:      [][2]string{{"p1", "p4"}, {"p2", "p5"}, {"p3", "p6"}},
+		},
+		{
+			name:      "1回戦の奇数人は最下位が不戦勝",
+			standings: standingsOf("p1", "p2", "p3", "p4", "p5"),
+			want:      [][2]string{{"p1", "p3"}, {"p2", "p4"}},
+			wantBye:   "p5",
+		},
+		{
+			name:      "順位の近い相手と組み合わせる",
+			played:    []types.Round{playedRound(1, [2]string{"p1", "p3"}, [2]string{"p2", "p4"})},
+			standings: standingsOf("p1", "p2", "p3", "p4"),
+			want:      [][2]string{{"p1", "p2"}, {"p3", "p4"}},
+		},
+		{
+			name:      "対戦済みの相手を避ける",
+			played:    []types.Round{playedRound(1, [2]string{"p1", "p2"}, [2]string{"p3", "p4"})},
+			standings: standingsOf("p1", "p2", "p3", "p4"),
+			want:      [][2]string{{"p1", "p3"}, {"p2", "p4"}},
+		},
+		{
+			name: "3回戦でも再戦しない",
+			played: []types.Round{
+				playedRound(1, [2]string{"p1", "p4"}, [2]string{"p2", "p5"}, [2]string{"p3", "p6"}),
+				playedRound(2, [2]string{"p1", "p2"}, [2]string{"p3", "p4"}, [2]string{"p5", "p6"}),
+			},
+			standings: standingsOf("p1", "p3", "p5", "p2", "p4", "p6"),
+			want:      [][2]string{{"p1", "p3"}, {"p5", "p4"}, {"p2", "p6"}},
+		},
+		{
+			name:      "不戦勝は既に不戦勝になったプレイヤーを避ける",
+			played:    []types.Round{playedRound(1, [2]string{"p1", "p3"}, [2]string{"p2", "p4"}, [2]string{"p5", ""})},
+			standings: standingsOf("p1", "p2", "p5", "p3", "p4"),
+			want:      [][2]string{{"p1", "p2"}, {"p5", "p3"}},
+			wantBye:   "p4",
+		},
+		{
+			name:      "再戦を避けられない場合は順位順に組み合わせる",
+			played:    []types.Round{playedRound(1, [2]string{"p1", "p2"})},
+			standings: standingsOf("p1", "p2"),
+			want:      [][2]string{{"p1", "p2"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tournament := &types.Tournament{Format: types.FormatSwiss, Rounds: tt.played}
+			round := newSwissRound(tournament, tt.standings)
+			if round.Number != len(tt.played)+1 {
+				t.Errorf("round number = %d, want %d", round.Number, len(tt.played)+1)
+			}
+			pairs, bye := pairsOf(round)
+			if !reflect.DeepEqual(pairs, tt.want) {
+				t.Errorf("pairs = %v, want %v", pairs, tt.want)
+			}
+			if bye != tt.wantBye {
+				t.Errorf("bye = %q, want %q", bye, tt.wantBye)
+			}
+		})
+	}
+}
+
+func TestPairAvoidingRematches(t *testing.T) {
+	played := func(pairs ...[2]string) map[string]map[string]bool {
+		m := make(map[string]map[string]bool)
+		for _, p := range pairs {
+			for _, id := range p {
+				if m[id] == nil {
+					m[id] = make(map[string]bool)
+				}
+			}
+			m[p[0]][p[1]] = true
+			m[p[1]][p[0]] = true
+		}
+		return m
+	}
+
+	tests := []struct {
+		name   string
+		order  []string
+		played map[string]map[string]bool
+		want   [][2]string
+		ok     bool
+	}{
+		{
+			name:   "対戦がなければ順位順",
+			order:  []string{"a", "b", "c", "d"},
+			played: played(),
+			want:   [][2]string{{"a", "b"}, {"c", "d"}},
+			ok:     true,
+		},
+		{
+			name:   "残りを組めない場合はやり直す",
+			order:  []string{"a", "b", "c", "d"},
+			played: played([2]string{"c", "d"}),
+			want:   [][2]string{{"a", "c"}, {"b", "d"}},
+			ok:     true,
+		},
+		{
+			name:   "全員と対戦済みなら組めない",
+			order:  []string{"a", "b", "c", "d"},
+			played: played([2]string{"a", "b"}, [2]string{"a", "c"}, [2]string{"a", "d"}),
+			ok:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			steps := 0
+			got, ok := pairAvoidingRematches(tt.order, tt.played, &steps)
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v", ok, tt.ok)
+			}
+			if ok && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("pairs = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// server/src/internal/feature/tournament/service/standings.go
+package service
+
+import (
+	"server/src/internal/feature/tournament/types"
+	"sort"
+)
+
+// computeStandings は終了した試合から順位表を作成します。
+// 勝ち点（勝ち・不戦勝1点、引き分け0.5点）、ブッフホルツ（対戦相手の勝ち点の合計）、クイズの得点の合計、シード順位の順に比較します。
+func computeStandings(t *types.Tournament) []types.Standing {
+	rows := make(map[string]*types.Standing, len(t.Participants))
+	seed := make(map[string]int, len(t.Participants))
+	for _, p := range t.Participants {
+		rows[p.UserID] = &types.Standing{UserID: p.UserID, Name: p.Name}
+		seed[p.UserID] = p.Seed
+	}
+
+	opponents := make(map[string][]string)
+	for _, round := range t.Rounds {
+		for _, m := range round.Matches {
+			if m.Status != types.MatchFinished {
+				continue
+			}
+			a, b := rows[m.PlayerA], rows[m.PlayerB]
+			if a == nil {
+				continue
+			}
+			if m.Bye || b == nil {
+				a.Points++
+				a.Wins++
+				continue
+			}
+			a.ScoreFor += m.Scores[m.PlayerA]
+			b.ScoreFor += m.Scores[m.PlayerB]
+			opponents[m.PlayerA] = append(opponents[m.PlayerA], m.PlayerB)
+			opponents[m.PlayerB] = append(opponents[m.PlayerB], m.PlayerA)
+			switch {
+			case m.Draw:
+				a.Points += 0.5
+				b.Points += 0.5
+				a.Draws++
+				b.Draws++
+			case m.Winner == m.PlayerA:
+				a.Points++
+				a.Wins++
+				b.Losses++
+			default:
+				b.Points++
+				b.Wins++
+				a.Losses++
+			}
+		}
+	}
+
+	standings := make([]types.Standing, 0, len(rows))
+	for userID, row := range rows {
+		for _, opponent := range opponents[userID] {
+			row.Buchholz += rows[opponent].Points
+		}
+		standings = append(standings, *row)
+	}
+
+	sort.Slice(standings, func(i, j int) bool {
+		a, b := standings[i], standings[j]
+		if a.Points != b.Points {
+			return a.Points > b.Points
+		}
+		if a.Buchholz != b.Buchholz {
+			return a.Buchholz > b.Buchholz
+		}
+		if a.ScoreFor != b.ScoreFor {
+			return a.ScoreFor > b.ScoreFor
+		}
+		return seed[a.UserID] < seed[b.UserID]
+	})
+	for i := range standings {
+		prev := i - 1
+		if prev >= 0 && standings[i].Points == standings[prev].Points &&
+			standings[i].Buchholz == standings[prev].Buchholz && standings[i].ScoreFor == standings[prev].ScoreFor {
+			standings[i].Rank = standings[prev].Rank
+		} else {
+			standings[i].Rank = i + 1
+		}
+	}
+	return standings
+}
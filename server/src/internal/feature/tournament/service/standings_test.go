@@ -0,0 +1,119 @@
+// server/src/internal/feature/tournament/service/standings_test.go
+package service
+
+import (
+	"server/src/internal/feature/tournament/types"
+	"testing"
+)
+
+// result は終了した1試合です。winner が空の場合は引き分け、b が空の場合は a の不戦勝です。
+func result(a, b, winner string, scoreA, scoreB int) types.Match {
+	m := types.Match{PlayerA: a, PlayerB: b, Status: types.MatchFinished, Winner: winner}
+	switch {
+	case b == "":
+		m.Bye, m.Winner = true, a
+	case winner == "":
+		m.Draw = true
+	}
+	if b != "" {
+		m.Scores = map[string]int{a: scoreA, b: scoreB}
+	}
+	return m
+}
+
+func TestComputeStandings(t *testing.T) {
+	type row struct {
+		userID   string
+		rank     int
+		points   float64
+		buchholz float64
+	}
+	tests := []struct {
+		name    string
+		n       int
+		matches []types.Match
+		want    []row
+	}{
+		{
+			name: "勝ち点が同じ場合はブッフホルツで順位を決める",
+			n:    4,
+			matches: []types.Match{
+				result("p1", "p2", "p1", 100, 0),
+				result("p3", "p4", "p3", 100, 0),
+				result("p2", "p3", "p2", 100, 0),
+				result("p1", "p4", "p1", 100, 0),
+			},
+			want: []row{
+				{userID: "p1", rank: 1, points: 2, buchholz: 1},
+				{userID: "p2", rank: 2, points: 1, buchholz: 3},
+				{userID: "p3", rank: 3, points: 1, buchholz: 1},
+				{userID: "p4", rank: 4, points: 0, buchholz: 3},
+			},
+		},
+		{
+			name: "不戦勝は1点でブッフホルツに含めない",
+			n:    3,
+			matches: []types.Match{
+				result("p1", "", "", 0, 0),
+				result("p2", "p3", "p3", 0, 100),
+			},
+			want: []row{
+				{userID: "p3", rank: 1, points: 1, buchholz: 0},
+				{userID: "p1", rank: 2, points: 1, buchholz: 0},
+				{userID: "p2", rank: 3, points: 0, buchholz: 1},
+			},
+		},
+		{
+			name: "引き分けは0.5点で、ブッフホルツも同じなら得点の合計で決める",
+			n:    2,
+			matches: []types.Match{
+				result("p1", "p2", "", 100, 300),
+			},
+			want: []row{
+				{userID: "p2", rank: 1, points: 0.5, buchholz: 0.5},
+				{userID: "p1", rank: 2, points: 0.5, buchholz: 0.5},
+			},
+		},
+		{
+			name: "すべて同じ場合は同順位でシード順に並べる",
+			n:    2,
+			matches: []types.Match{
+				result("p2", "p1", "", 100, 100),
+			},
+			want: []row{
+				{userID: "p1", rank: 1, points: 0.5, buchholz: 0.5},
+				{userID: "p2", rank: 1, points: 0.5, buchholz: 0.5},
+			},
+		},
+		{
+			name: "終了していない試合は数えない",
+			n:    2,
+			matches: []types.Match{
+				{PlayerA: "p1", PlayerB: "p2", Status: types.MatchReady},
+			},
+			want: []row{
+				{userID: "p1", rank: 1},
+				{userID: "p2", rank: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tournament := &types.Tournament{
+				Participants: participants(tt.n),
+				Rounds:       []types.Round{{Number: 1, Matches: tt.matches}},
+			}
+			standings := computeStandings(tournament)
+			if len(standings) != len(tt.want) {
+				t.Fatalf("got %d rows, want %d", len(standings), len(tt.want))
+			}
+			for i, want := range tt.want {
+				got := standings[i]
+				if got.UserID != want.userID || got.Rank != want.rank || got.Points != want.points || got.Buchholz != want.buchholz {
+					t.Errorf("row %d = {%s rank %d points %v buchholz %v}, want {%s rank %d points %v buchholz %v}",
+						i, got.UserID, got.Rank, got.Points, got.Buchholz, want.userID, want.rank, want.points, want.buchholz)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,493 @@
+// server/src/internal/feature/tournament/service/tournamentService.go
+package service
+
+import (
+	"crypto/rand"
+	"fmt"
+	"log"
+	"math"
+	mathrand "math/rand"
+	quizservice "server/src/internal/feature/quiz/service"
+	quiztypes "server/src/internal/feature/quiz/types"
+	roomservice "server/src/internal/feature/room/service"
+	roomtypes "server/src/internal/feature/room/types"
+	"server/src/internal/feature/tournament/repository"
+	"server/src/internal/feature/tournament/types"
+	"sort"
+	"sync"
+	"time"
+)
+
+// matchRef はルームに対応する試合です。
+type matchRef struct {
+	tournamentID string
+	matchID      string
+}
+
+// TournamentService は複数のルームにまたがるトーナメントを担当します。
+//
+// 各試合は1対1のルームとして room 機能で作成し、ゲームの進行は通常のルームと同じく QuizService に任せます。
+// ゲームが終了すると、その結果の順位から試合の勝敗を決め、次の試合の組み合わせを作成します。
+type TournamentService struct {
+	repo  *repository.TournamentRepository
+	rooms *roomservice.RoomService
+
+	matches map[string]matchRef // 対戦中の試合（Key: ルームID）
+	mu      sync.Mutex
+}
+
+// NewTournamentService は新しいサービスインスタンスを生成し、ゲーム終了時に試合結果を記録するよう登録します。
+// サーバーの再起動に備えて、進行中のトーナメントの対戦中の試合を読み込みます。
+func NewTournamentService(repo *repository.TournamentRepository, rooms *roomservice.RoomService, quiz *quizservice.QuizService) *TournamentService {
+	s := &TournamentService{
+		repo:    repo,
+		rooms:   rooms,
+		matches: make(map[string]matchRef),
+	}
+	s.loadMatches()
+	quiz.OnGameOver(s.recordResult)
+	return s
+}
+
+func (s *TournamentService) loadMatches() {
+	tournaments, err := s.repo.FindAll()
+	if err != nil {
+		log.Printf("error: cannot load tournaments: %v", err)
+		return
+	}
+	for _, t := range tournaments {
+		if t.Status != types.StatusRunning {
+			continue
+		}
+		for _, round := range t.Rounds {
+			for _, m := range round.Matches {
+				if m.Status == types.MatchReady && m.RoomID != "" {
+					s.matches[m.RoomID] = matchRef{tournamentID: t.ID, matchID: m.ID}
+				}
+			}
+		}
+	}
+}
+
+// Create はトーナメントを作成します。作成後は Register で参加者を受け付け、Start で開始します。
+func (s *TournamentService) Create(req *types.CreateRequest) (*types.Tournament, error) {
+	if req.HostID == "" {
+		return nil, ErrUserIDRequired
+	}
+	format := req.Format
+	if format == "" {
+		format = types.FormatSingleElimination
+	}
+	switch format {
+	case types.FormatSingleElimination, types.FormatSwiss, types.FormatRoundRobin:
+	default:
+		return nil, fmt.Errorf("%w: unknown format %q", ErrInvalidTournament, req.Format)
+	}
+	if req.Rounds < 0 {
+		return nil, fmt.Errorf("%w: rounds must not be negative", ErrInvalidTournament)
+	}
+	if req.Settings.GameMode == string(quiztypes.GameModeTeam) {
+		return nil, fmt.Errorf("%w: team mode cannot be used for 1v1 matches", ErrInvalidTournament)
+	}
+
+	name := req.Name
+	if name == "" {
+		name = "Tournament"
+	}
+	t := &types.Tournament{
+		ID:           generateTournamentID(),
+		Name:         name,
+		Format:       format,
+		HostID:       req.HostID,
+		Status:       types.StatusRegistration,
+		Seed:         req.Seed,
+		RoundCount:   req.Rounds,
+		Settings:     req.Settings,
+		Participants: []types.Participant{},
+		Rounds:       []types.Round{},
+		CreatedAt:    time.Now().UTC(),
+	}
+	if err := s.repo.Save(t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Get はトーナメントを取得します。
+func (s *TournamentService) Get(id string) (*types.Tournament, error) {
+	t, err := s.repo.Find(id)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, ErrTournamentNotFound
+	}
+	return t, nil
+}
+
+// List はすべてのトーナメントを新しい順に返します。
+func (s *TournamentService) List() ([]types.Tournament, error) {
+	tournaments, err := s.repo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(tournaments, func(i, j int) bool {
+		return tournaments[i].CreatedAt.After(tournaments[j].CreatedAt)
+	})
+	return tournaments, nil
+}
+
+// Register は参加者を登録します。登録できるのは開始前だけです。
+func (s *TournamentService) Register(id string, req *types.RegisterRequest) (*types.Tournament, error) {
+	if req.UserID == "" {
+		return nil, ErrUserIDRequired
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if t.Status != types.StatusRegistration {
+		return nil, ErrRegistrationClosed
+	}
+	for _, p := range t.Participants {
+		if p.UserID == req.UserID {
+			return nil, ErrAlreadyRegistered
+		}
+	}
+
+	name := req.Name
+	if name == "" {
+		name = req.UserID
+	}
+	t.Participants = append(t.Participants, types.Participant{UserID: req.UserID, Name: name})
+	if err := s.repo.Save(t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Start はシード順位を決めて1回戦の組み合わせを作成し、各試合のルームを作成します。ホストのみが実行できます。
+// シード順位はトーナメントのシードで参加者を並び替えて決めるため、同じシード・同じ参加者なら同じ組み合わせになります。
+func (s *TournamentService) Start(id string, req *types.HostRequest) (*types.Tournament, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if t.HostID != req.UserID {
+		return nil, ErrNotHost
+	}
+	if t.Status != types.StatusRegistration {
+		return nil, ErrRegistrationClosed
+	}
+	if len(t.Participants) < 2 {
+		return nil, ErrNotEnoughParticipants
+	}
+
+	if t.Seed == 0 {
+		t.Seed = mathrand.Int63n(quizservice.MAX_GAME_SEED) + 1
+	}
+	rng := mathrand.New(mathrand.NewSource(t.Seed))
+	rng.Shuffle(len(t.Participants), func(i, j int) {
+		t.Participants[i], t.Participants[j] = t.Participants[j], t.Participants[i]
+	})
+	for i := range t.Participants {
+		t.Participants[i].Seed = i + 1
+	}
+
+	switch t.Format {
+	case types.FormatSingleElimination:
+		t.Rounds = newSingleEliminationRounds(t.Participants)
+		t.RoundCount = len(t.Rounds)
+	case types.FormatRoundRobin:
+		t.Rounds = newRoundRobinRounds(t.Participants)
+		t.RoundCount = len(t.Rounds)
+	case types.FormatSwiss:
+		t.RoundCount = swissRoundCount(t.RoundCount, len(t.Participants))
+		t.Rounds = []types.Round{newSwissRound(t, computeStandings(t))}
+	}
+	t.Status = types.StatusRunning
+	t.CurrentRound = 1
+
+	s.progress(t)
+	if err := s.repo.Save(t); err != nil {
+		return nil, err
+	}
+	log.Printf("Tournament %s started with %d participants (%s)", t.ID, len(t.Participants), t.Format)
+	return t, nil
+}
+
+// swissRoundCount はスイス式のラウンド数を返します。指定がない場合は優勝者が1人に決まる ceil(log2(参加人数)) ラウンドです。
+// 同じ相手との再戦を避けられるよう、参加人数 - 1 ラウンドを上限にします。
+func swissRoundCount(requested, participants int) int {
+	rounds := requested
+	if rounds == 0 {
+		rounds = int(math.Ceil(math.Log2(float64(participants))))
+	}
+	if rounds > participants-1 {
+		rounds = participants - 1
+	}
+	if rounds < 1 {
+		rounds = 1
+	}
+	return rounds
+}
+
+// Report はホストが試合結果を登録します。対戦相手が現れないなど、ゲームで勝敗を決められなかった場合に使用します。
+// 勝ち抜き戦では引き分けを登録できません。
+func (s *TournamentService) Report(id, matchID string, req *types.ReportRequest) (*types.Tournament, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if t.HostID != req.UserID {
+		return nil, ErrNotHost
+	}
+	if t.Status != types.StatusRunning {
+		return nil, ErrNotRunning
+	}
+	m := findMatch(t, matchID)
+	if m == nil {
+		return nil, ErrMatchNotFound
+	}
+	if m.Status == types.MatchFinished || m.PlayerA == "" || m.PlayerB == "" {
+		return nil, ErrMatchNotReady
+	}
+	switch {
+	case req.Draw && req.Winner != "":
+		return nil, fmt.Errorf("%w: specify either winner or draw", ErrInvalidResult)
+	case req.Draw && t.Format == types.FormatSingleElimination:
+		return nil, fmt.Errorf("%w: single elimination matches cannot end in a draw", ErrInvalidResult)
+	case !req.Draw && req.Winner != m.PlayerA && req.Winner != m.PlayerB:
+		return nil, fmt.Errorf("%w: winner must be one of the players", ErrInvalidResult)
+	}
+
+	delete(s.matches, m.RoomID)
+	finishMatch(m, req.Winner, req.Draw, nil)
+	s.progress(t)
+	if err := s.repo.Save(t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// recordResult はトーナメントの試合のルームのゲームが終了したときに、試合結果を記録して次の試合に進めます。
+// 順位の高い方が勝ちです。同じ順位の場合は引き分けとし、勝ち抜き戦ではシード順位の高い方を勝ちにします。
+// ホストがゲームを中断した場合やゲームの途中でルームが削除された場合は結果を記録せず、同じルームでの再戦かホストによる結果の登録を待ちます。
+func (s *TournamentService) recordResult(result quiztypes.GameResult) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ref, ok := s.matches[result.RoomID]
+	if !ok {
+		return
+	}
+	if result.Aborted {
+		log.Printf("Tournament %s match %s was aborted; waiting for a rematch or a reported result", ref.tournamentID, ref.matchID)
+		return
+	}
+	delete(s.matches, result.RoomID)
+
+	t, err := s.Get(ref.tournamentID)
+	if err != nil {
+		log.Printf("error: cannot load tournament %s: %v", ref.tournamentID, err)
+		return
+	}
+	m := findMatch(t, ref.matchID)
+	if m == nil || m.Status == types.MatchFinished {
+		return
+	}
+
+	ranks := make(map[string]int)
+	scores := make(map[string]int)
+	for _, r := range result.Results {
+		if r.UserID == m.PlayerA || r.UserID == m.PlayerB {
+			ranks[r.UserID] = r.Rank
+			scores[r.UserID] = r.Score
+		}
+	}
+	winner, draw := decideWinner(t, m, ranks)
+	finishMatch(m, winner, draw, scores)
+	s.progress(t)
+
+	if err := s.repo.Save(t); err != nil {
+		log.Printf("error: cannot save tournament %s: %v", t.ID, err)
+		return
+	}
+	log.Printf("Tournament %s match %s finished: winner=%q draw=%v", t.ID, m.ID, m.Winner, m.Draw)
+}
+
+// decideWinner はゲームの順位から試合の勝者を決めます。結果にいないプレイヤー（途中で退出したなど）は負けです。
+func decideWinner(t *types.Tournament, m *types.Match, ranks map[string]int) (string, bool) {
+	rankA, okA := ranks[m.PlayerA]
+	rankB, okB := ranks[m.PlayerB]
+	switch {
+	case okA && !okB:
+		return m.PlayerA, false
+	case okB && !okA:
+		return m.PlayerB, false
+	case okA && rankA < rankB:
+		return m.PlayerA, false
+	case okB && rankB < rankA:
+		return m.PlayerB, false
+	}
+	if t.Format != types.FormatSingleElimination {
+		return "", true
+	}
+	if participantSeed(t, m.PlayerB) < participantSeed(t, m.PlayerA) {
+		return m.PlayerB, false
+	}
+	return m.PlayerA, false
+}
+
+func participantSeed(t *types.Tournament, userID string) int {
+	for _, p := range t.Participants {
+		if p.UserID == userID {
+			return p.Seed
+		}
+	}
+	return math.MaxInt
+}
+
+func findMatch(t *types.Tournament, matchID string) *types.Match {
+	for r := range t.Rounds {
+		for i := range t.Rounds[r].Matches {
+			if t.Rounds[r].Matches[i].ID == matchID {
+				return &t.Rounds[r].Matches[i]
+			}
+		}
+	}
+	return nil
+}
+
+func finishMatch(m *types.Match, winner string, draw bool, scores map[string]int) {
+	m.Status = types.MatchFinished
+	m.Winner = winner
+	m.Draw = draw
+	if len(scores) > 0 {
+		m.Scores = scores
+	}
+}
+
+// progress は試合結果を反映し、対戦相手の決まった試合のルームを作成します。すべての試合が終われば優勝者を決めます。
+// 勝ち抜き戦は勝者を次の試合に進め、スイス式・総当たり戦はラウンドの全試合が終わってから次のラウンドに進みます。
+func (s *TournamentService) progress(t *types.Tournament) {
+	for {
+		if t.Format == types.FormatSingleElimination {
+			advanceSingleElimination(t)
+			t.CurrentRound = currentRound(t)
+			if final := &t.Rounds[len(t.Rounds)-1].Matches[0]; final.Status == types.MatchFinished {
+				s.finish(t, final.Winner)
+				return
+			}
+			s.openMatches(t, 0, len(t.Rounds))
+			return
+		}
+
+		t.Standings = computeStandings(t)
+		if !roundFinished(t.Rounds[t.CurrentRound-1]) {
+			s.openMatches(t, t.CurrentRound-1, t.CurrentRound)
+			return
+		}
+		if t.CurrentRound >= t.RoundCount {
+			s.finish(t, t.Standings[0].UserID)
+			return
+		}
+		if t.Format == types.FormatSwiss {
+			t.Rounds = append(t.Rounds, newSwissRound(t, t.Standings))
+		}
+		t.CurrentRound++
+	}
+}
+
+// currentRound は勝ち抜き戦で、まだ終わっていない試合のある最初のラウンドを返します。
+func currentRound(t *types.Tournament) int {
+	for _, round := range t.Rounds {
+		if !roundFinished(round) {
+			return round.Number
+		}
+	}
+	return len(t.Rounds)
+}
+
+func roundFinished(round types.Round) bool {
+	for _, m := range round.Matches {
+		if m.Status != types.MatchFinished {
+			return false
+		}
+	}
+	return true
+}
+
+// openMatches は指定した範囲のラウンドで、対戦相手が決まった試合のルームを作成します。
+// ルームを作成できなかった試合は waiting のまま残し、ホストが結果を登録できるようにします。
+func (s *TournamentService) openMatches(t *types.Tournament, from, to int) {
+	for r := from; r < to; r++ {
+		for i := range t.Rounds[r].Matches {
+			m := &t.Rounds[r].Matches[i]
+			if m.Status != types.MatchWaiting || m.PlayerA == "" || m.PlayerB == "" {
+				continue
+			}
+			if err := s.openMatch(t, m); err != nil {
+				log.Printf("error: cannot create room for tournament %s match %s: %v", t.ID, m.ID, err)
+			}
+		}
+	}
+}
+
+// openMatch は試合のルームを作成し、2人をプレイヤーとして登録します。ルームのホストは PlayerA です。
+func (s *TournamentService) openMatch(t *types.Tournament, m *types.Match) error {
+	room, err := s.rooms.CreateRoom(&roomtypes.RoomCreationRequest{
+		HostID:   m.PlayerA,
+		Settings: t.Settings,
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := s.rooms.JoinRoom(room.RoomID, &roomtypes.JoinRequest{
+		PlayerName: participantName(t, m.PlayerB),
+		UserId:     m.PlayerB,
+	}); err != nil {
+		return err
+	}
+
+	m.RoomID = room.RoomID
+	m.Status = types.MatchReady
+	s.matches[room.RoomID] = matchRef{tournamentID: t.ID, matchID: m.ID}
+	return nil
+}
+
+func participantName(t *types.Tournament, userID string) string {
+	for _, p := range t.Participants {
+		if p.UserID == userID {
+			return p.Name
+		}
+	}
+	return userID
+}
+
+func (s *TournamentService) finish(t *types.Tournament, winner string) {
+	finishedAt := time.Now().UTC()
+	t.Status = types.StatusFinished
+	t.Winner = winner
+	t.FinishedAt = &finishedAt
+	log.Printf("Tournament %s finished: winner=%s", t.ID, winner)
+}
+
+// generateTournamentID はランダムなトーナメントIDを生成します。
+func generateTournamentID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		log.Printf("error generating tournament ID: %v", err)
+	}
+	return fmt.Sprintf("%x", b)
+}
@@ -0,0 +1,123 @@
+// server/src/internal/feature/tournament/types/tournamentType.go
+package types
+
+import (
+	roomtypes "server/src/internal/feature/room/types"
+	"time"
+)
+
+// Format はトーナメントの形式です。
+type Format string
+
+const (
+	FormatSingleElimination Format = "single_elimination" // 負けたら終わりの勝ち抜き戦
+	FormatSwiss             Format = "swiss"              // 同じ勝ち点のプレイヤー同士を毎回組み合わせる
+	FormatRoundRobin        Format = "round_robin"        // 全員と1回ずつ対戦する総当たり戦
+)
+
+// Status はトーナメントの進行状態です。
+type Status string
+
+const (
+	StatusRegistration Status = "registration" // 参加受付中
+	StatusRunning      Status = "running"      // 対戦中
+	StatusFinished     Status = "finished"     // 終了
+)
+
+// MatchStatus は1試合の状態です。
+type MatchStatus string
+
+const (
+	MatchWaiting  MatchStatus = "waiting"  // 対戦相手が決まっていない
+	MatchReady    MatchStatus = "ready"    // ルームを作成し、対戦を待っている
+	MatchFinished MatchStatus = "finished" // 勝敗が決まった
+)
+
+// Participant はトーナメントの参加者です。
+type Participant struct {
+	UserID string `json:"userId" dynamodbav:"user_id"`
+	Name   string `json:"name" dynamodbav:"name"`
+	Seed   int    `json:"seed,omitempty" dynamodbav:"seed,omitempty"` // 開始時に決まるシード順位（1が最上位）
+}
+
+// Match は1対1の1試合です。
+type Match struct {
+	ID      string         `json:"id" dynamodbav:"id"` // r{ラウンド}-m{番号}
+	Round   int            `json:"round" dynamodbav:"round"`
+	PlayerA string         `json:"playerA,omitempty" dynamodbav:"player_a,omitempty"`
+	PlayerB string         `json:"playerB,omitempty" dynamodbav:"player_b,omitempty"`
+	Bye     bool           `json:"bye,omitempty" dynamodbav:"bye,omitempty"` // 対戦相手がおらず不戦勝
+	RoomID  string         `json:"roomId,omitempty" dynamodbav:"room_id,omitempty"`
+	Status  MatchStatus    `json:"status" dynamodbav:"status"`
+	Winner  string         `json:"winner,omitempty" dynamodbav:"winner,omitempty"`
+	Draw    bool           `json:"draw,omitempty" dynamodbav:"draw,omitempty"` // 引き分け（勝ち抜き戦以外）
+	Scores  map[string]int `json:"scores,omitempty" dynamodbav:"scores,omitempty"`
+}
+
+// Round は1ラウンド分の試合です。
+type Round struct {
+	Number  int     `json:"number" dynamodbav:"number"`
+	Matches []Match `json:"matches" dynamodbav:"matches"`
+}
+
+// Standing はスイス式・総当たり戦の順位表の1行です。勝ちは1点、引き分けは0.5点、不戦勝は1点です。
+type Standing struct {
+	Rank     int     `json:"rank" dynamodbav:"rank"`
+	UserID   string  `json:"userId" dynamodbav:"user_id"`
+	Name     string  `json:"name" dynamodbav:"name"`
+	Points   float64 `json:"points" dynamodbav:"points"`
+	Wins     int     `json:"wins" dynamodbav:"wins"`
+	Draws    int     `json:"draws" dynamodbav:"draws"`
+	Losses   int     `json:"losses" dynamodbav:"losses"`
+	Buchholz float64 `json:"buchholz" dynamodbav:"buchholz"`  // 対戦相手の勝ち点の合計（同点時の順位付けに使用）
+	ScoreFor int     `json:"scoreFor" dynamodbav:"score_for"` // クイズの得点の合計
+}
+
+// Tournament は複数のルームにまたがるトーナメントです。
+type Tournament struct {
+	ID           string             `json:"id" dynamodbav:"tournament_id"`
+	Name         string             `json:"name" dynamodbav:"name"`
+	Format       Format             `json:"format" dynamodbav:"format"`
+	HostID       string             `json:"hostId" dynamodbav:"host_id"`
+	Status       Status             `json:"status" dynamodbav:"status"`
+	Seed         int64              `json:"seed,omitempty" dynamodbav:"seed,omitempty"`              // 組み合わせに使用する乱数のシード
+	RoundCount   int                `json:"roundCount,omitempty" dynamodbav:"round_count,omitempty"` // 全ラウンド数
+	CurrentRound int                `json:"currentRound" dynamodbav:"current_round"`
+	Settings     roomtypes.Settings `json:"settings" dynamodbav:"settings"` // 各試合のルーム設定
+	Participants []Participant      `json:"participants" dynamodbav:"participants"`
+	Rounds       []Round            `json:"rounds" dynamodbav:"rounds"`
+	Standings    []Standing         `json:"standings,omitempty" dynamodbav:"standings,omitempty"`
+	Winner       string             `json:"winner,omitempty" dynamodbav:"winner,omitempty"`
+	CreatedAt    time.Time          `json:"createdAt" dynamodbav:"created_at"`
+	FinishedAt   *time.Time         `json:"finishedAt,omitempty" dynamodbav:"finished_at,omitempty"`
+}
+
+// --- リクエスト用の構造体 ---
+
+// CreateRequest はトーナメント作成のリクエストボディです。
+type CreateRequest struct {
+	Name     string             `json:"name"`
+	Format   Format             `json:"format"`
+	HostID   string             `json:"hostId"`
+	Seed     int64              `json:"seed,omitempty"`   // 0の場合は開始時にランダムに決める
+	Rounds   int                `json:"rounds,omitempty"` // スイス式のラウンド数（0の場合は参加人数から決める）
+	Settings roomtypes.Settings `json:"settings"`
+}
+
+// RegisterRequest は参加登録のリクエストボディです。
+type RegisterRequest struct {
+	UserID string `json:"userId"`
+	Name   string `json:"name"`
+}
+
+// HostRequest はホストのみが実行できる操作のリクエストボディです。
+type HostRequest struct {
+	UserID string `json:"userId"`
+}
+
+// ReportRequest はホストが試合結果を登録するリクエストボディです（不参加などでゲームが行われなかった場合に使用します）。
+type ReportRequest struct {
+	UserID string `json:"userId"` // ホストのユーザーID
+	Winner string `json:"winner,omitempty"`
+	Draw   bool   `json:"draw,omitempty"`
+}